@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/consensys/gnark/backend/r1cs"
+	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/frontend"
 	gnarkio "github.com/consensys/gnark/io"
 	"github.com/stretchr/testify/require"
@@ -60,9 +61,12 @@ func (assert *Assert) ProverFailed(r1cs r1cs.R1CS, solution interface{}) {
 //
 // 3. Runs groth16.Prove()
 //
-// 4. Runs groth16.Verify()
+// 4. Runs groth16.Verify() and groth16.BatchVerify()
 //
-// 5. Ensure deserialization(serialization) of generated objects is correct
+// 5. Runs groth16.Prove() and groth16.Verify() with a statically typed witness (see frontend.NewWitness),
+// and groth16.ReadAndProve() and groth16.ReadAndVerify() with a binary witness
+//
+// 6. Ensure deserialization(serialization) of generated objects is correct
 //
 // ensure result vectors a*b=c, and check other properties like random sampling
 func (assert *Assert) ProverSucceeded(r1cs r1cs.R1CS, solution interface{}) {
@@ -90,11 +94,9 @@ func (assert *Assert) ProverSucceeded(r1cs r1cs.R1CS, solution interface{}) {
 	assert.NoError(err, "proving with good solution should not output an error")
 
 	// ensure random sampling; calling prove twice with same input should produce different proof
-	{
-		proof2, err := Prove(r1cs, pk, _solution)
-		assert.NoError(err, "proving with good solution should not output an error")
-		assert.False(reflect.DeepEqual(proof, proof2), "calling prove twice with same input should produce different proof")
-	}
+	proof2, err := Prove(r1cs, pk, _solution)
+	assert.NoError(err, "proving with good solution should not output an error")
+	assert.False(reflect.DeepEqual(proof, proof2), "calling prove twice with same input should produce different proof")
 
 	// verifier
 	{
@@ -102,6 +104,40 @@ func (assert *Assert) ProverSucceeded(r1cs r1cs.R1CS, solution interface{}) {
 		assert.NoError(err, "verifying proof with good solution should not output an error")
 	}
 
+	// batch verifier
+	{
+		err := BatchVerify([]Proof{proof, proof2}, vk, []interface{}{_solution, _solution})
+		assert.NoError(err, "batch verifying proofs with good solution should not output an error")
+	}
+
+	// statically typed witness
+	if circuit, ok := solution.(frontend.Circuit); ok {
+		witness, err := frontend.NewWitness(r1cs.GetCurveID(), circuit)
+		assert.NoError(err, "building witness from circuit should not output an error")
+
+		proof, err := Prove(r1cs, pk, witness)
+		assert.NoError(err, "proving with good witness should not output an error")
+		err = Verify(proof, vk, witness)
+		assert.NoError(err, "verifying proof with good witness should not output an error")
+	}
+
+	// binary witness
+	{
+		var full, public, extracted bytes.Buffer
+		_, err := witness.WriteFull(&full, r1cs, _solution)
+		assert.NoError(err, "writing binary witness should not output an error")
+		_, err = witness.WritePublic(&public, r1cs, _solution)
+		assert.NoError(err, "writing binary public witness should not output an error")
+		_, err = witness.ExtractPublic(&extracted, bytes.NewReader(full.Bytes()))
+		assert.NoError(err, "extracting binary public witness should not output an error")
+		assert.Equal(public.Bytes(), extracted.Bytes(), "extracted public witness doesn't match written public witness")
+
+		proof, err := ReadAndProve(r1cs, pk, &full)
+		assert.NoError(err, "proving with good binary witness should not output an error")
+		err = ReadAndVerify(proof, vk, &public)
+		assert.NoError(err, "verifying proof with good binary public witness should not output an error")
+	}
+
 	// serialization
 	assert.serializationSucceeded(proof, NewProof(r1cs.GetCurveID()))
 	assert.serializationSucceeded(pk, NewProvingKey(r1cs.GetCurveID()))
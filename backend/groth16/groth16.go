@@ -64,7 +64,22 @@ type VerifyingKey interface {
 }
 
 // Verify runs the groth16.Verify algorithm on provided proof with given solution
+//
+// solution must be map[string]interface{}, implement frontend.Circuit ( see frontend.ParseWitness )
+// or be a statically typed witness ( see frontend.NewWitness )
 func Verify(proof Proof, vk VerifyingKey, solution interface{}) error {
+	// statically typed path
+	switch _witness := solution.(type) {
+	case *backend_bls377.Witness:
+		return groth16_bls377.VerifyWitness(proof.(*groth16_bls377.Proof), vk.(*groth16_bls377.VerifyingKey), _witness)
+	case *backend_bls381.Witness:
+		return groth16_bls381.VerifyWitness(proof.(*groth16_bls381.Proof), vk.(*groth16_bls381.VerifyingKey), _witness)
+	case *backend_bn256.Witness:
+		return groth16_bn256.VerifyWitness(proof.(*groth16_bn256.Proof), vk.(*groth16_bn256.VerifyingKey), _witness)
+	case *backend_bw761.Witness:
+		return groth16_bw761.VerifyWitness(proof.(*groth16_bw761.Proof), vk.(*groth16_bw761.VerifyingKey), _witness)
+	}
+
 	_solution, err := frontend.ParseWitness(solution)
 	if err != nil {
 		return err
@@ -83,22 +98,83 @@ func Verify(proof Proof, vk VerifyingKey, solution interface{}) error {
 	}
 }
 
+// BatchVerify verifies several proofs generated with the same proving key at once
+//
+// publicWitnesses[i] is the solution matching proofs[i], see frontend.ParseWitness.
+// The proofs are combined with random coefficients so that the cost is roughly
+// len(proofs)+2 Miller loops and a single final exponentiation. If the batch is invalid,
+// the returned error identifies the first proof that failed verification.
+func BatchVerify(proofs []Proof, vk VerifyingKey, publicWitnesses []interface{}) error {
+	_publicWitnesses := make([]map[string]interface{}, len(publicWitnesses))
+	for i := 0; i < len(publicWitnesses); i++ {
+		_solution, err := frontend.ParseWitness(publicWitnesses[i])
+		if err != nil {
+			return err
+		}
+		_publicWitnesses[i] = _solution
+	}
+
+	switch _vk := vk.(type) {
+	case *groth16_bls377.VerifyingKey:
+		_proofs := make([]*groth16_bls377.Proof, len(proofs))
+		for i := 0; i < len(proofs); i++ {
+			_proofs[i] = proofs[i].(*groth16_bls377.Proof)
+		}
+		return groth16_bls377.BatchVerify(_proofs, _vk, _publicWitnesses)
+	case *groth16_bls381.VerifyingKey:
+		_proofs := make([]*groth16_bls381.Proof, len(proofs))
+		for i := 0; i < len(proofs); i++ {
+			_proofs[i] = proofs[i].(*groth16_bls381.Proof)
+		}
+		return groth16_bls381.BatchVerify(_proofs, _vk, _publicWitnesses)
+	case *groth16_bn256.VerifyingKey:
+		_proofs := make([]*groth16_bn256.Proof, len(proofs))
+		for i := 0; i < len(proofs); i++ {
+			_proofs[i] = proofs[i].(*groth16_bn256.Proof)
+		}
+		return groth16_bn256.BatchVerify(_proofs, _vk, _publicWitnesses)
+	case *groth16_bw761.VerifyingKey:
+		_proofs := make([]*groth16_bw761.Proof, len(proofs))
+		for i := 0; i < len(proofs); i++ {
+			_proofs[i] = proofs[i].(*groth16_bw761.Proof)
+		}
+		return groth16_bw761.BatchVerify(_proofs, _vk, _publicWitnesses)
+	default:
+		panic("unrecognized R1CS curve type")
+	}
+}
+
 // Prove generates the proof of knoweldge of a r1cs with solution.
 // if force flag is set, Prove ignores R1CS solving error (ie invalid solution) and executes
 // the FFTs and MultiExponentiations to compute an (invalid) Proof object
+//
+// solution must be map[string]interface{}, implement frontend.Circuit ( see frontend.ParseWitness )
+// or be a statically typed witness ( see frontend.NewWitness )
 func Prove(r1cs r1cs.R1CS, pk ProvingKey, solution interface{}, force ...bool) (Proof, error) {
 
+	_force := false
+	if len(force) > 0 {
+		_force = force[0]
+	}
+
+	// statically typed path
+	switch _witness := solution.(type) {
+	case *backend_bls377.Witness:
+		return groth16_bls377.ProveWitness(r1cs.(*backend_bls377.R1CS), pk.(*groth16_bls377.ProvingKey), _witness, _force)
+	case *backend_bls381.Witness:
+		return groth16_bls381.ProveWitness(r1cs.(*backend_bls381.R1CS), pk.(*groth16_bls381.ProvingKey), _witness, _force)
+	case *backend_bn256.Witness:
+		return groth16_bn256.ProveWitness(r1cs.(*backend_bn256.R1CS), pk.(*groth16_bn256.ProvingKey), _witness, _force)
+	case *backend_bw761.Witness:
+		return groth16_bw761.ProveWitness(r1cs.(*backend_bw761.R1CS), pk.(*groth16_bw761.ProvingKey), _witness, _force)
+	}
+
 	_solution, err := frontend.ParseWitness(solution)
 
 	if err != nil {
 		return nil, err
 	}
 
-	_force := false
-	if len(force) > 0 {
-		_force = force[0]
-	}
-
 	switch _r1cs := r1cs.(type) {
 	case *backend_bls377.R1CS:
 		return groth16_bls377.Prove(_r1cs, pk.(*groth16_bls377.ProvingKey), _solution, _force)
@@ -113,6 +189,40 @@ func Prove(r1cs r1cs.R1CS, pk ProvingKey, solution interface{}, force ...bool) (
 	}
 }
 
+// ReadAndVerify verifies a proof, reading the public inputs from a binary witness
+// ( see backend/witness )
+func ReadAndVerify(proof Proof, vk VerifyingKey, publicWitness io.Reader) error {
+	var witness r1cs.Witness
+	switch proof.(type) {
+	case *groth16_bls377.Proof:
+		witness = &backend_bls377.Witness{}
+	case *groth16_bls381.Proof:
+		witness = &backend_bls381.Witness{}
+	case *groth16_bn256.Proof:
+		witness = &backend_bn256.Witness{}
+	case *groth16_bw761.Proof:
+		witness = &backend_bw761.Witness{}
+	default:
+		panic("unrecognized R1CS curve type")
+	}
+	if _, err := witness.ReadFrom(publicWitness); err != nil {
+		return err
+	}
+	return Verify(proof, vk, witness)
+}
+
+// ReadAndProve generates the proof of knoweldge of a r1cs, reading the inputs from a binary witness
+// ( see backend/witness )
+// if force flag is set, ReadAndProve ignores R1CS solving error (ie invalid solution) and executes
+// the FFTs and MultiExponentiations to compute an (invalid) Proof object
+func ReadAndProve(_r1cs r1cs.R1CS, pk ProvingKey, witness io.Reader, force ...bool) (Proof, error) {
+	_witness := r1cs.NewWitness(_r1cs.GetCurveID())
+	if _, err := _witness.ReadFrom(witness); err != nil {
+		return nil, err
+	}
+	return Prove(_r1cs, pk, _witness, force...)
+}
+
 // Setup runs groth16.Setup with provided R1CS
 func Setup(r1cs r1cs.R1CS) (ProvingKey, VerifyingKey, error) {
 
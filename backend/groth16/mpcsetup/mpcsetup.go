@@ -0,0 +1,276 @@
+// Copyright 2020 ConsenSys AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mpcsetup implements a multi party computation to generate the groth16 proving and
+// verifying keys, as described in https://eprint.iacr.org/2017/1050.pdf
+//
+// The ceremony has two phases:
+//
+// 1. Phase 1 ("powers of tau") is universal: it supports any circuit up to a given size.
+// Each participant updates the parameters with secret randomness (see Phase1.Contribute())
+// and the coordinator checks the transcript with VerifyPhase1().
+//
+// 2. Phase 2 is circuit specific: it is initialized from the last phase 1 contribution and
+// the R1CS (see InitPhase2()), each participant updates δ (see Phase2.Contribute()) and the
+// coordinator checks the transcript with VerifyPhase2().
+//
+// Finally, ExtractKeys() outputs a groth16.ProvingKey and groth16.VerifyingKey, usable
+// with groth16.Prove and groth16.Verify. The keys are sound as long as one participant
+// of each phase discarded its randomness.
+package mpcsetup
+
+import (
+	"io"
+
+	"github.com/consensys/gurvy"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/r1cs"
+	backend_bls377 "github.com/consensys/gnark/internal/backend/bls377"
+	backend_bls381 "github.com/consensys/gnark/internal/backend/bls381"
+	backend_bn256 "github.com/consensys/gnark/internal/backend/bn256"
+	backend_bw761 "github.com/consensys/gnark/internal/backend/bw761"
+
+	groth16_bls377 "github.com/consensys/gnark/internal/backend/bls377/groth16"
+	groth16_bls381 "github.com/consensys/gnark/internal/backend/bls381/groth16"
+	groth16_bn256 "github.com/consensys/gnark/internal/backend/bn256/groth16"
+	groth16_bw761 "github.com/consensys/gnark/internal/backend/bw761/groth16"
+
+	mpcsetup_bls377 "github.com/consensys/gnark/internal/backend/bls377/groth16/mpcsetup"
+	mpcsetup_bls381 "github.com/consensys/gnark/internal/backend/bls381/groth16/mpcsetup"
+	mpcsetup_bn256 "github.com/consensys/gnark/internal/backend/bn256/groth16/mpcsetup"
+	mpcsetup_bw761 "github.com/consensys/gnark/internal/backend/bw761/groth16/mpcsetup"
+)
+
+// Phase1 represents the state of the ceremony after a phase 1 contribution
+//
+// it's underlying implementation is curve specific (see gnark/internal/backend)
+type Phase1 interface {
+	io.WriterTo
+	io.ReaderFrom
+
+	// Contribute updates the parameters with fresh randomness, which is then discarded
+	Contribute() error
+}
+
+// Phase2 represents the state of the ceremony after a phase 2 contribution
+//
+// it's underlying implementation is curve specific (see gnark/internal/backend)
+type Phase2 interface {
+	io.WriterTo
+	io.ReaderFrom
+
+	// Contribute updates δ with fresh randomness, which is then discarded
+	Contribute() error
+}
+
+// Phase2Evaluations holds the circuit specific parts of the keys computed by InitPhase2
+// which are not updated by the phase 2 contributions
+//
+// it's underlying implementation is curve specific (see gnark/internal/backend)
+type Phase2Evaluations interface {
+	io.WriterTo
+	io.ReaderFrom
+}
+
+// InitPhase1 returns the initial state of phase 1, supporting circuits up to 2**power constraints
+func InitPhase1(curveID gurvy.ID, power int) Phase1 {
+	switch curveID {
+	case gurvy.BN256:
+		srs1 := mpcsetup_bn256.InitPhase1(power)
+		return &srs1
+	case gurvy.BLS377:
+		srs1 := mpcsetup_bls377.InitPhase1(power)
+		return &srs1
+	case gurvy.BLS381:
+		srs1 := mpcsetup_bls381.InitPhase1(power)
+		return &srs1
+	case gurvy.BW761:
+		srs1 := mpcsetup_bw761.InitPhase1(power)
+		return &srs1
+	default:
+		panic("not implemented")
+	}
+}
+
+// VerifyPhase1 checks that each contribution in c0, c1, c ... was correctly computed from the
+// previous one
+func VerifyPhase1(c0, c1 Phase1, c ...Phase1) error {
+	contribs := append([]Phase1{c0, c1}, c...)
+	for i := 0; i < len(contribs)-1; i++ {
+		var err error
+		switch current := contribs[i].(type) {
+		case *mpcsetup_bls377.Phase1:
+			err = mpcsetup_bls377.VerifyPhase1(current, contribs[i+1].(*mpcsetup_bls377.Phase1))
+		case *mpcsetup_bls381.Phase1:
+			err = mpcsetup_bls381.VerifyPhase1(current, contribs[i+1].(*mpcsetup_bls381.Phase1))
+		case *mpcsetup_bn256.Phase1:
+			err = mpcsetup_bn256.VerifyPhase1(current, contribs[i+1].(*mpcsetup_bn256.Phase1))
+		case *mpcsetup_bw761.Phase1:
+			err = mpcsetup_bw761.VerifyPhase1(current, contribs[i+1].(*mpcsetup_bw761.Phase1))
+		default:
+			panic("unrecognized Phase1 curve type")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InitPhase2 returns the initial state of phase 2 and the circuit evaluations, computed from the
+// last (verified) phase 1 contribution and the R1CS
+func InitPhase2(r1cs r1cs.R1CS, srs1 Phase1) (Phase2, Phase2Evaluations, error) {
+	switch _r1cs := r1cs.(type) {
+	case *backend_bls377.R1CS:
+		srs2, evals, err := mpcsetup_bls377.InitPhase2(_r1cs, srs1.(*mpcsetup_bls377.Phase1))
+		if err != nil {
+			return nil, nil, err
+		}
+		return &srs2, &evals, nil
+	case *backend_bls381.R1CS:
+		srs2, evals, err := mpcsetup_bls381.InitPhase2(_r1cs, srs1.(*mpcsetup_bls381.Phase1))
+		if err != nil {
+			return nil, nil, err
+		}
+		return &srs2, &evals, nil
+	case *backend_bn256.R1CS:
+		srs2, evals, err := mpcsetup_bn256.InitPhase2(_r1cs, srs1.(*mpcsetup_bn256.Phase1))
+		if err != nil {
+			return nil, nil, err
+		}
+		return &srs2, &evals, nil
+	case *backend_bw761.R1CS:
+		srs2, evals, err := mpcsetup_bw761.InitPhase2(_r1cs, srs1.(*mpcsetup_bw761.Phase1))
+		if err != nil {
+			return nil, nil, err
+		}
+		return &srs2, &evals, nil
+	default:
+		panic("unrecognized R1CS curve type")
+	}
+}
+
+// VerifyPhase2 checks that each contribution in c0, c1, c ... was correctly computed from the
+// previous one
+func VerifyPhase2(c0, c1 Phase2, c ...Phase2) error {
+	contribs := append([]Phase2{c0, c1}, c...)
+	for i := 0; i < len(contribs)-1; i++ {
+		var err error
+		switch current := contribs[i].(type) {
+		case *mpcsetup_bls377.Phase2:
+			err = mpcsetup_bls377.VerifyPhase2(current, contribs[i+1].(*mpcsetup_bls377.Phase2))
+		case *mpcsetup_bls381.Phase2:
+			err = mpcsetup_bls381.VerifyPhase2(current, contribs[i+1].(*mpcsetup_bls381.Phase2))
+		case *mpcsetup_bn256.Phase2:
+			err = mpcsetup_bn256.VerifyPhase2(current, contribs[i+1].(*mpcsetup_bn256.Phase2))
+		case *mpcsetup_bw761.Phase2:
+			err = mpcsetup_bw761.VerifyPhase2(current, contribs[i+1].(*mpcsetup_bw761.Phase2))
+		default:
+			panic("unrecognized Phase2 curve type")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExtractKeys returns the groth16 proving and verifying keys of the circuit, from the last
+// (verified) contributions of phase 1 and phase 2, and the evaluations returned by InitPhase2
+func ExtractKeys(r1cs r1cs.R1CS, srs1 Phase1, srs2 Phase2, evals Phase2Evaluations) (groth16.ProvingKey, groth16.VerifyingKey, error) {
+	switch _r1cs := r1cs.(type) {
+	case *backend_bls377.R1CS:
+		var pk groth16_bls377.ProvingKey
+		var vk groth16_bls377.VerifyingKey
+		if err := mpcsetup_bls377.ExtractKeys(_r1cs, srs1.(*mpcsetup_bls377.Phase1), srs2.(*mpcsetup_bls377.Phase2), evals.(*mpcsetup_bls377.Phase2Evaluations), &pk, &vk); err != nil {
+			return nil, nil, err
+		}
+		return &pk, &vk, nil
+	case *backend_bls381.R1CS:
+		var pk groth16_bls381.ProvingKey
+		var vk groth16_bls381.VerifyingKey
+		if err := mpcsetup_bls381.ExtractKeys(_r1cs, srs1.(*mpcsetup_bls381.Phase1), srs2.(*mpcsetup_bls381.Phase2), evals.(*mpcsetup_bls381.Phase2Evaluations), &pk, &vk); err != nil {
+			return nil, nil, err
+		}
+		return &pk, &vk, nil
+	case *backend_bn256.R1CS:
+		var pk groth16_bn256.ProvingKey
+		var vk groth16_bn256.VerifyingKey
+		if err := mpcsetup_bn256.ExtractKeys(_r1cs, srs1.(*mpcsetup_bn256.Phase1), srs2.(*mpcsetup_bn256.Phase2), evals.(*mpcsetup_bn256.Phase2Evaluations), &pk, &vk); err != nil {
+			return nil, nil, err
+		}
+		return &pk, &vk, nil
+	case *backend_bw761.R1CS:
+		var pk groth16_bw761.ProvingKey
+		var vk groth16_bw761.VerifyingKey
+		if err := mpcsetup_bw761.ExtractKeys(_r1cs, srs1.(*mpcsetup_bw761.Phase1), srs2.(*mpcsetup_bw761.Phase2), evals.(*mpcsetup_bw761.Phase2Evaluations), &pk, &vk); err != nil {
+			return nil, nil, err
+		}
+		return &pk, &vk, nil
+	default:
+		panic("unrecognized R1CS curve type")
+	}
+}
+
+// NewPhase1 instantiates a curve-typed Phase1 and returns an interface object
+// This function exists for serialization purposes
+func NewPhase1(curveID gurvy.ID) Phase1 {
+	switch curveID {
+	case gurvy.BN256:
+		return &mpcsetup_bn256.Phase1{}
+	case gurvy.BLS377:
+		return &mpcsetup_bls377.Phase1{}
+	case gurvy.BLS381:
+		return &mpcsetup_bls381.Phase1{}
+	case gurvy.BW761:
+		return &mpcsetup_bw761.Phase1{}
+	default:
+		panic("not implemented")
+	}
+}
+
+// NewPhase2 instantiates a curve-typed Phase2 and returns an interface object
+// This function exists for serialization purposes
+func NewPhase2(curveID gurvy.ID) Phase2 {
+	switch curveID {
+	case gurvy.BN256:
+		return &mpcsetup_bn256.Phase2{}
+	case gurvy.BLS377:
+		return &mpcsetup_bls377.Phase2{}
+	case gurvy.BLS381:
+		return &mpcsetup_bls381.Phase2{}
+	case gurvy.BW761:
+		return &mpcsetup_bw761.Phase2{}
+	default:
+		panic("not implemented")
+	}
+}
+
+// NewPhase2Evaluations instantiates a curve-typed Phase2Evaluations and returns an interface object
+// This function exists for serialization purposes
+func NewPhase2Evaluations(curveID gurvy.ID) Phase2Evaluations {
+	switch curveID {
+	case gurvy.BN256:
+		return &mpcsetup_bn256.Phase2Evaluations{}
+	case gurvy.BLS377:
+		return &mpcsetup_bls377.Phase2Evaluations{}
+	case gurvy.BLS381:
+		return &mpcsetup_bls381.Phase2Evaluations{}
+	case gurvy.BW761:
+		return &mpcsetup_bw761.Phase2Evaluations{}
+	default:
+		panic("not implemented")
+	}
+}
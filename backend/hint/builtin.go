@@ -0,0 +1,77 @@
+// Copyright 2020 ConsenSys AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hint
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gurvy"
+	bls377fr "github.com/consensys/gurvy/bls377/fr"
+	bls381fr "github.com/consensys/gurvy/bls381/fr"
+	bn256fr "github.com/consensys/gurvy/bn256/fr"
+	bw761fr "github.com/consensys/gurvy/bw761/fr"
+)
+
+// the hints used by the frontend are registered, so that a deserialized R1CS can be solved
+func init() {
+	Register(InvZero)
+	Register(Quo)
+	Register(Rem)
+}
+
+var errDivisionByZero = errors.New("division by zero")
+
+// InvZero computes the inverse of inputs[0] in the scalar field of curveID, or 0 if inputs[0] == 0
+func InvZero(curveID gurvy.ID, inputs []*big.Int, result *big.Int) error {
+	if result.ModInverse(inputs[0], Modulus(curveID)) == nil {
+		result.SetUint64(0)
+	}
+	return nil
+}
+
+// Quo computes the integer quotient of inputs[0] by inputs[1] (truncated, see big.Int.Quo)
+func Quo(curveID gurvy.ID, inputs []*big.Int, result *big.Int) error {
+	if inputs[1].Sign() == 0 {
+		return errDivisionByZero
+	}
+	result.Quo(inputs[0], inputs[1])
+	return nil
+}
+
+// Rem computes the remainder of the integer division of inputs[0] by inputs[1] (see big.Int.Rem)
+func Rem(curveID gurvy.ID, inputs []*big.Int, result *big.Int) error {
+	if inputs[1].Sign() == 0 {
+		return errDivisionByZero
+	}
+	result.Rem(inputs[0], inputs[1])
+	return nil
+}
+
+// Modulus returns the order of the scalar field of curveID
+func Modulus(curveID gurvy.ID) *big.Int {
+	switch curveID {
+	case gurvy.BN256:
+		return bn256fr.Modulus()
+	case gurvy.BLS377:
+		return bls377fr.Modulus()
+	case gurvy.BLS381:
+		return bls381fr.Modulus()
+	case gurvy.BW761:
+		return bw761fr.Modulus()
+	default:
+		panic("not implemented")
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 ConsenSys AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hint provides the registry of hint functions.
+//
+// A hint is a Go function computing the value of a wire from the values of other wires
+// while solving a R1CS (see frontend.ConstraintSystem.NewHint). Hints are not constraints:
+// a circuit using a hint must constrain its output.
+//
+// A R1CS references a hint by its ID, such that it can be serialized; a process solving
+// a deserialized R1CS must Register the hint functions it uses (typically in an init() function).
+package hint
+
+import (
+	"errors"
+	"hash/fnv"
+	"math/big"
+	"reflect"
+	"runtime"
+	"sync"
+
+	"github.com/consensys/gurvy"
+)
+
+// ErrNotFound is returned when solving a R1CS referencing a hint that is not registered
+var ErrNotFound = errors.New("hint function not registered")
+
+// Function computes the value of a hint output wire in result, from the values of its
+// inputs. Values are in regular form, in the scalar field of curveID (result is reduced
+// modulo the field order by the solver).
+type Function func(curveID gurvy.ID, inputs []*big.Int, result *big.Int) error
+
+// ID identifies a hint Function in a R1CS
+type ID uint32
+
+var (
+	registry   = make(map[ID]Function)
+	registryMu sync.RWMutex
+)
+
+// UUID returns the ID of f, derived from its fully qualified name
+//
+// f must be a named function, the ID of a closure isn't stable from one build to another
+func UUID(f Function) ID {
+	name := runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return ID(h.Sum32())
+}
+
+// Register adds f to the registry, and returns its ID
+func Register(f Function) ID {
+	id := UUID(f)
+	registryMu.Lock()
+	registry[id] = f
+	registryMu.Unlock()
+	return id
+}
+
+// Lookup returns the registered Function with this id
+func Lookup(id ID) (Function, bool) {
+	registryMu.RLock()
+	f, ok := registry[id]
+	registryMu.RUnlock()
+	return f, ok
+}
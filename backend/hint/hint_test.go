@@ -0,0 +1,60 @@
+// Copyright 2020 ConsenSys AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hint
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gurvy"
+)
+
+func double(curveID gurvy.ID, inputs []*big.Int, result *big.Int) error {
+	result.Lsh(inputs[0], 1)
+	return nil
+}
+
+func square(curveID gurvy.ID, inputs []*big.Int, result *big.Int) error {
+	result.Mul(inputs[0], inputs[0])
+	return nil
+}
+
+func TestRegistry(t *testing.T) {
+	if UUID(double) == UUID(square) {
+		t.Fatal("distinct functions should have distinct IDs")
+	}
+	if UUID(double) != UUID(double) {
+		t.Fatal("UUID should be deterministic")
+	}
+	if _, ok := Lookup(UUID(square)); ok {
+		t.Fatal("square is not registered")
+	}
+
+	id := Register(double)
+	if id != UUID(double) {
+		t.Fatal("Register should return the function UUID")
+	}
+	f, ok := Lookup(id)
+	if !ok {
+		t.Fatal("double should be registered")
+	}
+	var result big.Int
+	if err := f(gurvy.BN256, []*big.Int{big.NewInt(21)}, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Int64() != 42 {
+		t.Fatal("registered function doesn't match")
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 ConsenSys AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plonk
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/consensys/gnark/backend/r1cs"
+	"github.com/consensys/gnark/frontend"
+	"github.com/stretchr/testify/require"
+)
+
+// Assert is a helper to test circuits
+type Assert struct {
+	*require.Assertions
+}
+
+// NewAssert returns an Assert helper
+func NewAssert(t *testing.T) *Assert {
+	return &Assert{require.New(t)}
+}
+
+// ProverFailed check that a solution does NOT solve a circuit, and that a proof
+// forced with this solution doesn't verify
+//
+// solution must be map[string]interface{} or must implement frontend.Circuit
+// ( see frontend.ParseWitness )
+func (assert *Assert) ProverFailed(sparseR1CS r1cs.SparseR1CS, solution interface{}) {
+	_solution := assert.parseSolution(solution)
+
+	// setup
+	pk, vk := assert.setup(sparseR1CS)
+
+	_, err := Prove(sparseR1CS, pk, _solution)
+	assert.Error(err, "proving with bad solution should output an error")
+
+	// a proof computed from a bad solution must be rejected
+	proof, err := Prove(sparseR1CS, pk, _solution, true)
+	assert.NoError(err, "proving with force flag should not output an error")
+
+	err = Verify(proof, vk, _solution)
+	assert.Error(err, "verifying proof with bad solution should output an error")
+}
+
+// ProverSucceeded check that a solution solves a circuit
+//
+// solution must be map[string]interface{} or must implement frontend.Circuit
+// ( see frontend.ParseWitness )
+//
+// 1. Generates a random SRS and runs plonk.Setup()
+//
+// 2. Solves the sparse R1CS
+//
+// 3. Runs plonk.Prove()
+//
+// 4. Runs plonk.Verify()
+//
+// 5. Ensure deserialization(serialization) of generated objects is correct
+func (assert *Assert) ProverSucceeded(sparseR1CS r1cs.SparseR1CS, solution interface{}) {
+	_solution := assert.parseSolution(solution)
+
+	// setup
+	pk, vk := assert.setup(sparseR1CS)
+
+	// ensure expected Values are computed correctly
+	assert.SolvingSucceeded(sparseR1CS, _solution)
+
+	// prover
+	proof, err := Prove(sparseR1CS, pk, _solution)
+	assert.NoError(err, "proving with good solution should not output an error")
+
+	// ensure random sampling; calling prove twice with same input should produce different proof
+	{
+		proof2, err := Prove(sparseR1CS, pk, _solution)
+		assert.NoError(err, "proving with good solution should not output an error")
+		assert.False(reflect.DeepEqual(proof, proof2), "calling prove twice with same input should produce different proof")
+	}
+
+	// verifier
+	{
+		err := Verify(proof, vk, _solution)
+		assert.NoError(err, "verifying proof with good solution should not output an error")
+	}
+
+	// serialization
+	assert.serializationSucceeded(proof, NewProof(sparseR1CS.GetCurveID()))
+	assert.serializationSucceeded(pk, NewProvingKey(sparseR1CS.GetCurveID()))
+	assert.serializationSucceeded(vk, NewVerifyingKey(sparseR1CS.GetCurveID()))
+}
+
+// setup runs plonk.Setup() with a random SRS large enough for the sparse R1CS
+func (assert *Assert) setup(sparseR1CS r1cs.SparseR1CS) (ProvingKey, VerifyingKey) {
+	// the number of public inputs and constraints is bounded by nbWires + nbConstraints
+	srs, err := RandomSRS(sparseR1CS.GetCurveID(), sparseR1CS.GetNbWires()+sparseR1CS.GetNbConstraints())
+	assert.NoError(err)
+
+	assert.serializationSucceeded(srs, NewSRS(sparseR1CS.GetCurveID()))
+
+	pk, vk, err := Setup(sparseR1CS, srs)
+	assert.NoError(err)
+
+	return pk, vk
+}
+
+func (assert *Assert) serializationSucceeded(from io.WriterTo, to io.ReaderFrom) {
+	var buf bytes.Buffer
+	written, err := from.WriteTo(&buf)
+	assert.NoError(err, "serializing to buffer failed")
+
+	read, err := to.ReadFrom(&buf)
+	assert.NoError(err, "desererializing from buffer failed")
+
+	assert.EqualValues(written, read, "number of bytes read and written don't match")
+}
+
+// SolvingSucceeded Verifies that the sparse R1CS is solved with the given solution, without executing plonk workflow
+//
+// solution must be map[string]interface{} or must implement frontend.Circuit
+// ( see frontend.ParseWitness )
+func (assert *Assert) SolvingSucceeded(sparseR1CS r1cs.SparseR1CS, solution interface{}) {
+	assert.NoError(sparseR1CS.IsSolved(assert.parseSolution(solution)))
+}
+
+// SolvingFailed Verifies that the sparse R1CS is not solved with the given solution, without executing plonk workflow
+//
+// solution must be map[string]interface{} or must implement frontend.Circuit
+// ( see frontend.ParseWitness )
+func (assert *Assert) SolvingFailed(sparseR1CS r1cs.SparseR1CS, solution interface{}) {
+	assert.Error(sparseR1CS.IsSolved(assert.parseSolution(solution)))
+}
+
+func (assert *Assert) parseSolution(solution interface{}) map[string]interface{} {
+	_solution, err := frontend.ParseWitness(solution)
+	assert.NoError(err)
+	return _solution
+}
@@ -0,0 +1,254 @@
+// Copyright 2020 ConsenSys AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plonk implements PLONK zkSNARK workflow (https://eprint.iacr.org/2019/953.pdf)
+package plonk
+
+import (
+	"io"
+
+	"github.com/consensys/gurvy"
+
+	"github.com/consensys/gnark/backend/r1cs"
+	"github.com/consensys/gnark/frontend"
+	backend_bls377 "github.com/consensys/gnark/internal/backend/bls377"
+	backend_bls381 "github.com/consensys/gnark/internal/backend/bls381"
+	backend_bn256 "github.com/consensys/gnark/internal/backend/bn256"
+	backend_bw761 "github.com/consensys/gnark/internal/backend/bw761"
+
+	kzg_bls377 "github.com/consensys/gnark/crypto/polynomial/kzg/bls377"
+	kzg_bls381 "github.com/consensys/gnark/crypto/polynomial/kzg/bls381"
+	kzg_bn256 "github.com/consensys/gnark/crypto/polynomial/kzg/bn256"
+	kzg_bw761 "github.com/consensys/gnark/crypto/polynomial/kzg/bw761"
+
+	plonk_bls377 "github.com/consensys/gnark/internal/backend/bls377/plonk"
+	plonk_bls381 "github.com/consensys/gnark/internal/backend/bls381/plonk"
+	plonk_bn256 "github.com/consensys/gnark/internal/backend/bn256/plonk"
+	plonk_bw761 "github.com/consensys/gnark/internal/backend/bw761/plonk"
+)
+
+// Proof represents a PLONK proof generated by plonk.Prove
+//
+// it's underlying implementation is curve specific (see gnark/internal/backend)
+type Proof interface {
+	io.WriterTo
+	io.ReaderFrom
+}
+
+// ProvingKey represents a PLONK ProvingKey
+//
+// it's underlying implementation is curve specific (see gnark/internal/backend)
+type ProvingKey interface {
+	io.WriterTo
+	io.ReaderFrom
+}
+
+// VerifyingKey represents a PLONK VerifyingKey
+//
+// it's underlying implementation is curve specific (see gnark/internal/backend)
+type VerifyingKey interface {
+	io.WriterTo
+	io.ReaderFrom
+}
+
+// SRS represents a PLONK structured reference string. It is universal: the same SRS
+// can be used to setup any circuit which size is small enough
+//
+// it's underlying implementation is curve specific (see gnark/internal/backend)
+type SRS interface {
+	io.WriterTo
+	io.ReaderFrom
+}
+
+// Setup prepares the public data associated to a circuit, using the provided SRS
+func Setup(sparseR1CS r1cs.SparseR1CS, srs SRS) (ProvingKey, VerifyingKey, error) {
+
+	switch _sparseR1CS := sparseR1CS.(type) {
+	case *backend_bls377.SparseR1CS:
+		var pk plonk_bls377.ProvingKey
+		var vk plonk_bls377.VerifyingKey
+		if err := plonk_bls377.Setup(_sparseR1CS, srs.(*kzg_bls377.SRS), &pk, &vk); err != nil {
+			return nil, nil, err
+		}
+		return &pk, &vk, nil
+	case *backend_bls381.SparseR1CS:
+		var pk plonk_bls381.ProvingKey
+		var vk plonk_bls381.VerifyingKey
+		if err := plonk_bls381.Setup(_sparseR1CS, srs.(*kzg_bls381.SRS), &pk, &vk); err != nil {
+			return nil, nil, err
+		}
+		return &pk, &vk, nil
+	case *backend_bn256.SparseR1CS:
+		var pk plonk_bn256.ProvingKey
+		var vk plonk_bn256.VerifyingKey
+		if err := plonk_bn256.Setup(_sparseR1CS, srs.(*kzg_bn256.SRS), &pk, &vk); err != nil {
+			return nil, nil, err
+		}
+		return &pk, &vk, nil
+	case *backend_bw761.SparseR1CS:
+		var pk plonk_bw761.ProvingKey
+		var vk plonk_bw761.VerifyingKey
+		if err := plonk_bw761.Setup(_sparseR1CS, srs.(*kzg_bw761.SRS), &pk, &vk); err != nil {
+			return nil, nil, err
+		}
+		return &pk, &vk, nil
+	default:
+		panic("unrecognized sparse R1CS curve type")
+	}
+}
+
+// Prove generates a PLONK proof that the solution solves the sparse R1CS
+// if force flag is set, Prove ignores the solving error (ie invalid solution) and computes
+// an (invalid) Proof object
+func Prove(sparseR1CS r1cs.SparseR1CS, pk ProvingKey, solution interface{}, force ...bool) (Proof, error) {
+
+	_solution, err := frontend.ParseWitness(solution)
+	if err != nil {
+		return nil, err
+	}
+
+	_force := false
+	if len(force) > 0 {
+		_force = force[0]
+	}
+
+	switch _sparseR1CS := sparseR1CS.(type) {
+	case *backend_bls377.SparseR1CS:
+		return plonk_bls377.Prove(_sparseR1CS, pk.(*plonk_bls377.ProvingKey), _solution, _force)
+	case *backend_bls381.SparseR1CS:
+		return plonk_bls381.Prove(_sparseR1CS, pk.(*plonk_bls381.ProvingKey), _solution, _force)
+	case *backend_bn256.SparseR1CS:
+		return plonk_bn256.Prove(_sparseR1CS, pk.(*plonk_bn256.ProvingKey), _solution, _force)
+	case *backend_bw761.SparseR1CS:
+		return plonk_bw761.Prove(_sparseR1CS, pk.(*plonk_bw761.ProvingKey), _solution, _force)
+	default:
+		panic("unrecognized sparse R1CS curve type")
+	}
+}
+
+// Verify runs the plonk.Verify algorithm on provided proof with given solution
+func Verify(proof Proof, vk VerifyingKey, solution interface{}) error {
+	_solution, err := frontend.ParseWitness(solution)
+	if err != nil {
+		return err
+	}
+
+	switch _proof := proof.(type) {
+	case *plonk_bls377.Proof:
+		return plonk_bls377.Verify(_proof, vk.(*plonk_bls377.VerifyingKey), _solution)
+	case *plonk_bls381.Proof:
+		return plonk_bls381.Verify(_proof, vk.(*plonk_bls381.VerifyingKey), _solution)
+	case *plonk_bn256.Proof:
+		return plonk_bn256.Verify(_proof, vk.(*plonk_bn256.VerifyingKey), _solution)
+	case *plonk_bw761.Proof:
+		return plonk_bw761.Verify(_proof, vk.(*plonk_bw761.VerifyingKey), _solution)
+	default:
+		panic("unrecognized proof curve type")
+	}
+}
+
+// RandomSRS returns a SRS supporting sparse R1CS up to size constraints (public inputs included)
+//
+// the SRS toxic waste is sampled at random and discarded: this is for test purposes only,
+// a SRS used in production must be the output of a multi party computation
+func RandomSRS(curveID gurvy.ID, size uint64) (SRS, error) {
+	switch curveID {
+	case gurvy.BN256:
+		return plonk_bn256.NewSRS(size)
+	case gurvy.BLS377:
+		return plonk_bls377.NewSRS(size)
+	case gurvy.BLS381:
+		return plonk_bls381.NewSRS(size)
+	case gurvy.BW761:
+		return plonk_bw761.NewSRS(size)
+	default:
+		panic("not implemented")
+	}
+}
+
+// NewSRS instantiates a curve-typed SRS and returns an interface object
+// This function exists for serialization purposes
+func NewSRS(curveID gurvy.ID) SRS {
+	var srs SRS
+	switch curveID {
+	case gurvy.BN256:
+		srs = &kzg_bn256.SRS{}
+	case gurvy.BLS377:
+		srs = &kzg_bls377.SRS{}
+	case gurvy.BLS381:
+		srs = &kzg_bls381.SRS{}
+	case gurvy.BW761:
+		srs = &kzg_bw761.SRS{}
+	default:
+		panic("not implemented")
+	}
+	return srs
+}
+
+// NewProvingKey instantiates a curve-typed ProvingKey and returns an interface object
+// This function exists for serialization purposes
+func NewProvingKey(curveID gurvy.ID) ProvingKey {
+	var pk ProvingKey
+	switch curveID {
+	case gurvy.BN256:
+		pk = &plonk_bn256.ProvingKey{}
+	case gurvy.BLS377:
+		pk = &plonk_bls377.ProvingKey{}
+	case gurvy.BLS381:
+		pk = &plonk_bls381.ProvingKey{}
+	case gurvy.BW761:
+		pk = &plonk_bw761.ProvingKey{}
+	default:
+		panic("not implemented")
+	}
+	return pk
+}
+
+// NewVerifyingKey instantiates a curve-typed VerifyingKey and returns an interface object
+// This function exists for serialization purposes
+func NewVerifyingKey(curveID gurvy.ID) VerifyingKey {
+	var vk VerifyingKey
+	switch curveID {
+	case gurvy.BN256:
+		vk = &plonk_bn256.VerifyingKey{}
+	case gurvy.BLS377:
+		vk = &plonk_bls377.VerifyingKey{}
+	case gurvy.BLS381:
+		vk = &plonk_bls381.VerifyingKey{}
+	case gurvy.BW761:
+		vk = &plonk_bw761.VerifyingKey{}
+	default:
+		panic("not implemented")
+	}
+	return vk
+}
+
+// NewProof instantiates a curve-typed Proof and returns an interface object
+// This function exists for serialization purposes
+func NewProof(curveID gurvy.ID) Proof {
+	var proof Proof
+	switch curveID {
+	case gurvy.BN256:
+		proof = &plonk_bn256.Proof{}
+	case gurvy.BLS377:
+		proof = &plonk_bls377.Proof{}
+	case gurvy.BLS381:
+		proof = &plonk_bls381.Proof{}
+	case gurvy.BW761:
+		proof = &plonk_bw761.Proof{}
+	default:
+		panic("not implemented")
+	}
+	return proof
+}
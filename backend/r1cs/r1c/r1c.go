@@ -14,6 +14,8 @@
 
 package r1c
 
+import "github.com/consensys/gnark/backend/hint"
+
 // LinearExpression represent a linear expression of variables
 type LinearExpression []Term
 
@@ -25,6 +27,23 @@ type R1C struct {
 	Solver SolvingMethod
 }
 
+// Hint records the computation of a wire by a hint function, the solver computes it
+// from the value of the Inputs before solving the first constraint where the wire appears
+type Hint struct {
+	ID     hint.ID            // ID of the registered hint.Function
+	Inputs []LinearExpression // values passed to the hint function
+	WireID int                // wire computed by the hint
+}
+
+// SparseR1C used to compute the wires of a sparse R1CS (PLONK arithmetization)
+// L⋅xa + R⋅xb + O⋅xc + M⋅(xa⋅xb) + K == 0
+// where xa, xb and xc are the wires of the terms L, R and O
+type SparseR1C struct {
+	L, R, O Term
+	M, K    int // coefficient IDs of the multiplicative and constant terms
+	Solver  SolvingMethod
+}
+
 // SolvingMethod is used by the R1CS solver
 // note: it is not in backend/r1cs to avoid an import cycle
 type SolvingMethod uint8
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package r1cs expose the R1CS (rank-1 constraint system) interface
+// Package r1cs expose the R1CS (rank-1 constraint system) and SparseR1CS (PLONK arithmetization) interfaces
 package r1cs
 
 import (
@@ -55,3 +55,63 @@ func New(curveID gurvy.ID) R1CS {
 	}
 	return r1cs
 }
+
+// Witness represents the values of the inputs of a R1CS, ordered like the R1CS wires
+// it's underlying implementation is curve specific (i.e bn256/Witness, ...)
+// see frontend.NewWitness
+type Witness interface {
+	io.WriterTo
+	io.ReaderFrom
+	GetCurveID() gurvy.ID
+}
+
+// NewWitness instantiate a concrete curved-typed Witness and return a Witness interface
+// This method exists for (de)serialization purposes
+func NewWitness(curveID gurvy.ID) Witness {
+	var witness Witness
+	switch curveID {
+	case gurvy.BN256:
+		witness = &backend_bn256.Witness{}
+	case gurvy.BLS377:
+		witness = &backend_bls377.Witness{}
+	case gurvy.BLS381:
+		witness = &backend_bls381.Witness{}
+	case gurvy.BW761:
+		witness = &backend_bw761.Witness{}
+	default:
+		panic("not implemented")
+	}
+	return witness
+}
+
+// SparseR1CS represents a sparse constraint system (PLONK arithmetization)
+// each constraint is of the form qL⋅xa + qR⋅xb + qO⋅xc + qM⋅(xa⋅xb) + qC == 0
+// it's underlying implementation is curve specific (i.e bn256/SparseR1CS, ...)
+type SparseR1CS interface {
+	io.WriterTo
+	io.ReaderFrom
+	IsSolved(solution map[string]interface{}) error
+	GetNbConstraints() uint64
+	GetNbWires() uint64
+	GetNbCoefficients() int
+	GetCurveID() gurvy.ID
+}
+
+// NewSparse instantiate a concrete curved-typed SparseR1CS and return a SparseR1CS interface
+// This method exists for (de)serialization purposes
+func NewSparse(curveID gurvy.ID) SparseR1CS {
+	var r1cs SparseR1CS
+	switch curveID {
+	case gurvy.BN256:
+		r1cs = &backend_bn256.SparseR1CS{}
+	case gurvy.BLS377:
+		r1cs = &backend_bls377.SparseR1CS{}
+	case gurvy.BLS381:
+		r1cs = &backend_bls381.SparseR1CS{}
+	case gurvy.BW761:
+		r1cs = &backend_bw761.SparseR1CS{}
+	default:
+		panic("not implemented")
+	}
+	return r1cs
+}
@@ -32,10 +32,12 @@ func (r1cs *UntypedR1CS) toBLS381() *bls381backend.R1CS {
 		PublicWires:     r1cs.PublicWires,
 		NbConstraints:   r1cs.NbConstraints,
 		NbCOConstraints: r1cs.NbCOConstraints,
+		Levels:          r1cs.Levels,
 		Constraints:     r1cs.Constraints,
 		Coefficients:    make([]fr.Element, len(r1cs.Coefficients)),
 		Logs:            r1cs.Logs,
 		DebugInfo:       r1cs.DebugInfo,
+		Hints:           r1cs.Hints,
 	}
 
 	for i := 0; i < len(r1cs.Coefficients); i++ {
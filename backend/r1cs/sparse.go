@@ -0,0 +1,496 @@
+// Copyright 2020 ConsenSys AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package r1cs
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/r1cs/r1c"
+	"github.com/consensys/gurvy"
+)
+
+// ToSparseR1CS splits the rank-1 constraints of the UntypedR1CS into sparse constraints
+// (qL⋅xa + qR⋅xb + qO⋅xc + qM⋅(xa⋅xb) + qC == 0), converts the big.Int coefficients
+// to field elements in the basefield of the provided curveID and returns a SparseR1CS
+//
+// the terms on the ONE_WIRE are folded into the constant qC, and intermediate (internal) wires
+// are created when a linear expression has more than one wire
+func (r1cs *UntypedR1CS) ToSparseR1CS(curveID gurvy.ID) SparseR1CS {
+	return r1cs.toSparse().ToSparseR1CS(curveID)
+}
+
+// sparseTerm is a linear expression with at most one wire: coeff⋅wire + constant
+// if the expression is a constant, wire is the ONE_WIRE and coeff is 0
+type sparseTerm struct {
+	wire            int
+	coeff, constant big.Int
+}
+
+// linExp is a normalized linear expression: each wire appears once with a non zero
+// coefficient, the terms on the ONE_WIRE are summed in constant
+type linExp struct {
+	wires    []int
+	coeffs   []big.Int
+	constant big.Int
+}
+
+// sparseBuilder records the sparse constraints while walking through the rank-1 constraints
+//
+// intermediate wires are appended after the R1CS wires (with ids >= r1cs.NbWires), and moved
+// at the end of the internal wires once all constraints are split
+type sparseBuilder struct {
+	r1cs        *UntypedR1CS
+	oneWire     int
+	solved      []bool            // solved[i] is set if wire i is an input or has been computed by a previous constraint
+	hints       map[int]*r1c.Hint // hint computing the wire, indexed by wire ID
+	coeffs      []big.Int
+	coeffsIDs   map[string]int
+	constraints []r1c.SparseR1C // computational constraints
+	assertions  []r1c.SparseR1C
+}
+
+func (r1cs *UntypedR1CS) toSparse() *UntypedSparseR1CS {
+	nbWires := int(r1cs.NbWires)
+	nbInternal := nbWires - int(r1cs.NbPublicWires+r1cs.NbSecretWires)
+
+	builder := sparseBuilder{
+		r1cs:      r1cs,
+		oneWire:   -1,
+		solved:    make([]bool, nbWires),
+		hints:     make(map[int]*r1c.Hint, len(r1cs.Hints)),
+		coeffsIDs: make(map[string]int),
+	}
+	for i := 0; i < len(r1cs.Hints); i++ {
+		builder.hints[r1cs.Hints[i].WireID] = &r1cs.Hints[i]
+	}
+
+	// inputs are known before we solve the first constraint
+	for i := nbInternal; i < nbWires; i++ {
+		builder.solved[i] = true
+	}
+	for i := 0; i < len(r1cs.PublicWires); i++ {
+		if r1cs.PublicWires[i] == backend.OneWire {
+			builder.oneWire = nbWires - int(r1cs.NbPublicWires) + i
+		}
+	}
+	if builder.oneWire == -1 {
+		panic("R1CS doesn't have a ONE_WIRE")
+	}
+
+	for i := 0; i < int(r1cs.NbCOConstraints); i++ {
+		builder.splitR1C(&r1cs.Constraints[i])
+	}
+	for i := int(r1cs.NbCOConstraints); i < len(r1cs.Constraints); i++ {
+		builder.splitAssertion(&r1cs.Constraints[i])
+	}
+
+	// the hints inputs coefficients are moved to the sparse R1CS coefficients
+	hints := make([]r1c.Hint, len(r1cs.Hints))
+	for i := 0; i < len(r1cs.Hints); i++ {
+		hints[i] = r1c.Hint{
+			ID:     r1cs.Hints[i].ID,
+			Inputs: make([]r1c.LinearExpression, len(r1cs.Hints[i].Inputs)),
+			WireID: r1cs.Hints[i].WireID,
+		}
+		for j, input := range r1cs.Hints[i].Inputs {
+			hints[i].Inputs[j] = make(r1c.LinearExpression, len(input))
+			for k, t := range input {
+				hints[i].Inputs[j][k] = r1c.Pack(t.VariableID(), builder.coeffID(&r1cs.Coefficients[t.CoeffID()]), backend.Internal)
+			}
+		}
+	}
+
+	// wires = [internal wires | intermediate wires | secret inputs | public inputs]
+	nbIntermediate := len(builder.solved) - nbWires
+	res := UntypedSparseR1CS{
+		NbWires:         uint64(len(builder.solved)),
+		NbPublicWires:   r1cs.NbPublicWires,
+		NbSecretWires:   r1cs.NbSecretWires,
+		SecretWires:     r1cs.SecretWires,
+		PublicWires:     r1cs.PublicWires,
+		NbConstraints:   uint64(len(builder.constraints) + len(builder.assertions)),
+		NbCOConstraints: uint64(len(builder.constraints)),
+		Constraints:     append(builder.constraints, builder.assertions...),
+		Hints:           hints,
+		Coefficients:    builder.coeffs,
+		Logs:            make([]backend.LogEntry, len(r1cs.Logs)),
+		DebugInfo:       make([]backend.LogEntry, len(r1cs.DebugInfo)),
+	}
+
+	offsetID := func(id int) int {
+		switch {
+		case id < nbInternal:
+			return id
+		case id < nbWires:
+			return id + nbIntermediate
+		default:
+			return id - nbWires + nbInternal
+		}
+	}
+	visibility := func(id int) backend.Visibility {
+		switch {
+		case id < nbInternal+nbIntermediate:
+			return backend.Internal
+		case id < len(builder.solved)-int(r1cs.NbPublicWires):
+			return backend.Secret
+		default:
+			return backend.Public
+		}
+	}
+	offsetTerm := func(t *r1c.Term) {
+		id := offsetID(t.VariableID())
+		t.SetVariableID(id)
+		t.SetConstraintVisibility(visibility(id))
+	}
+	for i := 0; i < len(res.Constraints); i++ {
+		offsetTerm(&res.Constraints[i].L)
+		offsetTerm(&res.Constraints[i].R)
+		offsetTerm(&res.Constraints[i].O)
+	}
+	for i := 0; i < len(res.Hints); i++ {
+		res.Hints[i].WireID = offsetID(res.Hints[i].WireID)
+		for _, input := range res.Hints[i].Inputs {
+			for j := range input {
+				offsetTerm(&input[j])
+			}
+		}
+	}
+
+	offsetEntry := func(entry backend.LogEntry) backend.LogEntry {
+		res := backend.LogEntry{Format: entry.Format}
+		for _, id := range entry.ToResolve {
+			res.ToResolve = append(res.ToResolve, offsetID(id))
+		}
+		return res
+	}
+	for i := 0; i < len(r1cs.Logs); i++ {
+		res.Logs[i] = offsetEntry(r1cs.Logs[i])
+	}
+	for i := 0; i < len(r1cs.DebugInfo); i++ {
+		res.DebugInfo[i] = offsetEntry(r1cs.DebugInfo[i])
+	}
+
+	return &res
+}
+
+// splitR1C splits a computational constraint, such that each resulting sparse constraint
+// has a single wire to compute
+func (builder *sparseBuilder) splitR1C(r *r1c.R1C) {
+	if r.Solver == r1c.BinaryDec {
+		builder.splitBinaryDec(r)
+		return
+	}
+
+	l, _r, o := builder.linExp(r.L), builder.linExp(r.R), builder.linExp(r.O)
+	builder.solveHints(l, _r, o)
+
+	// find the wire to compute, as in the R1CS solver, there is at most one
+	var loc, toSolve int
+	for i, e := range []*linExp{&l, &_r, &o} {
+		for _, w := range e.wires {
+			if !builder.solved[w] {
+				if loc != 0 {
+					panic("found more than one wire to instantiate")
+				}
+				loc = i + 1
+				toSolve = w
+			}
+		}
+	}
+
+	switch loc {
+	case 0:
+		// all the wires are already computed, this is just a check
+		builder.constraints = append(builder.constraints, builder.mulGate(builder.reduce(l), builder.reduce(_r), builder.reduce(o)))
+		return
+	case 1:
+		builder.solveFactor(l, _r, o, toSolve)
+	case 2:
+		builder.solveFactor(_r, l, o, toSolve)
+	case 3:
+		builder.solveProduct(l, _r, o, toSolve)
+	}
+	builder.solved[toSolve] = true
+}
+
+// splitAssertion splits a constraint where all wires are already computed
+// the final check is recorded in the assertions, the intermediate wires it needs are computed
+// by computational constraints
+func (builder *sparseBuilder) splitAssertion(r *r1c.R1C) {
+	l, _r, o := builder.linExp(r.L), builder.linExp(r.R), builder.linExp(r.O)
+	builder.solveHints(l, _r, o)
+	builder.assertions = append(builder.assertions, builder.mulGate(builder.reduce(l), builder.reduce(_r), builder.reduce(o)))
+}
+
+// solveHints marks the hint outputs in the linear expressions as solved: as in the R1CS solver,
+// they are computed before the first constraint which uses them
+func (builder *sparseBuilder) solveHints(exps ...linExp) {
+	for _, e := range exps {
+		for _, w := range e.wires {
+			builder.solveHint(w)
+		}
+	}
+}
+
+func (builder *sparseBuilder) solveHint(wire int) {
+	h, ok := builder.hints[wire]
+	if !ok || builder.solved[wire] {
+		return
+	}
+	for _, input := range h.Inputs {
+		for _, t := range input {
+			builder.solveHint(t.VariableID())
+		}
+	}
+	builder.solved[wire] = true
+}
+
+// solveFactor records the constraints for x⋅y == o where x contains the wire to solve
+func (builder *sparseBuilder) solveFactor(x, y, o linExp, toSolve int) {
+	ry, ro := builder.reduce(y), builder.reduce(o)
+	coeff, rest := x.split(toSolve)
+
+	if len(rest.wires) == 0 {
+		// (coeff⋅toSolve + constant)⋅y == o
+		builder.constraints = append(builder.constraints, builder.mulGate(builder.term(toSolve, &coeff, &rest.constant), ry, ro))
+		return
+	}
+
+	// we compute v == x first, then toSolve = (v - rest) / coeff
+	v := builder.newWire()
+	builder.constraints = append(builder.constraints, builder.mulGate(builder.term(v, bOne, nil), ry, ro))
+
+	rr := builder.reduce(rest)
+	builder.constraints = append(builder.constraints, builder.linearGate(rr, builder.term(v, bMinusOne, nil), builder.term(toSolve, &coeff, nil)))
+}
+
+// solveProduct records the constraints for x⋅y == o where o contains the wire to solve
+func (builder *sparseBuilder) solveProduct(x, y, o linExp, toSolve int) {
+	rx, ry := builder.reduce(x), builder.reduce(y)
+	coeff, rest := o.split(toSolve)
+
+	if len(rest.wires) == 0 {
+		// x⋅y == coeff⋅toSolve + constant
+		builder.constraints = append(builder.constraints, builder.mulGate(rx, ry, builder.term(toSolve, &coeff, &rest.constant)))
+		return
+	}
+
+	// we compute v == x⋅y first, then toSolve = (v - rest) / coeff
+	v := builder.newWire()
+	builder.constraints = append(builder.constraints, builder.mulGate(rx, ry, builder.term(v, bOne, nil)))
+
+	rr := builder.reduce(rest)
+	rr.coeff.Neg(&rr.coeff)
+	rr.constant.Neg(&rr.constant)
+	coeff.Neg(&coeff)
+	builder.constraints = append(builder.constraints, builder.linearGate(builder.term(v, bOne, nil), rr, builder.term(toSolve, &coeff, nil)))
+}
+
+// splitBinaryDec records the constraints for the binary decomposition o == Σ 2**i⋅bits[i]
+//
+// with x[0] = o, it's done bit by bit: x[i] == 2⋅x[i+1] + bits[i] where x[i+1] and bits[i] are solved
+// together from x[i] (r1c.BinaryDec solving method), and x[n-1] == bits[n-1]
+func (builder *sparseBuilder) splitBinaryDec(r *r1c.R1C) {
+	l := builder.linExp(r.L)
+	nbBits := len(l.wires)
+	if nbBits == 0 {
+		return
+	}
+
+	// the coefficients of the bits are 2**i, sort the bits according to their position
+	bits := make([]int, nbBits)
+	for i := range bits {
+		bits[i] = -1
+	}
+	for i, w := range l.wires {
+		c := &l.coeffs[i]
+		pos := c.BitLen() - 1
+		if c.Sign() != 1 || pos >= nbBits || int(c.TrailingZeroBits()) != pos || bits[pos] != -1 {
+			panic("unexpected coefficient in binary decomposition")
+		}
+		bits[pos] = w
+	}
+
+	_o := builder.linExp(r.O)
+	builder.solveHints(_o)
+	o := builder.reduce(_o)
+	x := o.wire
+	if o.coeff.Cmp(bOne) != 0 || o.constant.Sign() != 0 {
+		x = builder.newWire()
+		builder.constraints = append(builder.constraints, builder.linearGate(o, builder.constant(nil), builder.term(x, bMinusOne, nil)))
+	}
+
+	for i := 0; i < nbBits-1; i++ {
+		next := builder.newWire()
+		c := builder.makeConstraint(builder.term(next, bTwo, nil), builder.term(bits[i], bOne, nil), builder.term(x, bMinusOne, nil), bZero, bZero)
+		c.Solver = r1c.BinaryDec
+		builder.constraints = append(builder.constraints, c)
+		x = next
+	}
+	builder.constraints = append(builder.constraints, builder.linearGate(builder.term(bits[nbBits-1], bOne, nil), builder.constant(nil), builder.term(x, bMinusOne, nil)))
+
+	for _, w := range bits {
+		builder.solved[w] = true
+	}
+}
+
+// mulGate returns the constraint (cl⋅xa + kl)⋅(cr⋅xb + kr) == co⋅xc + ko
+func (builder *sparseBuilder) mulGate(l, r, o sparseTerm) r1c.SparseR1C {
+	var qL, qR, qO, qM, qC big.Int
+	qM.Mul(&l.coeff, &r.coeff)
+	qL.Mul(&l.coeff, &r.constant)
+	qR.Mul(&l.constant, &r.coeff)
+	qO.Neg(&o.coeff)
+	qC.Mul(&l.constant, &r.constant).Sub(&qC, &o.constant)
+
+	a := builder.term(l.wire, &qL, nil)
+	b := builder.term(r.wire, &qR, nil)
+	c := builder.term(o.wire, &qO, nil)
+	return builder.makeConstraint(a, b, c, &qM, &qC)
+}
+
+// linearGate returns the constraint l + r + o == 0
+func (builder *sparseBuilder) linearGate(l, r, o sparseTerm) r1c.SparseR1C {
+	var qC big.Int
+	qC.Add(&l.constant, &r.constant).Add(&qC, &o.constant)
+	return builder.makeConstraint(l, r, o, bZero, &qC)
+}
+
+// makeConstraint returns the constraint qL⋅xa + qR⋅xb + qO⋅xc + qM⋅(xa⋅xb) + qC == 0
+// where the wires and qL, qR, qO are given by l, r, o (their constants are ignored)
+func (builder *sparseBuilder) makeConstraint(l, r, o sparseTerm, qM, qC *big.Int) r1c.SparseR1C {
+	return r1c.SparseR1C{
+		L:      r1c.Pack(l.wire, builder.coeffID(&l.coeff), backend.Internal),
+		R:      r1c.Pack(r.wire, builder.coeffID(&r.coeff), backend.Internal),
+		O:      r1c.Pack(o.wire, builder.coeffID(&o.coeff), backend.Internal),
+		M:      builder.coeffID(qM),
+		K:      builder.coeffID(qC),
+		Solver: r1c.SingleOutput,
+	}
+}
+
+// reduce returns a sparseTerm equal to the linear expression e
+// if e has more than one wire, intermediate wires are computed
+func (builder *sparseBuilder) reduce(e linExp) sparseTerm {
+	switch len(e.wires) {
+	case 0:
+		return builder.constant(&e.constant)
+	case 1:
+		return builder.term(e.wires[0], &e.coeffs[0], &e.constant)
+	}
+
+	// acc = c0⋅w0 + c1⋅w1, acc = acc + c2⋅w2, ...
+	acc := builder.term(e.wires[0], &e.coeffs[0], nil)
+	for i := 1; i < len(e.wires); i++ {
+		v := builder.newWire()
+		builder.constraints = append(builder.constraints, builder.linearGate(acc, builder.term(e.wires[i], &e.coeffs[i], nil), builder.term(v, bMinusOne, nil)))
+		acc = builder.term(v, bOne, nil)
+	}
+	acc.constant.Set(&e.constant)
+	return acc
+}
+
+// linExp returns the normalized linear expression of the rank-1 constraint linear expression le
+func (builder *sparseBuilder) linExp(le r1c.LinearExpression) linExp {
+	var res linExp
+	pos := make(map[int]int)
+	for _, t := range le {
+		w, coeff := t.VariableID(), &builder.r1cs.Coefficients[t.CoeffID()]
+		if w == builder.oneWire {
+			res.constant.Add(&res.constant, coeff)
+			continue
+		}
+		if i, ok := pos[w]; ok {
+			res.coeffs[i].Add(&res.coeffs[i], coeff)
+			continue
+		}
+		pos[w] = len(res.wires)
+		res.wires = append(res.wires, w)
+		res.coeffs = append(res.coeffs, *new(big.Int).Set(coeff))
+	}
+
+	// remove the wires which coefficients are 0
+	n := 0
+	for i := 0; i < len(res.wires); i++ {
+		if res.coeffs[i].Sign() != 0 {
+			res.wires[n] = res.wires[i]
+			res.coeffs[n] = res.coeffs[i]
+			n++
+		}
+	}
+	res.wires, res.coeffs = res.wires[:n], res.coeffs[:n]
+	return res
+}
+
+// split returns the coefficient of wire in e, and e without this wire
+func (e linExp) split(wire int) (big.Int, linExp) {
+	var coeff big.Int
+	var rest linExp
+	rest.constant.Set(&e.constant)
+	for i := 0; i < len(e.wires); i++ {
+		if e.wires[i] == wire {
+			coeff.Set(&e.coeffs[i])
+			continue
+		}
+		rest.wires = append(rest.wires, e.wires[i])
+		rest.coeffs = append(rest.coeffs, e.coeffs[i])
+	}
+	return coeff, rest
+}
+
+// term returns coeff⋅wire + constant (constant may be nil)
+func (builder *sparseBuilder) term(wire int, coeff, constant *big.Int) sparseTerm {
+	res := sparseTerm{wire: wire}
+	res.coeff.Set(coeff)
+	if constant != nil {
+		res.constant.Set(constant)
+	}
+	return res
+}
+
+// constant returns a sparseTerm on the ONE_WIRE with a 0 coefficient (constant may be nil)
+func (builder *sparseBuilder) constant(constant *big.Int) sparseTerm {
+	return builder.term(builder.oneWire, bZero, constant)
+}
+
+// newWire returns the id of a new intermediate wire, computed by the next recorded constraint
+func (builder *sparseBuilder) newWire() int {
+	builder.solved = append(builder.solved, true)
+	return len(builder.solved) - 1
+}
+
+// coeffID tries to fetch the entry where b is if it exits, otherwise appends b to
+// the list of coeffs and returns the corresponding entry
+func (builder *sparseBuilder) coeffID(b *big.Int) int {
+	key := b.Text(16)
+	if idx, ok := builder.coeffsIDs[key]; ok {
+		return idx
+	}
+
+	var bCopy big.Int
+	bCopy.Set(b)
+	resID := len(builder.coeffs)
+	builder.coeffs = append(builder.coeffs, bCopy)
+	builder.coeffsIDs[key] = resID
+	return resID
+}
+
+var (
+	bMinusOne = new(big.Int).SetInt64(-1)
+	bZero     = new(big.Int)
+	bOne      = new(big.Int).SetInt64(1)
+	bTwo      = new(big.Int).SetInt64(2)
+)
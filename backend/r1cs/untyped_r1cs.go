@@ -37,9 +37,11 @@ type UntypedR1CS struct {
 	DebugInfo     []backend.LogEntry
 
 	// Constraints
-	NbConstraints   uint64 // total number of constraints
-	NbCOConstraints uint64 // number of constraints that need to be solved, the first of the Constraints slice
+	NbConstraints   uint64  // total number of constraints
+	NbCOConstraints uint64  // number of constraints that need to be solved, the first of the Constraints slice
+	Levels          [][]int // computational constraints grouped in levels of independent constraints
 	Constraints     []r1c.R1C
+	Hints           []r1c.Hint // wires computed by hint functions
 	Coefficients    []big.Int
 }
 
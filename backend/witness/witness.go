@@ -0,0 +1,117 @@
+// Copyright 2020 ConsenSys AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package witness provides a compact binary encoding of a witness (the assignment of the
+// inputs of a circuit), which, unlike the JSON format of gnark/io, distinguishes public
+// and secret inputs.
+//
+// The encoding is
+//
+//	curve ID (uint16) | nbPublic (uint32) | nbSecret (uint32) | public values | secret values
+//
+// where values are field elements (big endian, regular form) ordered as in the R1CS public
+// and secret wires, the ONE_WIRE being omitted. A public witness is a witness with nbSecret == 0.
+//
+// groth16.ReadAndProve and groth16.ReadAndVerify consume this encoding directly.
+package witness
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark/backend/r1cs"
+	"github.com/consensys/gnark/frontend"
+	backend_bls377 "github.com/consensys/gnark/internal/backend/bls377"
+	backend_bls381 "github.com/consensys/gnark/internal/backend/bls381"
+	backend_bn256 "github.com/consensys/gnark/internal/backend/bn256"
+	backend_bw761 "github.com/consensys/gnark/internal/backend/bw761"
+	"github.com/consensys/gurvy"
+	fr_bls377 "github.com/consensys/gurvy/bls377/fr"
+	fr_bls381 "github.com/consensys/gurvy/bls381/fr"
+	fr_bn256 "github.com/consensys/gurvy/bn256/fr"
+	fr_bw761 "github.com/consensys/gurvy/bw761/fr"
+)
+
+const headerSize = 10
+
+// WriteFull writes the binary encoding of the public and secret inputs of assignment to w
+//
+// assignment must be map[string]interface{} or must implement frontend.Circuit
+// ( see frontend.ParseWitness )
+func WriteFull(w io.Writer, r1cs r1cs.R1CS, assignment interface{}) (int64, error) {
+	return write(w, r1cs, assignment, false)
+}
+
+// WritePublic writes the binary encoding of the public inputs of assignment to w
+//
+// assignment must be map[string]interface{} or must implement frontend.Circuit
+// ( see frontend.ParseWitness )
+func WritePublic(w io.Writer, r1cs r1cs.R1CS, assignment interface{}) (int64, error) {
+	return write(w, r1cs, assignment, true)
+}
+
+// ExtractPublic reads a witness from r and writes its public part to w
+//
+// r is read up to the end of the public values, the secret values are left unread
+func ExtractPublic(w io.Writer, r io.Reader) (int64, error) {
+	var header [headerSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, err
+	}
+
+	var frSize int64
+	switch gurvy.ID(binary.BigEndian.Uint16(header[0:2])) {
+	case gurvy.BN256:
+		frSize = fr_bn256.Limbs * 8
+	case gurvy.BLS377:
+		frSize = fr_bls377.Limbs * 8
+	case gurvy.BLS381:
+		frSize = fr_bls381.Limbs * 8
+	case gurvy.BW761:
+		frSize = fr_bw761.Limbs * 8
+	default:
+		return 0, errors.New("unknown curve ID in witness")
+	}
+	nbPublic := int64(binary.BigEndian.Uint32(header[2:6]))
+
+	// a public witness has no secret value
+	binary.BigEndian.PutUint32(header[6:10], 0)
+	written, err := w.Write(header[:])
+	if err != nil {
+		return int64(written), err
+	}
+	n, err := io.CopyN(w, r, nbPublic*frSize)
+	return int64(written) + n, err
+}
+
+func write(w io.Writer, r1cs r1cs.R1CS, assignment interface{}, publicOnly bool) (int64, error) {
+	_assignment, err := frontend.ParseWitness(assignment)
+	if err != nil {
+		return 0, err
+	}
+
+	switch _r1cs := r1cs.(type) {
+	case *backend_bls377.R1CS:
+		return _r1cs.WriteWitness(w, _assignment, publicOnly)
+	case *backend_bls381.R1CS:
+		return _r1cs.WriteWitness(w, _assignment, publicOnly)
+	case *backend_bn256.R1CS:
+		return _r1cs.WriteWitness(w, _assignment, publicOnly)
+	case *backend_bw761.R1CS:
+		return _r1cs.WriteWitness(w, _assignment, publicOnly)
+	default:
+		panic("unrecognized R1CS curve type")
+	}
+}
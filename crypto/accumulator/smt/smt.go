@@ -0,0 +1,33 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package smt provides a fixed depth sparse Merkle tree, keyed by field element
+//
+// A tree of depth d has 2^d leaves, the leaf at index key stores a field element. The zero value
+// marks an absent key: it can't be set, such that a proof that a key maps to zero is a proof of
+// non membership.
+//
+// Leaves are Hash(LeafTag || value) and nodes are Hash(NodeTag || left || right), the tags
+// separating the leaves from the nodes. The path from the leaf to the root is given by the bits of
+// the key, least significant bit first (bit i set means the node at height i is a right child).
+//
+// The curve specific implementations are in the sub packages (bn256, bls381, ...), the circuit
+// counterpart is std/accumulator/smt.
+package smt
+
+// Domain separation tags, the first element hashed in a leaf or a node
+const (
+	LeafTag = 0
+	NodeTag = 1
+)
@@ -0,0 +1,53 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package twistededwards
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gurvy/bls377/fr"
+)
+
+// CurveParams curve parameters: ax^2 + y^2 = 1 + d*x^2*y^2
+type CurveParams struct {
+	A, D     fr.Element // in Montgomery form
+	Cofactor fr.Element // not in Montgomery form
+	Order    big.Int
+	Base     Point
+}
+
+var edwards CurveParams
+var initOnce sync.Once
+
+// GetEdwardsCurve returns the twisted Edwards curve on BLS377's Fr
+func GetEdwardsCurve() CurveParams {
+	initOnce.Do(initEdBLS377)
+	return edwards
+}
+
+func initEdBLS377() {
+
+	edwards.A.SetString("8444461749428370424248824938781546531375899335154063827935233455917409239040") // -1
+	edwards.D.SetUint64(3021)
+	edwards.Cofactor.SetUint64(4).FromMont()
+	edwards.Order.SetString("2111115437357092606062206234695386632838870926408408195193685246394721360383", 10)
+
+	edwards.Base.X.SetString("717051916204163000937139483451426116831771857428389560441264442629694842243")
+	edwards.Base.Y.SetString("882565546457454111605105352482086902132191855952243170543452705048019814192")
+
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package twistededwards
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBase(t *testing.T) {
+	ed := GetEdwardsCurve()
+
+	if !ed.Base.IsOnCurve() {
+		t.Fatal("base point should be on the curve")
+	}
+
+	// the base point is of order Order
+	var p, zero Point
+	zero.Y.SetOne()
+	p.ScalarMul(&ed.Base, &ed.Order)
+	if !p.Equal(&zero) {
+		t.Fatal("Order*Base should be the neutral element")
+	}
+}
+
+func TestAddProj(t *testing.T) {
+	ed := GetEdwardsCurve()
+
+	var p1, p2, expected Point
+	p1.ScalarMul(&ed.Base, big.NewInt(23902374))
+	p2.ScalarMul(&ed.Base, big.NewInt(188020))
+	expected.Add(&p1, &p2)
+
+	var p1proj, p2proj PointProj
+	p1proj.FromAffine(&p1)
+	p2proj.FromAffine(&p2)
+	p1proj.Add(&p1proj, &p2proj)
+	p1.FromProj(&p1proj)
+
+	if !p1.Equal(&expected) {
+		t.Fatal("affine and projective additions should match")
+	}
+	if !p1.IsOnCurve() {
+		t.Fatal("the sum should be on the curve")
+	}
+}
+
+func TestDoubleProj(t *testing.T) {
+	ed := GetEdwardsCurve()
+
+	var p, expected Point
+	p.ScalarMul(&ed.Base, big.NewInt(23902374))
+	expected.Add(&p, &p)
+
+	var pproj PointProj
+	pproj.FromAffine(&p).Double(&pproj)
+	p.FromProj(&pproj)
+
+	if !p.Equal(&expected) {
+		t.Fatal("affine and projective doublings should match")
+	}
+}
+
+func TestScalarMul(t *testing.T) {
+	ed := GetEdwardsCurve()
+
+	// (a+b)*Base = a*Base + b*Base
+	var p1, p2, expected Point
+	p1.ScalarMul(&ed.Base, big.NewInt(23902374))
+	p2.ScalarMul(&ed.Base, big.NewInt(188020))
+	p1.Add(&p1, &p2)
+	expected.ScalarMul(&ed.Base, big.NewInt(23902374+188020))
+	if !p1.Equal(&expected) {
+		t.Fatal("scalar multiplication should be linear")
+	}
+
+	// test consistancy with negation
+	var scalar big.Int
+	expected.Neg(&ed.Base)
+	scalar.Sub(&ed.Order, big.NewInt(1))
+	p1.ScalarMul(&ed.Base, &scalar)
+	if !p1.Equal(&expected) {
+		t.Fatal("Mul by order-1 not consistant with neg")
+	}
+}
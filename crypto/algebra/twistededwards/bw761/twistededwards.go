@@ -0,0 +1,54 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package twistededwards
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gurvy/bw761/fr"
+)
+
+// CurveParams curve parameters: ax^2 + y^2 = 1 + d*x^2*y^2
+type CurveParams struct {
+	A, D     fr.Element // in Montgomery form
+	Cofactor fr.Element // not in Montgomery form
+	Order    big.Int
+	Base     Point
+}
+
+var edwards CurveParams
+var initOnce sync.Once
+
+// GetEdwardsCurve returns the twisted Edwards curve on BW761's Fr
+func GetEdwardsCurve() CurveParams {
+	initOnce.Do(initEdBW761)
+	return edwards
+}
+
+func initEdBW761() {
+
+	edwards.A.SetString("258664426012969094010652733694893533536393512754914660539884262666720468348340822774968888139573360124440321458176") // -1
+	edwards.D.SetUint64(79743)
+	edwards.Cofactor.SetUint64(8).FromMont()
+	edwards.Order.SetString("32333053251621136751331591711861691692049189094364332567435817881934511297123972799646723302813083835942624121493", 10)
+
+	// cofactor times the point of smallest y
+	edwards.Base.X.SetString("136036368895934182660683598356860929301626347763617081130112690621600647994647215878319745636317269127728135244023")
+	edwards.Base.Y.SetString("6024428474054068658506791911508707895140380365230084187492084459156833056077725572090028084095717295372664768785")
+
+}
@@ -27,21 +27,26 @@ import (
 const mimcNbRounds = 91
 
 // BlockSize size that mimc consumes
-const BlockSize = 32
+const BlockSize = fr.Bytes
 
 // Params constants for the mimc hash function
 type Params []fr.Element
 
 // NewParams creates new mimc object
 func NewParams(seed string) Params {
+	return newParams(seed, mimcNbRounds)
+}
+
+// newParams returns nbRounds constants derived from seed
+func newParams(seed string, nbRounds int) Params {
 
 	// set the constants
-	res := make(Params, mimcNbRounds)
+	res := make(Params, nbRounds)
 
 	rnd := sha3.Sum256([]byte(seed))
 	value := new(big.Int).SetBytes(rnd[:])
 
-	for i := 0; i < mimcNbRounds; i++ {
+	for i := 0; i < nbRounds; i++ {
 		rnd = sha3.Sum256(value.Bytes())
 		value.SetBytes(rnd[:])
 		res[i].SetBigInt(value)
@@ -71,7 +76,7 @@ func NewMiMC(seed string) hash.Hash {
 // Reset resets the Hash to its initial state.
 func (d *digest) Reset() {
 	d.data = nil
-	d.h = fr.Element{0, 0, 0, 0}
+	d.h.SetZero()
 }
 
 // Sum appends the current hash to b and returns the resulting slice.
@@ -110,7 +115,7 @@ func (d *digest) Write(p []byte) (n int, err error) {
 // The XOR operation is replaced by field addition, data is in Montgomery form
 func (d *digest) checksum() fr.Element {
 
-	var buffer [32]byte
+	var buffer [BlockSize]byte
 	var x fr.Element
 
 	// if data size is not multiple of BlockSizes we padd:
@@ -128,7 +133,7 @@ func (d *digest) checksum() fr.Element {
 	}
 
 	if len(d.data) == 0 {
-		d.data = make([]byte, 32)
+		d.data = make([]byte, BlockSize)
 	}
 
 	nbChunks := len(d.data) / BlockSize
@@ -149,7 +154,7 @@ func (d *digest) checksum() fr.Element {
 func (d *digest) encrypt(m fr.Element) {
 
 	for i := 0; i < len(d.Params); i++ {
-		// m = (m+k+c)^7
+		// m = (m+k+c)^5
 		var tmp fr.Element
 		tmp.Add(&m, &d.h).Add(&tmp, &d.Params[i])
 		m.Square(&tmp).
@@ -160,6 +165,14 @@ func (d *digest) encrypt(m fr.Element) {
 	d.h = m
 }
 
+// sbox returns x^5, the round function of mimc
+func sbox(x *fr.Element) {
+	var tmp fr.Element
+	tmp.Square(x).
+		Square(&tmp)
+	x.Mul(x, &tmp)
+}
+
 // Sum computes the mimc hash of msg from seed
 func Sum(seed string, msg []byte) ([]byte, error) {
 	params := NewParams(seed)
@@ -172,3 +185,91 @@ func Sum(seed string, msg []byte) ([]byte, error) {
 	bytes := h.Bytes()
 	return bytes[:], nil
 }
+
+// NewSpongeParams returns the constants of the permutation of the mimc sponge of the given rate
+func NewSpongeParams(seed string, rate int) Params {
+	return newParams(seed, (rate+1)*mimcNbRounds)
+}
+
+// Sponge is a mimc sponge, absorbing and squeezing field elements
+//
+// the state is rate elements followed by the capacity element. The permutation is a generalized
+// Feistel network (x_0, ..., x_rate) <- (x_1 + sbox(x_0 + c), x_2, ..., x_rate, x_0), with
+// (rate+1)*mimcNbRounds rounds such that each element goes through mimcNbRounds S-boxes: with a
+// rate of 1 element, it is the Feistel network MiMC-2n/n on (xL, xR).
+//
+// The rate is full after rate absorbed elements, which are followed by a permutation. When the sponge
+// starts squeezing after a partially filled rate, the rate is padded with zeros, the number of elements
+// absorbed in it is added to the capacity element and the state is permuted: (x) and (x, 0) are
+// distinct inputs.
+type Sponge struct {
+	Params    Params
+	state     []fr.Element // rate elements, followed by the capacity element
+	pos       int          // position in the rate of the next absorbed or squeezed element
+	squeezing bool
+}
+
+// NewSponge returns a mimc sponge of the given rate (at least 1), with the constants derived from seed
+func NewSponge(seed string, rate int) *Sponge {
+	if rate < 1 {
+		panic("mimc: the rate of the sponge must be at least 1")
+	}
+	return &Sponge{Params: NewSpongeParams(seed, rate), state: make([]fr.Element, rate+1)}
+}
+
+// Absorb adds each element of data to the rate, with a permutation each time the rate is full
+func (s *Sponge) Absorb(data ...fr.Element) {
+	rate := len(s.state) - 1
+	if s.squeezing {
+		s.squeezing = false
+		s.pos = 0
+	}
+	for i := 0; i < len(data); i++ {
+		s.state[s.pos].Add(&s.state[s.pos], &data[i])
+		s.pos++
+		if s.pos == rate {
+			s.permutation()
+			s.pos = 0
+		}
+	}
+}
+
+// Squeeze returns n elements, read from the rate, with a permutation each time the rate is exhausted
+func (s *Sponge) Squeeze(n int) []fr.Element {
+	rate := len(s.state) - 1
+	if !s.squeezing {
+		if s.pos != 0 {
+			var length fr.Element
+			length.SetUint64(uint64(s.pos))
+			s.state[rate].Add(&s.state[rate], &length)
+			s.permutation()
+		}
+		s.squeezing = true
+		s.pos = 0
+	}
+	res := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		if s.pos == rate {
+			s.permutation()
+			s.pos = 0
+		}
+		res[i] = s.state[s.pos]
+		s.pos++
+	}
+	return res
+}
+
+// permutation (x_0, ..., x_rate) <- (x_1 + sbox(x_0 + c), x_2, ..., x_rate, x_0), for each constant c
+func (s *Sponge) permutation() {
+	var tmp fr.Element
+	last := len(s.state) - 1
+	for i := 0; i < len(s.Params); i++ {
+		tmp.Add(&s.state[0], &s.Params[i])
+		sbox(&tmp)
+		tmp.Add(&tmp, &s.state[1])
+		x0 := s.state[0]
+		s.state[0] = tmp
+		copy(s.state[1:], s.state[2:])
+		s.state[last] = x0
+	}
+}
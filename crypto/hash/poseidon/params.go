@@ -0,0 +1,228 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package poseidon generates the parameters of the Poseidon permutation (https://eprint.iacr.org/2019/458.pdf)
+//
+// The parameters are generated like the reference implementation (generate_parameters_grain.sage and
+// calc_round_numbers.py), for 128 bits of security, with the security margin, and the number of partial
+// rounds rounded up to a multiple of the width. For BN256 this gives the parameters of circomlib.
+//
+// The curve specific implementations are in the sub packages (bn256, bls381, ...).
+package poseidon
+
+import (
+	"math"
+	"math/big"
+)
+
+// securityLevel in bits
+const securityLevel = 128
+
+// Params of the Poseidon permutation on a state of T field elements
+type Params struct {
+	T               int         // width of the permutation (the rate is T-1, the capacity 1)
+	NbFullRounds    int         // number of rounds where the S-box is applied to the full state
+	NbPartialRounds int         // number of rounds where the S-box is applied to the first element
+	Alpha           uint64      // exponent of the S-box x -> x^Alpha
+	RoundConstants  [][]big.Int // round constants, added to the state at the beginning of each round
+	MDS             [][]big.Int // T*T matrix applied to the state at the end of each round
+}
+
+// LengthTag returns the initial value of the capacity element when hashing nbElements field elements,
+// nbElements*2^64: the domain separation of the Poseidon paper for fixed length hashing (section 4.2),
+// with one output element
+func LengthTag(nbElements int) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(int64(nbElements)), 64)
+}
+
+// NewParams returns the parameters of the Poseidon permutation of width t, on the field of order modulus
+func NewParams(modulus *big.Int, t int) *Params {
+	if t < 2 {
+		panic("poseidon: width must be at least 2")
+	}
+
+	p := &Params{T: t, Alpha: sboxExponent(modulus)}
+	p.NbFullRounds, p.NbPartialRounds = roundNumbers(modulus, t, p.Alpha)
+
+	n := modulus.BitLen()
+	g := newGrain(n, t, p.NbFullRounds, p.NbPartialRounds)
+
+	// round constants, sampled in [0, modulus)
+	p.RoundConstants = make([][]big.Int, p.NbFullRounds+p.NbPartialRounds)
+	for i := 0; i < len(p.RoundConstants); i++ {
+		p.RoundConstants[i] = make([]big.Int, t)
+		for j := 0; j < t; j++ {
+			for {
+				g.setBits(&p.RoundConstants[i][j], n)
+				if p.RoundConstants[i][j].Cmp(modulus) < 0 {
+					break
+				}
+			}
+		}
+	}
+
+	// Cauchy matrix M[i][j] = 1 / (x[i] + y[j]), with distinct x[i], y[j] and x[i] + y[j] != 0
+	for {
+		xy := make([]big.Int, 2*t)
+		for i := 0; i < len(xy); i++ {
+			g.setBits(&xy[i], n)
+			xy[i].Mod(&xy[i], modulus)
+		}
+		if p.MDS = cauchy(xy[:t], xy[t:], modulus); p.MDS != nil {
+			break
+		}
+	}
+
+	return p
+}
+
+// cauchy returns the Cauchy matrix of x and y, or nil if the elements are not distinct or the
+// matrix is not defined
+func cauchy(x, y []big.Int, modulus *big.Int) [][]big.Int {
+	xy := append(append([]big.Int{}, x...), y...)
+	for i := 0; i < len(xy); i++ {
+		for j := i + 1; j < len(xy); j++ {
+			if xy[i].Cmp(&xy[j]) == 0 {
+				return nil
+			}
+		}
+	}
+
+	res := make([][]big.Int, len(x))
+	for i := 0; i < len(x); i++ {
+		res[i] = make([]big.Int, len(y))
+		for j := 0; j < len(y); j++ {
+			res[i][j].Add(&x[i], &y[j]).Mod(&res[i][j], modulus)
+			if res[i][j].ModInverse(&res[i][j], modulus) == nil {
+				return nil
+			}
+		}
+	}
+	return res
+}
+
+// sboxExponent returns the smallest alpha >= 3 such that x -> x^alpha is a permutation
+func sboxExponent(modulus *big.Int) uint64 {
+	var pMinusOne, gcd, a big.Int
+	pMinusOne.Sub(modulus, big.NewInt(1))
+	for alpha := uint64(3); ; alpha += 2 {
+		a.SetUint64(alpha)
+		if gcd.GCD(nil, nil, &a, &pMinusOne).IsInt64() && gcd.Int64() == 1 {
+			return alpha
+		}
+	}
+}
+
+// roundNumbers returns the number of full and partial rounds, minimizing the number of S-boxes
+//
+// this follows calc_round_numbers.py (including the reuse of the partial rounds counter in the
+// search), with the number of partial rounds rounded up to a multiple of t
+func roundNumbers(modulus *big.Int, t int, alpha uint64) (nbFullRounds, nbPartialRounds int) {
+	fp, _ := new(big.Float).SetInt(modulus).Float64()
+	log2p := math.Log(fp) / math.Log(2)
+
+	minCost := math.Inf(1)
+	for rp := 1; rp < 500; rp++ {
+		rpT := rp
+		for rf := 4; rf < 100; rf += 2 {
+			if !isSecure(log2p, t, rf, rpT, float64(alpha)) {
+				continue
+			}
+			rfT := rf + 2
+			rpT = int(math.Ceil(float64(rpT) * 1.075))
+			cost := float64(t*rfT + rpT)
+			if cost < minCost || (cost == minCost && rfT < nbFullRounds) {
+				nbFullRounds, nbPartialRounds = rfT, rpT
+				minCost = cost
+			}
+		}
+	}
+
+	nbPartialRounds = (nbPartialRounds + t - 1) / t * t
+	return
+}
+
+// isSecure returns true if rf full rounds and rp partial rounds resist the statistical,
+// interpolation and Gröbner basis attacks
+func isSecure(log2p float64, t, rf, rp int, alpha float64) bool {
+	m := float64(securityLevel)
+	n := math.Ceil(log2p)
+	logAlpha := func(x float64) float64 { return math.Log(x) / math.Log(alpha) }
+
+	rf1 := 10.0
+	if m <= math.Floor(log2p-(alpha-1)/2.0)*float64(t+1) {
+		rf1 = 6 // statistical
+	}
+	rf2 := 1 + math.Ceil(logAlpha(2)*math.Min(m, n)) + math.Ceil(logAlpha(float64(t))) - float64(rp) // interpolation
+	rf3 := logAlpha(2)*math.Min(m, log2p) - float64(rp)                                              // Gröbner 1
+	rf4 := float64(t) - 1 + logAlpha(2)*math.Min(m/float64(t+1), log2p/2.0) - float64(rp)            // Gröbner 2
+
+	rfMax := math.Max(math.Max(math.Ceil(rf1), math.Ceil(rf2)), math.Max(math.Ceil(rf3), math.Ceil(rf4)))
+	return float64(rf) >= rfMax
+}
+
+// grain is the Grain LFSR used by the reference implementation to sample the parameters
+type grain struct {
+	state [80]uint8
+}
+
+func newGrain(n, t, nbFullRounds, nbPartialRounds int) *grain {
+	var g grain
+	i := 0
+	set := func(v, nbBits int) {
+		for j := nbBits - 1; j >= 0; j-- {
+			g.state[i] = uint8((v >> uint(j)) & 1)
+			i++
+		}
+	}
+	set(1, 2)  // prime field
+	set(0, 4)  // S-box x^alpha
+	set(n, 12) // field size
+	set(t, 12)
+	set(nbFullRounds, 10)
+	set(nbPartialRounds, 10)
+	set(1<<30-1, 30)
+
+	for j := 0; j < 160; j++ {
+		g.next()
+	}
+	return &g
+}
+
+func (g *grain) next() uint8 {
+	b := g.state[62] ^ g.state[51] ^ g.state[38] ^ g.state[23] ^ g.state[13] ^ g.state[0]
+	copy(g.state[:], g.state[1:])
+	g.state[79] = b
+	return b
+}
+
+// bit returns the next bit of the self-shrinking generator: the second bit of the first pair
+// whose first bit is 1
+func (g *grain) bit() uint {
+	for {
+		b1, b2 := g.next(), g.next()
+		if b1 == 1 {
+			return uint(b2)
+		}
+	}
+}
+
+// setBits sets z to the next nbBits bits, most significant bit first
+func (g *grain) setBits(z *big.Int, nbBits int) {
+	z.SetUint64(0)
+	for i := 0; i < nbBits; i++ {
+		z.Lsh(z, 1)
+		z.SetBit(z, 0, g.bit())
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poseidon
+
+import (
+	"testing"
+
+	bls377fr "github.com/consensys/gurvy/bls377/fr"
+	bls381fr "github.com/consensys/gurvy/bls381/fr"
+	bn256fr "github.com/consensys/gurvy/bn256/fr"
+	bw761fr "github.com/consensys/gurvy/bw761/fr"
+)
+
+func TestRoundNumbers(t *testing.T) {
+	// number of partial rounds of circomlib, for t = 2..17
+	expected := []int{56, 57, 56, 60, 60, 63, 64, 63, 60, 66, 60, 65, 70, 60, 64, 68}
+
+	for i := 0; i < len(expected); i++ {
+		width := i + 2
+		nbFullRounds, nbPartialRounds := roundNumbers(bn256fr.Modulus(), width, 5)
+		if nbFullRounds != 8 || nbPartialRounds != expected[i] {
+			t.Fatalf("t=%d: expected (8, %d) rounds, got (%d, %d)", width, expected[i], nbFullRounds, nbPartialRounds)
+		}
+	}
+}
+
+func TestSboxExponent(t *testing.T) {
+	if alpha := sboxExponent(bn256fr.Modulus()); alpha != 5 {
+		t.Fatalf("bn256: expected alpha=5, got %d", alpha)
+	}
+	if alpha := sboxExponent(bls381fr.Modulus()); alpha != 5 {
+		t.Fatalf("bls381: expected alpha=5, got %d", alpha)
+	}
+	if alpha := sboxExponent(bls377fr.Modulus()); alpha != 11 {
+		t.Fatalf("bls377: expected alpha=11, got %d", alpha)
+	}
+	if alpha := sboxExponent(bw761fr.Modulus()); alpha != 5 {
+		t.Fatalf("bw761: expected alpha=5, got %d", alpha)
+	}
+}
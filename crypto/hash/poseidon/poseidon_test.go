@@ -0,0 +1,120 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poseidon_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark/crypto/hash/poseidon/bn256"
+	"github.com/consensys/gurvy/bn256/fr"
+)
+
+// test vectors of circomlib (poseidon([1, 2]), t = 3), which is a single permutation of (0, 1, 2)
+func TestCircomlib(t *testing.T) {
+	params := bn256.NewParams(3)
+
+	var expected, rc fr.Element
+	expected.SetString("7853200120776062878684798364095072458815029376092732009249414926327459813530")
+	rc.SetString("6745197990210204598374042828761989596302876299545964402857411729872131034734")
+
+	if !params.RoundConstants[0][0].Equal(&rc) {
+		t.Fatal("first round constant doesn't match circomlib")
+	}
+
+	state := make([]fr.Element, 3)
+	state[1].SetUint64(1)
+	state[2].SetUint64(2)
+	params.Permutation(state)
+	if !state[0].Equal(&expected) {
+		t.Fatal("permutation doesn't match circomlib")
+	}
+}
+
+func TestHash(t *testing.T) {
+	params := bn256.NewParams(3)
+
+	// 0x02 is padded to the block 0x0201000...00, the hash of 1 element
+	var e fr.Element
+	block := make([]byte, bn256.BlockSize)
+	block[0], block[1] = 2, 1
+	e.SetBytes(block)
+	expected := params.Hash(e)
+	digest := expected.Bytes()
+
+	h := bn256.NewPoseidon(3)
+	h.Write([]byte{2})
+	if !bytes.Equal(h.Sum(nil), digest[:]) {
+		t.Fatal("hash.Hash doesn't match Params.Hash")
+	}
+
+	sum, err := bn256.Sum(3, []byte{2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sum, digest[:]) {
+		t.Fatal("Sum doesn't match Params.Hash")
+	}
+}
+
+func TestCollisions(t *testing.T) {
+	params := bn256.NewParams(3)
+
+	// inputs which are equal once padded with zeros to a multiple of the rate
+	var x, zero fr.Element
+	x.SetUint64(42)
+	inputs := [][]fr.Element{
+		{},
+		{zero},
+		{x},
+		{x, zero},
+		{x, zero, zero},
+		{x, zero, zero, zero},
+	}
+	hashes := make(map[fr.Element]int)
+	for i, input := range inputs {
+		h := params.Hash(input...)
+		if j, ok := hashes[h]; ok {
+			t.Fatalf("inputs %d and %d have the same hash", j, i)
+		}
+		hashes[h] = i
+	}
+
+	// data which are equal once padded or reduced modulo the field order
+	var r [fr.Bytes]byte
+	fr.Modulus().FillBytes(r[:])
+	data := [][]byte{
+		nil,
+		{0},
+		{2},
+		{0, 2},
+		append(make([]byte, fr.Bytes-1), 2),
+		make([]byte, bn256.BlockSize),
+		make([]byte, bn256.BlockSize+1),
+		r[:],
+		make([]byte, 2*bn256.BlockSize),
+	}
+	sums := make(map[string]int)
+	for i, d := range data {
+		sum, err := bn256.Sum(3, d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if j, ok := sums[string(sum)]; ok {
+			t.Fatalf("data %d and %d have the same hash", j, i)
+		}
+		sums[string(sum)] = i
+	}
+}
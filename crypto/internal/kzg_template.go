@@ -0,0 +1,735 @@
+package main
+
+const kzgTemplate = `
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gurvy/{{toLower .Curve}}/fr"
+	curve "github.com/consensys/gurvy/{{toLower .Curve}}"
+)
+
+var (
+	ErrInvalidNbDigests              = errors.New("number of digests is not the same as the number of polynomials")
+	ErrInvalidPolynomialSize         = errors.New("invalid polynomial size (larger than SRS or == 0)")
+	ErrVerifyOpeningProof            = errors.New("can't verify opening proof")
+	ErrVerifyBatchOpeningSinglePoint = errors.New("can't verify batch opening proof at single point")
+	ErrMinSRSSize                    = errors.New("minimum SRS size is 2")
+)
+
+// Digest commitment of a polynomial
+type Digest = curve.G1Affine
+
+// Polynomial in canonical basis: p[i] is the coefficient of X**i
+type Polynomial []fr.Element
+
+// Eval returns p(x)
+func (p Polynomial) Eval(x *fr.Element) fr.Element {
+	var res fr.Element
+	for i := len(p) - 1; i >= 0; i-- {
+		res.Mul(&res, x).Add(&res, &p[i])
+	}
+	return res
+}
+
+// SRS stores the result of the MPC
+type SRS struct {
+	G1 []curve.G1Affine  // [G1 [α]G1 , [α**2]G1, ... ]
+	G2 [2]curve.G2Affine // [G2, [α]G2 ]
+}
+
+// NewSRS returns a new SRS using alpha as randomness source
+//
+// In production, a SRS generated through MPC should be used.
+func NewSRS(size uint64, bAlpha *big.Int) (*SRS, error) {
+	if size < 2 {
+		return nil, ErrMinSRSSize
+	}
+
+	var alpha fr.Element
+	alpha.SetBigInt(bAlpha)
+
+	powers := make([]fr.Element, size)
+	powers[0].SetOne()
+	for i := 1; i < len(powers); i++ {
+		powers[i].Mul(&powers[i-1], &alpha)
+	}
+	// the scalars of the batch scalar multiplication are in regular form
+	for i := 0; i < len(powers); i++ {
+		powers[i].FromMont()
+	}
+
+	_, _, gen1Aff, gen2Aff := curve.Generators()
+
+	var srs SRS
+	srs.G1 = curve.BatchScalarMultiplicationG1(&gen1Aff, powers)
+	srs.G2[0] = gen2Aff
+	srs.G2[1].ScalarMultiplication(&gen2Aff, bAlpha)
+
+	return &srs, nil
+}
+
+// OpeningProof KZG proof for opening at a single point.
+type OpeningProof struct {
+	// H quotient polynomial (f - f(z))/(x-z)
+	H curve.G1Affine
+
+	// Point at which the polynomial is evaluated
+	Point fr.Element
+
+	// ClaimedValue purported value
+	ClaimedValue fr.Element
+}
+
+// BatchOpeningProof opening proof for many polynomials at the same point
+type BatchOpeningProof struct {
+	// H quotient polynomial Sum_i gamma**i*(f - f(z))/(x-z)
+	H curve.G1Affine
+
+	// Point at which the polynomials are evaluated
+	Point fr.Element
+
+	// ClaimedValues purported values
+	ClaimedValues []fr.Element
+}
+
+// Commit commits to a polynomial using a multi exponentiation with the SRS.
+// It is assumed that the polynomial is in canonical form, in Montgomery form.
+func Commit(p Polynomial, srs *SRS) (Digest, error) {
+	if len(p) == 0 || len(p) > len(srs.G1) {
+		return Digest{}, ErrInvalidPolynomialSize
+	}
+
+	// the scalars of the multi exponentiation are in regular form
+	scalars := make([]fr.Element, len(p))
+	for i := 0; i < len(p); i++ {
+		scalars[i] = p[i]
+		scalars[i].FromMont()
+	}
+
+	var res Digest
+	res.MultiExp(srs.G1[:len(p)], scalars)
+
+	return res, nil
+}
+
+// Open computes an opening proof of polynomial p at given point.
+func Open(p Polynomial, point *fr.Element, srs *SRS) (OpeningProof, error) {
+	if len(p) == 0 || len(p) > len(srs.G1) {
+		return OpeningProof{}, ErrInvalidPolynomialSize
+	}
+
+	// build the proof
+	res := OpeningProof{
+		Point:        *point,
+		ClaimedValue: p.Eval(point),
+	}
+
+	// compute H
+	h := dividePolyByXminusA(p, point)
+	if len(h) == 0 {
+		// p is constant, H = [0]G1
+		return res, nil
+	}
+
+	var err error
+	res.H, err = Commit(h, srs)
+	if err != nil {
+		return OpeningProof{}, err
+	}
+
+	return res, nil
+}
+
+// Verify verifies a KZG opening proof at a single point
+func Verify(commitment *Digest, proof *OpeningProof, srs *SRS) error {
+
+	// e([f(α) - f(a) + a⋅H(α)]G1, G2) == e([H(α)]G1, [α]G2)
+	var one, claimedValueNeg fr.Element
+	one.SetOne()
+	claimedValueNeg.Neg(&proof.ClaimedValue)
+	scalars := []fr.Element{one, claimedValueNeg, proof.Point}
+	for i := 0; i < len(scalars); i++ {
+		scalars[i].FromMont()
+	}
+
+	var folded curve.G1Affine
+	folded.MultiExp([]curve.G1Affine{*commitment, srs.G1[0], proof.H}, scalars)
+
+	var hNeg curve.G1Affine
+	hNeg.Neg(&proof.H)
+
+	check, err := pairingCheck([]curve.G1Affine{folded, hNeg}, []curve.G2Affine{srs.G2[0], srs.G2[1]})
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// BatchOpenSinglePoint creates a batch opening proof at a single point of several polynomials
+//
+// the polynomials are folded with the powers of a challenge γ, derived from the point,
+// the digests and the claimed values (Fiat Shamir)
+func BatchOpenSinglePoint(polynomials []Polynomial, digests []Digest, point *fr.Element, srs *SRS) (BatchOpeningProof, error) {
+
+	nbPolynomials := len(polynomials)
+	if nbPolynomials == 0 || nbPolynomials != len(digests) {
+		return BatchOpeningProof{}, ErrInvalidNbDigests
+	}
+	largestPoly := -1
+	for _, p := range polynomials {
+		if len(p) == 0 || len(p) > len(srs.G1) {
+			return BatchOpeningProof{}, ErrInvalidPolynomialSize
+		}
+		if len(p) > largestPoly {
+			largestPoly = len(p)
+		}
+	}
+
+	var res BatchOpeningProof
+
+	// compute the purported values
+	res.ClaimedValues = make([]fr.Element, nbPolynomials)
+	for i := 0; i < nbPolynomials; i++ {
+		res.ClaimedValues[i] = polynomials[i].Eval(point)
+	}
+
+	// set the point at which the evaluation is done
+	res.Point = *point
+
+	// derive the challenge γ, binded to the point and the commitments
+	gamma := deriveGamma(point, digests, res.ClaimedValues)
+
+	// fold the polynomials: Σ γ**i⋅p_i
+	folded := make(Polynomial, largestPoly)
+	var gammaPower, t fr.Element
+	gammaPower.SetOne()
+	for i := 0; i < nbPolynomials; i++ {
+		for j := 0; j < len(polynomials[i]); j++ {
+			t.Mul(&polynomials[i][j], &gammaPower)
+			folded[j].Add(&folded[j], &t)
+		}
+		gammaPower.Mul(&gammaPower, &gamma)
+	}
+
+	// compute H
+	h := dividePolyByXminusA(folded, point)
+	if len(h) == 0 {
+		// the polynomials are constant, H = [0]G1
+		return res, nil
+	}
+
+	var err error
+	res.H, err = Commit(h, srs)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+
+	return res, nil
+}
+
+// BatchVerifySinglePoint verifies a batched opening proof at a single point of a list of polynomials.
+func BatchVerifySinglePoint(digests []Digest, batchOpeningProof *BatchOpeningProof, srs *SRS) error {
+
+	nbDigests := len(digests)
+
+	// check consistancy between numbers of claims vs number of digests
+	if nbDigests == 0 || nbDigests != len(batchOpeningProof.ClaimedValues) {
+		return ErrInvalidNbDigests
+	}
+
+	// derive the challenge γ, binded to the point and the commitments
+	gamma := deriveGamma(&batchOpeningProof.Point, digests, batchOpeningProof.ClaimedValues)
+
+	// fold the claimed values and the digests
+	gammai := make([]fr.Element, nbDigests)
+	gammai[0].SetOne()
+	for i := 1; i < nbDigests; i++ {
+		gammai[i].Mul(&gammai[i-1], &gamma)
+	}
+
+	var foldedEvaluations, t fr.Element
+	for i := 0; i < nbDigests; i++ {
+		t.Mul(&batchOpeningProof.ClaimedValues[i], &gammai[i])
+		foldedEvaluations.Add(&foldedEvaluations, &t)
+	}
+
+	for i := 0; i < nbDigests; i++ {
+		gammai[i].FromMont()
+	}
+	var foldedDigests Digest
+	foldedDigests.MultiExp(digests, gammai)
+
+	// create the folded opening proof
+	foldedProof := OpeningProof{
+		H:            batchOpeningProof.H,
+		Point:        batchOpeningProof.Point,
+		ClaimedValue: foldedEvaluations,
+	}
+
+	if err := Verify(&foldedDigests, &foldedProof, srs); err != nil {
+		return ErrVerifyBatchOpeningSinglePoint
+	}
+	return nil
+}
+
+// BatchVerifyMultiPoints batch verifies a list of opening proofs at different points.
+// The purpose of the batching is to have only one pairing for verifying several proofs.
+//
+// digests[i] is the commitment of the polynomial opened by proofs[i]
+func BatchVerifyMultiPoints(digests []Digest, proofs []OpeningProof, srs *SRS) error {
+
+	// check consistancy nb proofs vs nb digests
+	nbProofs := len(proofs)
+	if nbProofs == 0 || len(digests) != nbProofs {
+		return ErrInvalidNbDigests
+	}
+
+	// if only one digest, call Verify
+	if nbProofs == 1 {
+		return Verify(&digests[0], &proofs[0], srs)
+	}
+
+	// sample random numbers λ_i for the random linear combination (λ_0 = 1)
+	randomNumbers := make([]fr.Element, nbProofs)
+	randomNumbers[0].SetOne()
+	for i := 1; i < nbProofs; i++ {
+		if _, err := randomNumbers[i].SetRandom(); err != nil {
+			return err
+		}
+	}
+
+	// e(Σ λ_i⋅(f_i(α) - f_i(a_i) + a_i⋅H_i(α)), G2) == e(Σ λ_i⋅H_i(α), [α]G2)
+	points := make([]curve.G1Affine, 0, 2*nbProofs+1)
+	scalars := make([]fr.Element, 0, 2*nbProofs+1)
+	var foldedEvaluations, t fr.Element
+	for i := 0; i < nbProofs; i++ {
+		t.Mul(&randomNumbers[i], &proofs[i].ClaimedValue)
+		foldedEvaluations.Add(&foldedEvaluations, &t)
+
+		points = append(points, digests[i], proofs[i].H)
+		t.Mul(&randomNumbers[i], &proofs[i].Point)
+		scalars = append(scalars, randomNumbers[i], t)
+	}
+	foldedEvaluations.Neg(&foldedEvaluations)
+	points = append(points, srs.G1[0])
+	scalars = append(scalars, foldedEvaluations)
+	for i := 0; i < len(scalars); i++ {
+		scalars[i].FromMont()
+	}
+	var folded curve.G1Affine
+	folded.MultiExp(points, scalars)
+
+	quotients := make([]curve.G1Affine, nbProofs)
+	for i := 0; i < nbProofs; i++ {
+		quotients[i] = proofs[i].H
+		randomNumbers[i].FromMont()
+	}
+	var foldedQuotients curve.G1Affine
+	foldedQuotients.MultiExp(quotients, randomNumbers)
+	foldedQuotients.Neg(&foldedQuotients)
+
+	check, err := pairingCheck([]curve.G1Affine{folded, foldedQuotients}, []curve.G2Affine{srs.G2[0], srs.G2[1]})
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// pairingCheck returns true if Π e(P[i], Q[i]) == 1
+//
+// the Miller loops are computed separately, as the bw761 Miller loop handles a single pair
+// and e(P, Q) == 1 is skipped when P is the point at infinity
+func pairingCheck(P []curve.G1Affine, Q []curve.G2Affine) (bool, error) {
+	var ml curve.GT
+	ml.SetOne()
+	for i := 0; i < len(P); i++ {
+		if P[i].IsInfinity() {
+			continue
+		}
+		t, err := curve.MillerLoop([]curve.G1Affine{P[i]}, []curve.G2Affine{Q[i]})
+		if err != nil {
+			return false, err
+		}
+		ml.Mul(&ml, &t)
+	}
+
+	var one curve.GT
+	one.SetOne()
+	res := curve.FinalExponentiation(&ml)
+	return res.Equal(&one), nil
+}
+
+// deriveGamma derives a challenge using Fiat Shamir to fold the polynomials
+func deriveGamma(point *fr.Element, digests []Digest, claimedValues []fr.Element) fr.Element {
+	h := sha256.New()
+
+	b := point.Bytes()
+	h.Write(b[:])
+	for i := 0; i < len(digests); i++ {
+		b := digests[i].Bytes()
+		h.Write(b[:])
+	}
+	for i := 0; i < len(claimedValues); i++ {
+		b := claimedValues[i].Bytes()
+		h.Write(b[:])
+	}
+
+	var gamma fr.Element
+	gamma.SetBytes(h.Sum(nil))
+	return gamma
+}
+
+// dividePolyByXminusA computes (f-f(a))/(x-a), in canonical basis
+func dividePolyByXminusA(f Polynomial, a *fr.Element) Polynomial {
+	if len(f) < 2 {
+		return Polynomial{}
+	}
+
+	res := make(Polynomial, len(f)-1)
+	res[len(res)-1].Set(&f[len(f)-1])
+	for i := len(res) - 2; i >= 0; i-- {
+		res[i].Mul(&res[i+1], a).Add(&res[i], &f[i+1])
+	}
+	return res
+}
+
+// WriteTo writes binary encoding of the SRS
+// points are stored in compressed form
+func (srs *SRS) WriteTo(w io.Writer) (int64, error) {
+	enc := curve.NewEncoder(w)
+
+	toEncode := []interface{}{srs.G1, &srs.G2[0], &srs.G2[1]}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes SRS data from reader.
+func (srs *SRS) ReadFrom(r io.Reader) (int64, error) {
+	dec := curve.NewDecoder(r)
+
+	toDecode := []interface{}{&srs.G1, &srs.G2[0], &srs.G2[1]}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+`
+
+const kzgTestTemplate = `
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/consensys/gurvy/{{toLower .Curve}}/fr"
+	curve "github.com/consensys/gurvy/{{toLower .Curve}}"
+)
+
+// testSRS re-used accross tests of the KZG commitment scheme
+var testSRS *SRS
+
+func init() {
+	const srsSize = 230
+	testSRS, _ = NewSRS(srsSize, new(big.Int).SetInt64(42))
+}
+
+func randomPolynomial(size int) Polynomial {
+	f := make(Polynomial, size)
+	for i := 0; i < size; i++ {
+		f[i].SetRandom()
+	}
+	return f
+}
+
+func TestDividePolyByXminusA(t *testing.T) {
+
+	const pSize = 230
+
+	// build random polynomial
+	pol := randomPolynomial(pSize)
+
+	// evaluate the polynomial at a random point
+	var point fr.Element
+	point.SetRandom()
+	evaluation := pol.Eval(&point)
+
+	// compute f-f(a)/x-a
+	h := dividePolyByXminusA(pol, &point)
+
+	if len(h) != pSize-1 {
+		t.Fatal("inconsistant size of quotient")
+	}
+
+	// probabilistic test (using Schwartz Zippel lemma, evaluation at one point is enough)
+	var randPoint, xminusa fr.Element
+	randPoint.SetRandom()
+
+	polRandpoint := pol.Eval(&randPoint)
+	polRandpoint.Sub(&polRandpoint, &evaluation) // f(rand)-f(point)
+
+	hRandPoint := h.Eval(&randPoint)
+	xminusa.Sub(&randPoint, &point) // rand-point
+
+	// f(rand)-f(point)	==? h(rand)*(rand-point)
+	hRandPoint.Mul(&hRandPoint, &xminusa)
+
+	if !hRandPoint.Equal(&polRandpoint) {
+		t.Fatal("Error f-f(a)/x-a")
+	}
+}
+
+func TestSerializationSRS(t *testing.T) {
+
+	// create a SRS
+	srs, err := NewSRS(64, new(big.Int).SetInt64(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// serialize it...
+	var buf bytes.Buffer
+	written, err := srs.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// reconstruct the SRS
+	var _srs SRS
+	read, err := _srs.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != read {
+		t.Fatal("number of bytes read and written don't match")
+	}
+
+	// compare
+	if !reflect.DeepEqual(srs, &_srs) {
+		t.Fatal("scheme serialization failed")
+	}
+}
+
+func TestCommit(t *testing.T) {
+
+	// create a polynomial
+	f := make(Polynomial, 60)
+	for i := 0; i < 60; i++ {
+		f[i].SetRandom()
+	}
+
+	// commit using the method from KZG
+	kzgCommit, err := Commit(f, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// check commitment using manual commit
+	var x fr.Element
+	x.SetString("42")
+	fx := f.Eval(&x)
+	var fxbi big.Int
+	fx.ToBigIntRegular(&fxbi)
+	var manualCommit curve.G1Affine
+	manualCommit.Set(&testSRS.G1[0])
+	manualCommit.ScalarMultiplication(&manualCommit, &fxbi)
+
+	// compare both results
+	if !kzgCommit.Equal(&manualCommit) {
+		t.Fatal("error KZG commitment")
+	}
+
+	// a polynomial larger than the SRS can't be committed
+	if _, err := Commit(randomPolynomial(len(testSRS.G1)+1), testSRS); err != ErrInvalidPolynomialSize {
+		t.Fatal("committing to a polynomial larger than the SRS should fail")
+	}
+}
+
+func TestVerifySinglePoint(t *testing.T) {
+
+	// create a polynomial
+	f := randomPolynomial(60)
+
+	// commit the polynomial
+	digest, err := Commit(f, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// compute opening proof at a random point
+	var point fr.Element
+	point.SetString("4321")
+	proof, err := Open(f, &point, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// verify the claimed valued
+	expected := f.Eval(&point)
+	if !proof.ClaimedValue.Equal(&expected) {
+		t.Fatal("inconsistant claimed value")
+	}
+
+	// verify correct proof
+	err = Verify(&digest, &proof, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	{
+		// verify wrong proof
+		proof.ClaimedValue.Double(&proof.ClaimedValue)
+		err = Verify(&digest, &proof, testSRS)
+		if err == nil {
+			t.Fatal("verifying wrong proof should have failed")
+		}
+	}
+}
+
+func TestBatchVerifySinglePoint(t *testing.T) {
+
+	// create polynomials
+	f := make([]Polynomial, 10)
+	for i := 0; i < 10; i++ {
+		f[i] = randomPolynomial(60 + i)
+	}
+
+	// commit the polynomials
+	digests := make([]Digest, 10)
+	for i := 0; i < 10; i++ {
+		digests[i], _ = Commit(f[i], testSRS)
+	}
+
+	// compute opening proof at a random point
+	var point fr.Element
+	point.SetString("4321")
+	proof, err := BatchOpenSinglePoint(f, digests, &point, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// verify the claimed values
+	for i := 0; i < 10; i++ {
+		expectedClaim := f[i].Eval(&point)
+		if !expectedClaim.Equal(&proof.ClaimedValues[i]) {
+			t.Fatal("inconsistant claimed values")
+		}
+	}
+
+	// verify correct proof
+	err = BatchVerifySinglePoint(digests, &proof, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	{
+		// verify wrong proof
+		proof.ClaimedValues[0].Double(&proof.ClaimedValues[0])
+		err = BatchVerifySinglePoint(digests, &proof, testSRS)
+		if err == nil {
+			t.Fatal("verifying wrong proof should have failed")
+		}
+	}
+}
+
+func TestBatchVerifyMultiPoints(t *testing.T) {
+
+	// create polynomials
+	f := make([]Polynomial, 10)
+	for i := 0; i < 10; i++ {
+		f[i] = randomPolynomial(60 + i)
+	}
+
+	// commit the polynomials
+	digests := make([]Digest, 10)
+	for i := 0; i < 10; i++ {
+		digests[i], _ = Commit(f[i], testSRS)
+	}
+
+	// compute 2 batch opening proofs at 2 random points
+	points := make([]fr.Element, 2)
+	batchProofs := make([]BatchOpeningProof, 2)
+	points[0].SetRandom()
+	batchProofs[0], _ = BatchOpenSinglePoint(f[:5], digests[:5], &points[0], testSRS)
+	points[1].SetRandom()
+	batchProofs[1], _ = BatchOpenSinglePoint(f[5:], digests[5:], &points[1], testSRS)
+
+	// fold the 2 batch opening proofs
+	proofs := make([]OpeningProof, 2)
+	foldedDigests := make([]Digest, 2)
+	proofs[0], foldedDigests[0] = foldBatchOpeningProof(digests[:5], &batchProofs[0])
+	proofs[1], foldedDigests[1] = foldBatchOpeningProof(digests[5:], &batchProofs[1])
+
+	// check the opening proofs
+	for i := 0; i < 2; i++ {
+		if err := Verify(&foldedDigests[i], &proofs[i], testSRS); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// batch verify the folded proofs
+	if err := BatchVerifyMultiPoints(foldedDigests, proofs, testSRS); err != nil {
+		t.Fatal(err)
+	}
+
+	// batch verify tampered folded proofs
+	proofs[0].ClaimedValue.Double(&proofs[0].ClaimedValue)
+	if err := BatchVerifyMultiPoints(foldedDigests, proofs, testSRS); err == nil {
+		t.Fatal("batch verifying wrong proofs should have failed")
+	}
+}
+
+// foldBatchOpeningProof returns the opening proof and the digest of Σ γ**i⋅f_i, where γ is
+// the challenge used by BatchOpenSinglePoint
+func foldBatchOpeningProof(digests []Digest, batchOpeningProof *BatchOpeningProof) (OpeningProof, Digest) {
+	gamma := deriveGamma(&batchOpeningProof.Point, digests, batchOpeningProof.ClaimedValues)
+
+	var foldedDigest Digest
+	var foldedEvaluation, gammaPower fr.Element
+	gammaPower.SetOne()
+	for i := 0; i < len(digests); i++ {
+		var t fr.Element
+		t.Mul(&batchOpeningProof.ClaimedValues[i], &gammaPower)
+		foldedEvaluation.Add(&foldedEvaluation, &t)
+
+		var b big.Int
+		gammaPower.ToBigIntRegular(&b)
+		var d curve.G1Affine
+		d.ScalarMultiplication(&digests[i], &b)
+		var acc, _d curve.G1Jac
+		acc.FromAffine(&foldedDigest)
+		_d.FromAffine(&d)
+		acc.AddAssign(&_d)
+		foldedDigest.FromJacobian(&acc)
+
+		gammaPower.Mul(&gammaPower, &gamma)
+	}
+
+	return OpeningProof{
+		H:            batchOpeningProof.H,
+		Point:        batchOpeningProof.Point,
+		ClaimedValue: foldedEvaluation,
+	}, foldedDigest
+}
+`
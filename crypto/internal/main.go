@@ -9,7 +9,7 @@ import (
 	"github.com/consensys/bavard"
 )
 
-//go:generate go run main.go mimc_template.go
+//go:generate go run main.go mimc_template.go kzg_template.go poseidon_template.go smt_template.go twistededwards_template.go eddsa_template.go schnorr_template.go
 func main() {
 
 	// -----------------------------------------------------
@@ -38,10 +38,132 @@ func main() {
 		Package:  "bls377",
 	}
 
+	mimcbw761 := templateData{
+		Curve:    "BW761",
+		Path:     "../hash/mimc/bw761/",
+		FileName: "mimc_bw761.go",
+		Src:      []string{mimcCommonTemplate, mimcCurveTemplate, mimcEncryptTemplate},
+		Package:  "bw761",
+	}
+
 	data := []templateData{
 		mimcbn256,
 		mimcbls381,
 		mimcbls377,
+		mimcbw761,
+	}
+
+	// -----------------------------------------------------
+	// kzg files
+	for _, curve := range []string{"BN256", "BLS381", "BLS377", "BW761"} {
+		path := "../polynomial/kzg/" + strings.ToLower(curve) + "/"
+		data = append(data, templateData{
+			Curve:    curve,
+			Path:     path,
+			FileName: "kzg.go",
+			Src:      []string{kzgTemplate},
+			Package:  "kzg",
+		}, templateData{
+			Curve:    curve,
+			Path:     path,
+			FileName: "kzg_test.go",
+			Src:      []string{kzgTestTemplate},
+			Package:  "kzg",
+		})
+	}
+
+	// -----------------------------------------------------
+	// poseidon files
+	for _, curve := range []string{"BN256", "BLS381", "BLS377", "BW761"} {
+		data = append(data, templateData{
+			Curve:    curve,
+			Path:     "../hash/poseidon/" + strings.ToLower(curve) + "/",
+			FileName: "poseidon_" + strings.ToLower(curve) + ".go",
+			Src:      []string{poseidonTemplate},
+			Package:  strings.ToLower(curve),
+		})
+	}
+
+	// -----------------------------------------------------
+	// sparse merkle tree files
+	for _, curve := range []string{"BN256", "BLS381", "BLS377", "BW761"} {
+		path := "../accumulator/smt/" + strings.ToLower(curve) + "/"
+		data = append(data, templateData{
+			Curve:    curve,
+			Path:     path,
+			FileName: "tree.go",
+			Src:      []string{smtTemplate},
+			Package:  strings.ToLower(curve),
+		}, templateData{
+			Curve:    curve,
+			Path:     path,
+			FileName: "tree_test.go",
+			Src:      []string{smtTestTemplate},
+			Package:  strings.ToLower(curve),
+		})
+	}
+
+	// -----------------------------------------------------
+	// twisted Edwards files (bn256 and bls381 are in gurvy)
+	for _, curve := range []string{"BLS377", "BW761"} {
+		path := "../algebra/twistededwards/" + strings.ToLower(curve) + "/"
+		data = append(data, templateData{
+			Curve:    curve,
+			Path:     path,
+			FileName: "twistededwards.go",
+			Src:      []string{twistedEdwardsTemplate},
+			Package:  "twistededwards",
+		}, templateData{
+			Curve:    curve,
+			Path:     path,
+			FileName: "point.go",
+			Src:      []string{twistedEdwardsPointTemplate},
+			Package:  "twistededwards",
+		}, templateData{
+			Curve:    curve,
+			Path:     path,
+			FileName: "twistededwards_test.go",
+			Src:      []string{twistedEdwardsTestTemplate},
+			Package:  "twistededwards",
+		})
+	}
+
+	// -----------------------------------------------------
+	// eddsa files
+	for _, curve := range []string{"BN256", "BLS381", "BLS377", "BW761"} {
+		path := "../signature/eddsa/" + strings.ToLower(curve) + "/"
+		data = append(data, templateData{
+			Curve:    curve,
+			Path:     path,
+			FileName: "eddsa.go",
+			Src:      []string{eddsaTemplate},
+			Package:  "eddsa",
+		}, templateData{
+			Curve:    curve,
+			Path:     path,
+			FileName: "eddsa_test.go",
+			Src:      []string{eddsaTestTemplate},
+			Package:  "eddsa",
+		})
+	}
+
+	// -----------------------------------------------------
+	// schnorr files
+	for _, curve := range []string{"BN256", "BLS381", "BLS377", "BW761"} {
+		path := "../signature/schnorr/" + strings.ToLower(curve) + "/"
+		data = append(data, templateData{
+			Curve:    curve,
+			Path:     path,
+			FileName: "schnorr.go",
+			Src:      []string{schnorrTemplate},
+			Package:  "schnorr",
+		}, templateData{
+			Curve:    curve,
+			Path:     path,
+			FileName: "schnorr_test.go",
+			Src:      []string{schnorrTestTemplate},
+			Package:  "schnorr",
+		})
 	}
 
 	var wg sync.WaitGroup
@@ -22,14 +22,23 @@ const mimcEncryptTemplate = `
 		m.Add(&m, &d.h)
 		d.h = m
 	}
-{{ else if eq .Curve "BLS381" }}
+
+	// sbox returns x^7, the round function of mimc
+	func sbox(x *fr.Element) {
+		var tmp fr.Element
+		tmp.Square(x).
+			Mul(&tmp, x).
+			Square(&tmp)
+		x.Mul(x, &tmp)
+	}
+{{ else if or (eq .Curve "BLS381") (eq .Curve "BW761") }}
 	// plain execution of a mimc run
 	// m: message
 	// k: encryption key
 	func (d *digest) encrypt(m fr.Element) {
 
 		for i:=0; i < len(d.Params); i++ {
-			// m = (m+k+c)^7
+			// m = (m+k+c)^5
 			var tmp fr.Element
 			tmp.Add(&m, &d.h).Add(&tmp, &d.Params[i])
 			m.Square(&tmp).
@@ -39,6 +48,14 @@ const mimcEncryptTemplate = `
 		m.Add(&m, &d.h)
 		d.h = m
 	}
+
+	// sbox returns x^5, the round function of mimc
+	func sbox(x *fr.Element) {
+		var tmp fr.Element
+		tmp.Square(x).
+			Square(&tmp)
+		x.Mul(x, &tmp)
+	}
 {{ else if eq .Curve "BLS377" }}
 	// plain execution of a mimc run
 	// m: message
@@ -46,12 +63,17 @@ const mimcEncryptTemplate = `
 	func (d *digest) encrypt(m fr.Element) {
 
 		for i:=0; i < len(d.Params); i++ {
-			// m = (m+k+c)^7
+			// m = (m+k+c)^-1
 			m.Add(&m, &d.h).Add(&m, &d.Params[i]).Inverse(&m)
 		}
 		m.Add(&m, &d.h)
 		d.h = m
 	}
+
+	// sbox returns x^-1, the round function of mimc
+	func sbox(x *fr.Element) {
+		x.Inverse(x)
+	}
 {{end}}
 
 {{end}}
@@ -62,109 +84,49 @@ const mimcCurveTemplate = `
 
 {{ define "mimc_custom" }}
 
-{{ if eq .Curve "BN256" }}
-	import (
-		"hash"
-		"math/big"
+import (
+	"hash"
+	"math/big"
 
-		"github.com/consensys/gurvy/bn256/fr"
-		"golang.org/x/crypto/sha3"
-	)
+	"github.com/consensys/gurvy/{{toLower .Curve}}/fr"
+	"golang.org/x/crypto/sha3"
+)
 
+{{ if eq .Curve "BW761" }}
+	// mimcNbRounds = ceil(377 / log2(5))
+	const mimcNbRounds = 163
+{{ else }}
 	const mimcNbRounds = 91
+{{ end }}
 
-	// BlockSize size that mimc consumes
-	const BlockSize = 32
-
-	// Params constants for the mimc hash function
-	type Params []fr.Element
-
-	// NewParams creates new mimc object
-	func NewParams(seed string) Params {
-
-		// set the constants
-		res := make(Params, mimcNbRounds)
+// BlockSize size that mimc consumes
+const BlockSize = fr.Bytes
 
-		rnd := sha3.Sum256([]byte(seed))
-		value := new(big.Int).SetBytes(rnd[:])
+// Params constants for the mimc hash function
+type Params []fr.Element
 
-		for i := 0; i < mimcNbRounds; i++ {
-			rnd = sha3.Sum256(value.Bytes())
-			value.SetBytes(rnd[:])
-			res[i].SetBigInt(value)
-		}
-
-		return res
-	}
-{{ else if eq .Curve "BLS377" }}
-	import (
-		"hash"
-		"math/big"
-
-		"github.com/consensys/gurvy/bls377/fr"
-		"golang.org/x/crypto/sha3"
-	)
-
-	const mimcNbRounds = 91
-
-	// BlockSize size that mimc consumes
-	const BlockSize = 32
-
-	// Params constants for the mimc hash function
-	type Params []fr.Element
-
-	// NewParams creates new mimc object
-	func NewParams(seed string) Params {
+// NewParams creates new mimc object
+func NewParams(seed string) Params {
+	return newParams(seed, mimcNbRounds)
+}
 
-		// set the constants
-		res := make(Params, mimcNbRounds)
+// newParams returns nbRounds constants derived from seed
+func newParams(seed string, nbRounds int) Params {
 
-		rnd := sha3.Sum256([]byte(seed))
-		value := new(big.Int).SetBytes(rnd[:])
+	// set the constants
+	res := make(Params, nbRounds)
 
-		for i := 0; i < mimcNbRounds; i++ {
-			rnd = sha3.Sum256(value.Bytes())
-			value.SetBytes(rnd[:])
-			res[i].SetBigInt(value)
-		}
+	rnd := sha3.Sum256([]byte(seed))
+	value := new(big.Int).SetBytes(rnd[:])
 
-		return res
+	for i := 0; i < nbRounds; i++ {
+		rnd = sha3.Sum256(value.Bytes())
+		value.SetBytes(rnd[:])
+		res[i].SetBigInt(value)
 	}
-{{ else if eq .Curve "BLS381" }}
-	import (
-		"hash"
-		"math/big"
-
-		"github.com/consensys/gurvy/bls381/fr"
-		"golang.org/x/crypto/sha3"
-	)
-
-	const mimcNbRounds = 91
-
-	// BlockSize size that mimc consumes
-	const BlockSize = 32
-
-	// Params constants for the mimc hash function
-	type Params []fr.Element
 
-	// NewParams creates new mimc object
-	func NewParams(seed string) Params {
-
-		// set the constants
-		res := make(Params, mimcNbRounds)
-
-		rnd := sha3.Sum256([]byte(seed))
-		value := new(big.Int).SetBytes(rnd[:])
-
-		for i := 0; i < mimcNbRounds; i++ {
-			rnd = sha3.Sum256(value.Bytes())
-			value.SetBytes(rnd[:])
-			res[i].SetBigInt(value)
-		}
-
-		return res
-	}
-{{end}}
+	return res
+}
 
 {{end}}
 `
@@ -194,7 +156,7 @@ func NewMiMC(seed string) hash.Hash {
 // Reset resets the Hash to its initial state.
 func (d *digest) Reset() {
 	d.data = nil
-	d.h = fr.Element{0, 0, 0, 0}
+	d.h.SetZero()
 }
 
 // Sum appends the current hash to b and returns the resulting slice.
@@ -233,7 +195,7 @@ func (d *digest) Write(p []byte) (n int, err error) {
 // The XOR operation is replaced by field addition, data is in Montgomery form
 func (d *digest) checksum() fr.Element {
 
-	var buffer [32]byte
+	var buffer [BlockSize]byte
 	var x fr.Element
 
 	// if data size is not multiple of BlockSizes we padd:
@@ -251,7 +213,7 @@ func (d *digest) checksum() fr.Element {
 	}
 
 	if len(d.data) == 0 {
-		d.data = make([]byte, 32)
+		d.data = make([]byte, BlockSize)
 	}
 
 	nbChunks := len(d.data) / BlockSize
@@ -280,4 +242,92 @@ func Sum(seed string, msg []byte) ([]byte, error) {
 	bytes := h.Bytes()
 	return bytes[:], nil
 }
+
+// NewSpongeParams returns the constants of the permutation of the mimc sponge of the given rate
+func NewSpongeParams(seed string, rate int) Params {
+	return newParams(seed, (rate+1)*mimcNbRounds)
+}
+
+// Sponge is a mimc sponge, absorbing and squeezing field elements
+//
+// the state is rate elements followed by the capacity element. The permutation is a generalized
+// Feistel network (x_0, ..., x_rate) <- (x_1 + sbox(x_0 + c), x_2, ..., x_rate, x_0), with
+// (rate+1)*mimcNbRounds rounds such that each element goes through mimcNbRounds S-boxes: with a
+// rate of 1 element, it is the Feistel network MiMC-2n/n on (xL, xR).
+//
+// The rate is full after rate absorbed elements, which are followed by a permutation. When the sponge
+// starts squeezing after a partially filled rate, the rate is padded with zeros, the number of elements
+// absorbed in it is added to the capacity element and the state is permuted: (x) and (x, 0) are
+// distinct inputs.
+type Sponge struct {
+	Params    Params
+	state     []fr.Element // rate elements, followed by the capacity element
+	pos       int          // position in the rate of the next absorbed or squeezed element
+	squeezing bool
+}
+
+// NewSponge returns a mimc sponge of the given rate (at least 1), with the constants derived from seed
+func NewSponge(seed string, rate int) *Sponge {
+	if rate < 1 {
+		panic("mimc: the rate of the sponge must be at least 1")
+	}
+	return &Sponge{Params: NewSpongeParams(seed, rate), state: make([]fr.Element, rate+1)}
+}
+
+// Absorb adds each element of data to the rate, with a permutation each time the rate is full
+func (s *Sponge) Absorb(data ...fr.Element) {
+	rate := len(s.state) - 1
+	if s.squeezing {
+		s.squeezing = false
+		s.pos = 0
+	}
+	for i := 0; i < len(data); i++ {
+		s.state[s.pos].Add(&s.state[s.pos], &data[i])
+		s.pos++
+		if s.pos == rate {
+			s.permutation()
+			s.pos = 0
+		}
+	}
+}
+
+// Squeeze returns n elements, read from the rate, with a permutation each time the rate is exhausted
+func (s *Sponge) Squeeze(n int) []fr.Element {
+	rate := len(s.state) - 1
+	if !s.squeezing {
+		if s.pos != 0 {
+			var length fr.Element
+			length.SetUint64(uint64(s.pos))
+			s.state[rate].Add(&s.state[rate], &length)
+			s.permutation()
+		}
+		s.squeezing = true
+		s.pos = 0
+	}
+	res := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		if s.pos == rate {
+			s.permutation()
+			s.pos = 0
+		}
+		res[i] = s.state[s.pos]
+		s.pos++
+	}
+	return res
+}
+
+// permutation (x_0, ..., x_rate) <- (x_1 + sbox(x_0 + c), x_2, ..., x_rate, x_0), for each constant c
+func (s *Sponge) permutation() {
+	var tmp fr.Element
+	last := len(s.state) - 1
+	for i := 0; i < len(s.Params); i++ {
+		tmp.Add(&s.state[0], &s.Params[i])
+		sbox(&tmp)
+		tmp.Add(&tmp, &s.state[1])
+		x0 := s.state[0]
+		s.state[0] = tmp
+		copy(s.state[1:], s.state[2:])
+		s.state[last] = x0
+	}
+}
 `
@@ -0,0 +1,181 @@
+package main
+
+const poseidonTemplate = `
+
+import (
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark/crypto/hash/poseidon"
+	"github.com/consensys/gurvy/{{toLower .Curve}}/fr"
+)
+
+// BlockSize size of the blocks of data converted to field elements, one byte less than an
+// element such that a block is always less than the field order
+const BlockSize = fr.Bytes - 1
+
+// Params of the Poseidon permutation, see poseidon.NewParams
+type Params struct {
+	T               int
+	NbFullRounds    int
+	NbPartialRounds int
+	Alpha           uint64
+	RoundConstants  [][]fr.Element
+	MDS             [][]fr.Element
+	alpha           big.Int
+}
+
+// NewParams returns the parameters of the Poseidon permutation of width t
+func NewParams(t int) *Params {
+	p := poseidon.NewParams(fr.Modulus(), t)
+
+	res := &Params{
+		T:               p.T,
+		NbFullRounds:    p.NbFullRounds,
+		NbPartialRounds: p.NbPartialRounds,
+		Alpha:           p.Alpha,
+		RoundConstants:  make([][]fr.Element, len(p.RoundConstants)),
+		MDS:             make([][]fr.Element, len(p.MDS)),
+	}
+	res.alpha.SetUint64(p.Alpha)
+	for i := 0; i < len(p.RoundConstants); i++ {
+		res.RoundConstants[i] = make([]fr.Element, len(p.RoundConstants[i]))
+		for j := 0; j < len(p.RoundConstants[i]); j++ {
+			res.RoundConstants[i][j].SetBigInt(&p.RoundConstants[i][j])
+		}
+	}
+	for i := 0; i < len(p.MDS); i++ {
+		res.MDS[i] = make([]fr.Element, len(p.MDS[i]))
+		for j := 0; j < len(p.MDS[i]); j++ {
+			res.MDS[i][j].SetBigInt(&p.MDS[i][j])
+		}
+	}
+	return res
+}
+
+// Permutation applies the Poseidon permutation to state, of length params.T
+func (params *Params) Permutation(state []fr.Element) {
+	half := params.NbFullRounds / 2
+	mix := make([]fr.Element, params.T)
+
+	for r := 0; r < len(params.RoundConstants); r++ {
+		for i := 0; i < params.T; i++ {
+			state[i].Add(&state[i], &params.RoundConstants[r][i])
+		}
+
+		if r < half || r >= half+params.NbPartialRounds {
+			for i := 0; i < params.T; i++ {
+				state[i].Exp(state[i], &params.alpha)
+			}
+		} else {
+			state[0].Exp(state[0], &params.alpha)
+		}
+
+		var t fr.Element
+		for i := 0; i < params.T; i++ {
+			mix[i].SetZero()
+			for j := 0; j < params.T; j++ {
+				t.Mul(&params.MDS[i][j], &state[j])
+				mix[i].Add(&mix[i], &t)
+			}
+		}
+		copy(state, mix)
+	}
+}
+
+// Hash absorbs data in a sponge of rate T-1 and capacity 1 (the first element of the state),
+// and returns the first element of the state
+//
+// The capacity element starts at poseidon.LengthTag(len(data)), and data is absorbed by chunks of
+// T-1 elements, the last one padded with zeros: the tag separates the inputs that are equal once
+// padded, such as (x) and (x, 0). Without the tag, hashing T-1 elements would be the circomlib
+// Poseidon, a single permutation of (0, data...).
+func (params *Params) Hash(data ...fr.Element) fr.Element {
+	rate := params.T - 1
+	state := make([]fr.Element, params.T)
+	state[0].SetBigInt(poseidon.LengthTag(len(data)))
+	if len(data) == 0 {
+		// a single permutation of the tag
+		data = make([]fr.Element, 1)
+	}
+	for i := 0; i < len(data); i += rate {
+		for j := 0; j < rate && i+j < len(data); j++ {
+			state[1+j].Add(&state[1+j], &data[i+j])
+		}
+		params.Permutation(state)
+	}
+	return state[0]
+}
+
+// digest represents the partial evaluation of the checksum
+// along with the params of the poseidon function
+type digest struct {
+	params *Params
+	data   []byte // data to hash
+}
+
+// NewPoseidon returns a Poseidon hash.Hash of width t, pure-go reference implementation
+//
+// the data is padded with a byte 1 and zeros to a multiple of BlockSize bytes, then each block
+// is converted to a field element (big endian) and the elements are hashed with Params.Hash. As
+// the padding and the conversion are injective, distinct data are distinct elements.
+func NewPoseidon(t int) hash.Hash {
+	return &digest{params: NewParams(t)}
+}
+
+// Reset resets the Hash to its initial state.
+func (d *digest) Reset() {
+	d.data = nil
+}
+
+// Sum appends the current hash to b and returns the resulting slice.
+// It does not change the underlying hash state.
+func (d *digest) Sum(b []byte) []byte {
+	h := d.params.Hash(toElements(d.data)...)
+	hash := h.Bytes()
+	return append(b, hash[:]...)
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *digest) Size() int {
+	return fr.Bytes
+}
+
+// BlockSize returns the hash's underlying block size.
+// The Write method must be able to accept any amount
+// of data, but it may operate more efficiently if all writes
+// are a multiple of the block size.
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
+
+// Write (via the embedded io.Writer interface) adds more data to the running hash.
+// It never returns an error.
+func (d *digest) Write(p []byte) (n int, err error) {
+	d.data = append(d.data, p...)
+	return len(p), nil
+}
+
+// toElements pads data and converts it to field elements, by blocks of BlockSize bytes
+// .. || 0xaf8 -> .. || 0xaf80100...00
+func toElements(data []byte) []fr.Element {
+	padded := make([]byte, (len(data)/BlockSize+1)*BlockSize)
+	copy(padded, data)
+	padded[len(data)] = 1
+
+	res := make([]fr.Element, len(padded)/BlockSize)
+	for i := 0; i < len(res); i++ {
+		res[i].SetBytes(padded[i*BlockSize : (i+1)*BlockSize])
+	}
+	return res
+}
+
+// Sum computes the poseidon hash of msg, with a permutation of width t
+func Sum(t int, msg []byte) ([]byte, error) {
+	d := NewPoseidon(t)
+	if _, err := d.Write(msg); err != nil {
+		return nil, err
+	}
+	return d.Sum(nil), nil
+}
+`
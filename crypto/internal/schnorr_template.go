@@ -0,0 +1,186 @@
+package main
+
+const schnorrTemplate = `
+
+import (
+	"errors"
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gurvy/{{toLower .Curve}}/fr"
+	{{- if or (eq .Curve "BN256") (eq .Curve "BLS381") }}
+	"github.com/consensys/gurvy/{{toLower .Curve}}/twistededwards"
+	{{- else }}
+	twistededwards "github.com/consensys/gnark/crypto/algebra/twistededwards/{{toLower .Curve}}"
+	{{- end }}
+	"golang.org/x/crypto/blake2b"
+)
+
+var errNotOnCurve = errors.New("point not on curve")
+
+const frSize = fr.Bytes
+
+// Signature represents a Schnorr signature (E, S), where E = H(R, A, M) and S = r - E*s,
+// with R = r*Base, A = s*Base the public key, and M the message
+// cf https://en.wikipedia.org/wiki/Schnorr_signature for notation
+type Signature struct {
+	E big.Int
+	S big.Int
+}
+
+// PublicKey schnorr signature object
+type PublicKey struct {
+	A     twistededwards.Point
+	HFunc hash.Hash
+}
+
+// PrivateKey private key of a schnorr instance
+type PrivateKey struct {
+	randSrc [32]byte // randomizer (random = H(randSrc,msg))
+	scalar  big.Int  // secret scalar
+}
+
+// GetCurveParams get the parameters of the Edwards curve used
+func GetCurveParams() twistededwards.CurveParams {
+	return twistededwards.GetEdwardsCurve()
+}
+
+// New creates an instance of schnorr
+func New(seed [32]byte, hFunc hash.Hash) (PublicKey, PrivateKey) {
+
+	c := GetCurveParams()
+
+	var pub PublicKey
+	var priv PrivateKey
+
+	h := blake2b.Sum512(seed[:])
+	copy(priv.randSrc[:], h[32:])
+	priv.scalar.SetBytes(h[:32]).Mod(&priv.scalar, &c.Order)
+
+	pub.A.ScalarMul(&c.Base, &priv.scalar)
+	pub.HFunc = hFunc
+
+	return pub, priv
+}
+
+// Sign sign a message
+func Sign(message []byte, pub PublicKey, priv PrivateKey) (Signature, error) {
+
+	curveParams := GetCurveParams()
+
+	var res Signature
+
+	// r = H(randSrc || msg), R = r*Base
+	randBytes := blake2b.Sum512(append(priv.randSrc[:], message...))
+	var r big.Int
+	r.SetBytes(randBytes[:]).Mod(&r, &curveParams.Order)
+
+	var R twistededwards.Point
+	R.ScalarMul(&curveParams.Base, &r)
+	if !R.IsOnCurve() {
+		return Signature{}, errNotOnCurve
+	}
+
+	// E = H(R, A, M)
+	if err := hram(&res.E, pub.HFunc, &R, &pub.A, message); err != nil {
+		return Signature{}, err
+	}
+
+	// S = r - E*s
+	res.S.Mul(&res.E, &priv.scalar).
+		Sub(&r, &res.S).
+		Mod(&res.S, &curveParams.Order)
+
+	return res, nil
+}
+
+// Verify verifies a schnorr signature, checking that E = H(S*Base + E*A, A, M)
+func Verify(sig Signature, message []byte, pub PublicKey) (bool, error) {
+
+	curveParams := GetCurveParams()
+
+	// verify that pubKey is on the curve
+	if !pub.A.IsOnCurve() {
+		return false, errNotOnCurve
+	}
+
+	// R = S*Base + E*A
+	var R, tmp twistededwards.Point
+	R.ScalarMul(&curveParams.Base, &sig.S)
+	tmp.ScalarMul(&pub.A, &sig.E)
+	R.Add(&R, &tmp)
+
+	var e big.Int
+	if err := hram(&e, pub.HFunc, &R, &pub.A, message); err != nil {
+		return false, err
+	}
+
+	return e.Cmp(&sig.E) == 0, nil
+}
+
+// hram sets e to H(R, A, M), all parameters in data are in Montgomery form
+func hram(e *big.Int, hFunc hash.Hash, R, A *twistededwards.Point, message []byte) error {
+	rX := R.X.Bytes()
+	rY := R.Y.Bytes()
+	aX := A.X.Bytes()
+	aY := A.Y.Bytes()
+	dataToHash := make([]byte, 4*frSize+len(message))
+	copy(dataToHash[:], rX[:])
+	copy(dataToHash[frSize:], rY[:])
+	copy(dataToHash[2*frSize:], aX[:])
+	copy(dataToHash[3*frSize:], aY[:])
+	copy(dataToHash[4*frSize:], message)
+	hFunc.Reset()
+	if _, err := hFunc.Write(dataToHash[:]); err != nil {
+		return err
+	}
+	e.SetBytes(hFunc.Sum([]byte{}))
+	return nil
+}
+`
+
+const schnorrTestTemplate = `
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark/crypto/hash/mimc/{{toLower .Curve}}"
+	"github.com/consensys/gurvy/{{toLower .Curve}}/fr"
+)
+
+func TestSchnorr(t *testing.T) {
+
+	var seed [32]byte
+	copy(seed[:], "schnorr")
+
+	pubKey, privKey := New(seed, {{toLower .Curve}}.NewMiMC("seed"))
+
+	var frMsg fr.Element
+	frMsg.SetString("44717650746155748460101257525078853138837311576962212923649547644148297035978")
+	msgBin := frMsg.Bytes()
+	signature, err := Sign(msgBin[:], pubKey, privKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// verifies correct msg
+	res, err := Verify(signature, msgBin[:], pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("Verify correct signature should return true")
+	}
+
+	// verifies wrong msg
+	frMsg.SetString("44717650746155748460101257525078853138837311576962212923649547644148297035979")
+	msgBin = frMsg.Bytes()
+	res, err = Verify(signature, msgBin[:], pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("Verify wrong signature should be false")
+	}
+}
+`
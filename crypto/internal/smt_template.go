@@ -0,0 +1,325 @@
+package main
+
+const smtTemplate = `
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark/crypto/accumulator/smt"
+	"github.com/consensys/gurvy/{{toLower .Curve}}/fr"
+)
+
+var (
+	errInvalidDepth = errors.New("depth must be positive")
+	errKeyTooLarge  = errors.New("key does not fit in the depth of the tree")
+	errKeyIsSet     = errors.New("key is set")
+	errZeroValue    = errors.New("the zero value marks an absent key and can't be set")
+)
+
+// Tree is a sparse Merkle tree of fixed depth, keyed by field element, see package smt
+//
+// only the nodes which differ from the root of an empty subtree are stored.
+type Tree struct {
+	hash   hash.Hash
+	depth  int
+	empty  [][]byte              // empty[i] is the root of an empty subtree of height i
+	nodes  []map[string][]byte   // nodes[i] are the non empty nodes at height i, by index
+	values map[string]fr.Element // values of the non empty leaves, by key
+}
+
+// New creates an empty Tree of the given depth. The provided hash will be used for all hashing
+// operations within the Tree.
+func New(h hash.Hash, depth int) (*Tree, error) {
+	if depth <= 0 {
+		return nil, errInvalidDepth
+	}
+	t := &Tree{
+		hash:   h,
+		depth:  depth,
+		empty:  make([][]byte, depth+1),
+		nodes:  make([]map[string][]byte, depth+1),
+		values: make(map[string]fr.Element),
+	}
+	t.empty[0] = leafSum(h, fr.Element{})
+	for i := 1; i <= depth; i++ {
+		t.empty[i] = nodeSum(h, t.empty[i-1], t.empty[i-1])
+	}
+	for i := 0; i <= depth; i++ {
+		t.nodes[i] = make(map[string][]byte)
+	}
+	return t, nil
+}
+
+// Depth returns the depth of the tree, which is also the length of its proofs
+func (t *Tree) Depth() int {
+	return t.depth
+}
+
+// Root returns the Merkle root of the tree
+func (t *Tree) Root() []byte {
+	return t.node(t.depth, new(big.Int))
+}
+
+// Get returns the value stored at key, zero if the key is not set
+func (t *Tree) Get(key fr.Element) (fr.Element, error) {
+	k, err := t.key(key)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	return t.values[string(k.Bytes())], nil
+}
+
+// Set stores value at key and updates the root. The zero value is rejected, use Remove.
+func (t *Tree) Set(key, value fr.Element) error {
+	if value.IsZero() {
+		return errZeroValue
+	}
+	return t.set(key, value)
+}
+
+// Remove removes key from the tree and updates the root, nothing changes if the key is not set
+func (t *Tree) Remove(key fr.Element) error {
+	return t.set(key, fr.Element{})
+}
+
+// set stores value at key, or removes the key if value is zero, and updates the nodes on the
+// path from the leaf to the root
+func (t *Tree) set(key, value fr.Element) error {
+	k, err := t.key(key)
+	if err != nil {
+		return err
+	}
+
+	if value.IsZero() {
+		delete(t.values, string(k.Bytes()))
+	} else {
+		t.values[string(k.Bytes())] = value
+	}
+
+	sum := leafSum(t.hash, value)
+	index := new(big.Int).Set(k)
+	sibling := new(big.Int)
+	for i := 0; i <= t.depth; i++ {
+		t.setNode(i, index, sum)
+		if i == t.depth {
+			break
+		}
+		sibling.SetBit(index, 0, index.Bit(0)^1)
+		if index.Bit(0) == 0 {
+			sum = nodeSum(t.hash, sum, t.node(i, sibling))
+		} else {
+			sum = nodeSum(t.hash, t.node(i, sibling), sum)
+		}
+		index.Rsh(index, 1)
+	}
+	return nil
+}
+
+// Prove returns the value stored at key (zero if the key is not set), and the proof set
+// proving it is in the tree. The proof set contains the siblings of the nodes on the
+// path from the leaf to the root, starting from the sibling of the leaf.
+func (t *Tree) Prove(key fr.Element) (value fr.Element, proofSet [][]byte, err error) {
+	k, err := t.key(key)
+	if err != nil {
+		return fr.Element{}, nil, err
+	}
+
+	proofSet = make([][]byte, t.depth)
+	index := new(big.Int).Set(k)
+	sibling := new(big.Int)
+	for i := 0; i < t.depth; i++ {
+		sibling.SetBit(index, 0, index.Bit(0)^1)
+		proofSet[i] = t.node(i, sibling)
+		index.Rsh(index, 1)
+	}
+	return t.values[string(k.Bytes())], proofSet, nil
+}
+
+// ProveNonMembership returns the proof set proving that key is not set, that is, the leaf
+// at key stores the zero value. An error is returned if the key is set.
+func (t *Tree) ProveNonMembership(key fr.Element) (proofSet [][]byte, err error) {
+	value, proofSet, err := t.Prove(key)
+	if err != nil {
+		return nil, err
+	}
+	if !value.IsZero() {
+		return nil, errKeyIsSet
+	}
+	return proofSet, nil
+}
+
+// VerifyProof returns true if proofSet proves that value is stored at key in the tree whose
+// root is merkleRoot. The zero value verifies a proof of non membership.
+func VerifyProof(h hash.Hash, merkleRoot []byte, key, value fr.Element, proofSet [][]byte) bool {
+	if merkleRoot == nil || len(proofSet) == 0 {
+		return false
+	}
+	var k big.Int
+	key.ToBigIntRegular(&k)
+	if k.BitLen() > len(proofSet) {
+		return false
+	}
+
+	sum := leafSum(h, value)
+	for i := 0; i < len(proofSet); i++ {
+		if k.Bit(i) == 0 {
+			sum = nodeSum(h, sum, proofSet[i])
+		} else {
+			sum = nodeSum(h, proofSet[i], sum)
+		}
+	}
+	return bytes.Equal(sum, merkleRoot)
+}
+
+// key returns key as an integer, or an error if it doesn't fit in the depth of the tree
+func (t *Tree) key(key fr.Element) (*big.Int, error) {
+	k := new(big.Int)
+	key.ToBigIntRegular(k)
+	if k.BitLen() > t.depth {
+		return nil, errKeyTooLarge
+	}
+	return k, nil
+}
+
+// node returns the node at the given height and index
+func (t *Tree) node(height int, index *big.Int) []byte {
+	if n, ok := t.nodes[height][string(index.Bytes())]; ok {
+		return n
+	}
+	return t.empty[height]
+}
+
+// setNode stores the node at the given height and index, or removes it if it is the root of an
+// empty subtree
+func (t *Tree) setNode(height int, index *big.Int, sum []byte) {
+	if bytes.Equal(sum, t.empty[height]) {
+		delete(t.nodes[height], string(index.Bytes()))
+		return
+	}
+	t.nodes[height][string(index.Bytes())] = sum
+}
+
+// sum returns the hash of the input data using the specified algorithm.
+func sum(h hash.Hash, data ...[]byte) []byte {
+	h.Reset()
+	for _, d := range data {
+		// the Hash interface specifies that Write never returns an error
+		_, _ = h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// leafSum returns the hash of a leaf storing value, Hash(LeafTag || value)
+func leafSum(h hash.Hash, value fr.Element) []byte {
+	v := value.Bytes()
+	return sum(h, tag(smt.LeafTag), v[:])
+}
+
+// nodeSum returns the hash created from two sibling nodes being combined into a parent node,
+// Hash(NodeTag || a || b)
+func nodeSum(h hash.Hash, a, b []byte) []byte {
+	return sum(h, tag(smt.NodeTag), a, b)
+}
+
+// tag returns the bytes of the field element t
+func tag(t uint64) []byte {
+	var e fr.Element
+	e.SetUint64(t)
+	b := e.Bytes()
+	return b[:]
+}
+`
+
+const smtTestTemplate = `
+
+import (
+	"bytes"
+	"testing"
+
+	mimc "github.com/consensys/gnark/crypto/hash/mimc/{{toLower .Curve}}"
+	"github.com/consensys/gurvy/{{toLower .Curve}}/fr"
+)
+
+const depth = 8
+
+// element returns the field element v
+func element(v uint64) fr.Element {
+	var e fr.Element
+	e.SetUint64(v)
+	return e
+}
+
+func TestTree(t *testing.T) {
+	tree, err := New(mimc.NewMiMC("seed"), depth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	emptyRoot := tree.Root()
+
+	if err := tree.Set(element(3), element(42)); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := tree.Get(element(3)); v != element(42) {
+		t.Fatal("Get should return the value which was set")
+	}
+	if v, _ := tree.Get(element(4)); !v.IsZero() {
+		t.Fatal("Get should return zero for a key which is not set")
+	}
+	if err := tree.Set(element(1<<depth), element(1)); err == nil {
+		t.Fatal("a key larger than 2^depth should be rejected")
+	}
+	if err := tree.Set(element(4), element(0)); err == nil {
+		t.Fatal("the zero value should be rejected")
+	}
+	if _, err := tree.ProveNonMembership(element(3)); err == nil {
+		t.Fatal("non membership of a key which is set should be rejected")
+	}
+
+	value, proof, err := tree.Prove(element(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyProof(mimc.NewMiMC("seed"), tree.Root(), element(3), value, proof) {
+		t.Fatal("the proof in plain go should pass")
+	}
+	if VerifyProof(mimc.NewMiMC("seed"), tree.Root(), element(5), value, proof) {
+		t.Fatal("the proof for another key should fail")
+	}
+
+	proof, err = tree.ProveNonMembership(element(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyProof(mimc.NewMiMC("seed"), tree.Root(), element(4), element(0), proof) {
+		t.Fatal("the proof of non membership should pass")
+	}
+	if VerifyProof(mimc.NewMiMC("seed"), tree.Root(), element(4), element(42), proof) {
+		t.Fatal("the proof of non membership should not prove a value")
+	}
+
+	// removing the key gives back the empty tree
+	if err := tree.Remove(element(3)); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(tree.Root(), emptyRoot) {
+		t.Fatal("the root should be the root of the empty tree")
+	}
+}
+
+func TestDomainSeparation(t *testing.T) {
+	h := mimc.NewMiMC("seed")
+	a, b := leafSum(h, element(1)), leafSum(h, element(2))
+
+	one := element(1)
+	v := one.Bytes()
+	if bytes.Equal(leafSum(h, one), sum(h, v[:])) {
+		t.Fatal("leaves should be hashed with LeafTag")
+	}
+	if bytes.Equal(nodeSum(h, a, b), sum(h, a, b)) {
+		t.Fatal("nodes should be hashed with NodeTag")
+	}
+}
+`
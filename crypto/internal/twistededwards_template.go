@@ -0,0 +1,365 @@
+package main
+
+// twisted Edwards curves embedded in the scalar field of curves for which gurvy doesn't provide them yet
+// (bn256 and bls381 use gurvy/{curve}/twistededwards)
+
+const twistedEdwardsTemplate = `
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gurvy/{{toLower .Curve}}/fr"
+)
+
+// CurveParams curve parameters: ax^2 + y^2 = 1 + d*x^2*y^2
+type CurveParams struct {
+	A, D     fr.Element // in Montgomery form
+	Cofactor fr.Element // not in Montgomery form
+	Order    big.Int
+	Base     Point
+}
+
+var edwards CurveParams
+var initOnce sync.Once
+
+// GetEdwardsCurve returns the twisted Edwards curve on {{.Curve}}'s Fr
+func GetEdwardsCurve() CurveParams {
+	initOnce.Do(initEd{{.Curve}})
+	return edwards
+}
+
+func initEd{{.Curve}}() {
+{{ if eq .Curve "BLS377" }}
+	edwards.A.SetString("8444461749428370424248824938781546531375899335154063827935233455917409239040") // -1
+	edwards.D.SetUint64(3021)
+	edwards.Cofactor.SetUint64(4).FromMont()
+	edwards.Order.SetString("2111115437357092606062206234695386632838870926408408195193685246394721360383", 10)
+
+	edwards.Base.X.SetString("717051916204163000937139483451426116831771857428389560441264442629694842243")
+	edwards.Base.Y.SetString("882565546457454111605105352482086902132191855952243170543452705048019814192")
+{{ else if eq .Curve "BW761" }}
+	edwards.A.SetString("258664426012969094010652733694893533536393512754914660539884262666720468348340822774968888139573360124440321458176") // -1
+	edwards.D.SetUint64(79743)
+	edwards.Cofactor.SetUint64(8).FromMont()
+	edwards.Order.SetString("32333053251621136751331591711861691692049189094364332567435817881934511297123972799646723302813083835942624121493", 10)
+
+	// cofactor times the point of smallest y
+	edwards.Base.X.SetString("136036368895934182660683598356860929301626347763617081130112690621600647994647215878319745636317269127728135244023")
+	edwards.Base.Y.SetString("6024428474054068658506791911508707895140380365230084187492084459156833056077725572090028084095717295372664768785")
+{{ end }}
+}
+`
+
+const twistedEdwardsPointTemplate = `
+
+import (
+	"math/big"
+
+	"github.com/consensys/gurvy/{{toLower .Curve}}/fr"
+)
+
+// Point point on a twisted Edwards curve
+type Point struct {
+	X, Y fr.Element
+}
+
+// PointProj point in projective coordinates
+type PointProj struct {
+	X, Y, Z fr.Element
+}
+
+// Set sets p to p1 and return it
+func (p *PointProj) Set(p1 *PointProj) *PointProj {
+	p.X.Set(&p1.X)
+	p.Y.Set(&p1.Y)
+	p.Z.Set(&p1.Z)
+	return p
+}
+
+// Set sets p to p1 and return it
+func (p *Point) Set(p1 *Point) *Point {
+	p.X.Set(&p1.X)
+	p.Y.Set(&p1.Y)
+	return p
+}
+
+// Equal returns true if p=p1 false otherwise
+func (p *Point) Equal(p1 *Point) bool {
+	return p.X.Equal(&p1.X) && p.Y.Equal(&p1.Y)
+}
+
+// Equal returns true if p=p1 false otherwise
+// If one point is on the affine chart Z=0 it returns false
+func (p *PointProj) Equal(p1 *PointProj) bool {
+	if p.Z.IsZero() || p1.Z.IsZero() {
+		return false
+	}
+	var pAffine, p1Affine Point
+	pAffine.FromProj(p)
+	p1Affine.FromProj(p1)
+	return pAffine.Equal(&p1Affine)
+}
+
+// NewPoint creates a new instance of Point
+func NewPoint(x, y fr.Element) Point {
+	return Point{x, y}
+}
+
+// IsOnCurve checks if a point is on the twisted Edwards curve
+func (p *Point) IsOnCurve() bool {
+
+	ecurve := GetEdwardsCurve()
+
+	var lhs, rhs, tmp fr.Element
+
+	tmp.Mul(&p.Y, &p.Y)
+	lhs.Mul(&p.X, &p.X).
+		Mul(&lhs, &ecurve.A).
+		Add(&lhs, &tmp)
+
+	tmp.Mul(&p.X, &p.X).
+		Mul(&tmp, &p.Y).
+		Mul(&tmp, &p.Y).
+		Mul(&tmp, &ecurve.D)
+	rhs.SetOne().Add(&rhs, &tmp)
+
+	return lhs.Equal(&rhs)
+}
+
+// Add adds two points (x,y), (u,v) on a twisted Edwards curve with parameters a, d
+// modifies p
+func (p *Point) Add(p1, p2 *Point) *Point {
+
+	ecurve := GetEdwardsCurve()
+
+	var xu, yv, xv, yu, dxyuv, one, denx, deny fr.Element
+	pRes := new(Point)
+	xv.Mul(&p1.X, &p2.Y)
+	yu.Mul(&p1.Y, &p2.X)
+	pRes.X.Add(&xv, &yu)
+
+	xu.Mul(&p1.X, &p2.X).Mul(&xu, &ecurve.A)
+	yv.Mul(&p1.Y, &p2.Y)
+	pRes.Y.Sub(&yv, &xu)
+
+	dxyuv.Mul(&xv, &yu).Mul(&dxyuv, &ecurve.D)
+	one.SetOne()
+	denx.Add(&one, &dxyuv)
+	deny.Sub(&one, &dxyuv)
+
+	p.X.Div(&pRes.X, &denx)
+	p.Y.Div(&pRes.Y, &deny)
+
+	return p
+}
+
+// Double doubles point (x,y) on a twisted Edwards curve with parameters a, d
+// modifies p
+func (p *Point) Double(p1 *Point) *Point {
+	p.Add(p1, p1)
+	return p
+}
+
+// Neg negates point (x,y) on a twisted Edwards curve with parameters a, d
+// modifies p
+func (p *Point) Neg(p1 *Point) *Point {
+	p.X.Neg(&p1.X)
+	p.Y.Set(&p1.Y)
+	return p
+}
+
+// FromProj sets p in affine from p in projective
+func (p *Point) FromProj(p1 *PointProj) *Point {
+	p.X.Div(&p1.X, &p1.Z)
+	p.Y.Div(&p1.Y, &p1.Z)
+	return p
+}
+
+// FromAffine sets p in projective from p in affine
+func (p *PointProj) FromAffine(p1 *Point) *PointProj {
+	p.X.Set(&p1.X)
+	p.Y.Set(&p1.Y)
+	p.Z.SetOne()
+	return p
+}
+
+// Add adds points in projective coordinates
+// cf https://hyperelliptic.org/EFD/g1p/auto-twisted-projective.html
+func (p *PointProj) Add(p1, p2 *PointProj) *PointProj {
+
+	var res PointProj
+
+	ecurve := GetEdwardsCurve()
+
+	var A, B, C, D, E, F, G, H, I fr.Element
+	A.Mul(&p1.Z, &p2.Z)
+	B.Square(&A)
+	C.Mul(&p1.X, &p2.X)
+	D.Mul(&p1.Y, &p2.Y)
+	E.Mul(&ecurve.D, &C).Mul(&E, &D)
+	F.Sub(&B, &E)
+	G.Add(&B, &E)
+	H.Add(&p1.X, &p1.Y)
+	I.Add(&p2.X, &p2.Y)
+	res.X.Mul(&H, &I).
+		Sub(&res.X, &C).
+		Sub(&res.X, &D).
+		Mul(&res.X, &A).
+		Mul(&res.X, &F)
+	H.Mul(&ecurve.A, &C)
+	res.Y.Sub(&D, &H).
+		Mul(&res.Y, &A).
+		Mul(&res.Y, &G)
+	res.Z.Mul(&F, &G)
+
+	p.Set(&res)
+	return p
+}
+
+// Double adds points in projective coordinates
+// cf https://hyperelliptic.org/EFD/g1p/auto-twisted-projective.html
+func (p *PointProj) Double(p1 *PointProj) *PointProj {
+
+	var res PointProj
+
+	ecurve := GetEdwardsCurve()
+
+	var B, C, D, E, F, H, J, tmp fr.Element
+
+	B.Add(&p1.X, &p1.Y).Square(&B)
+	C.Square(&p1.X)
+	D.Square(&p1.Y)
+	E.Mul(&ecurve.A, &C)
+	F.Add(&E, &D)
+	H.Square(&p1.Z)
+	tmp.Double(&H)
+	J.Sub(&F, &tmp)
+	res.X.Sub(&B, &C).
+		Sub(&res.X, &D).
+		Mul(&res.X, &J)
+	res.Y.Sub(&E, &D).Mul(&res.Y, &F)
+	res.Z.Mul(&F, &J)
+
+	p.Set(&res)
+	return p
+}
+
+// Neg sets p to -p1 and returns it
+func (p *PointProj) Neg(p1 *PointProj) *PointProj {
+	p.X.Neg(&p1.X)
+	p.Y.Set(&p1.Y)
+	p.Z.Set(&p1.Z)
+	return p
+}
+
+// ScalarMul scalar multiplication of a point
+// p1 points on the twisted Edwards curve
+// scalar NOT in Montgomery form
+// modifies p
+func (p *Point) ScalarMul(p1 *Point, scalar *big.Int) *Point {
+
+	var resProj, p1Proj PointProj
+	resProj.X.SetZero()
+	resProj.Y.SetOne()
+	resProj.Z.SetOne()
+
+	p1Proj.FromAffine(p1)
+
+	for i := scalar.BitLen() - 1; i >= 0; i-- {
+		resProj.Double(&resProj)
+		if scalar.Bit(i) == 1 {
+			resProj.Add(&resProj, &p1Proj)
+		}
+	}
+
+	p.FromProj(&resProj)
+
+	return p
+}
+`
+
+const twistedEdwardsTestTemplate = `
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBase(t *testing.T) {
+	ed := GetEdwardsCurve()
+
+	if !ed.Base.IsOnCurve() {
+		t.Fatal("base point should be on the curve")
+	}
+
+	// the base point is of order Order
+	var p, zero Point
+	zero.Y.SetOne()
+	p.ScalarMul(&ed.Base, &ed.Order)
+	if !p.Equal(&zero) {
+		t.Fatal("Order*Base should be the neutral element")
+	}
+}
+
+func TestAddProj(t *testing.T) {
+	ed := GetEdwardsCurve()
+
+	var p1, p2, expected Point
+	p1.ScalarMul(&ed.Base, big.NewInt(23902374))
+	p2.ScalarMul(&ed.Base, big.NewInt(188020))
+	expected.Add(&p1, &p2)
+
+	var p1proj, p2proj PointProj
+	p1proj.FromAffine(&p1)
+	p2proj.FromAffine(&p2)
+	p1proj.Add(&p1proj, &p2proj)
+	p1.FromProj(&p1proj)
+
+	if !p1.Equal(&expected) {
+		t.Fatal("affine and projective additions should match")
+	}
+	if !p1.IsOnCurve() {
+		t.Fatal("the sum should be on the curve")
+	}
+}
+
+func TestDoubleProj(t *testing.T) {
+	ed := GetEdwardsCurve()
+
+	var p, expected Point
+	p.ScalarMul(&ed.Base, big.NewInt(23902374))
+	expected.Add(&p, &p)
+
+	var pproj PointProj
+	pproj.FromAffine(&p).Double(&pproj)
+	p.FromProj(&pproj)
+
+	if !p.Equal(&expected) {
+		t.Fatal("affine and projective doublings should match")
+	}
+}
+
+func TestScalarMul(t *testing.T) {
+	ed := GetEdwardsCurve()
+
+	// (a+b)*Base = a*Base + b*Base
+	var p1, p2, expected Point
+	p1.ScalarMul(&ed.Base, big.NewInt(23902374))
+	p2.ScalarMul(&ed.Base, big.NewInt(188020))
+	p1.Add(&p1, &p2)
+	expected.ScalarMul(&ed.Base, big.NewInt(23902374+188020))
+	if !p1.Equal(&expected) {
+		t.Fatal("scalar multiplication should be linear")
+	}
+
+	// test consistancy with negation
+	var scalar big.Int
+	expected.Neg(&ed.Base)
+	scalar.Sub(&ed.Order, big.NewInt(1))
+	p1.ScalarMul(&ed.Base, &scalar)
+	if !p1.Equal(&expected) {
+		t.Fatal("Mul by order-1 not consistant with neg")
+	}
+}
+`
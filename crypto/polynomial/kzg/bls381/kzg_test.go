@@ -0,0 +1,313 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package kzg
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+
+	curve "github.com/consensys/gurvy/bls381"
+	"github.com/consensys/gurvy/bls381/fr"
+)
+
+// testSRS re-used accross tests of the KZG commitment scheme
+var testSRS *SRS
+
+func init() {
+	const srsSize = 230
+	testSRS, _ = NewSRS(srsSize, new(big.Int).SetInt64(42))
+}
+
+func randomPolynomial(size int) Polynomial {
+	f := make(Polynomial, size)
+	for i := 0; i < size; i++ {
+		f[i].SetRandom()
+	}
+	return f
+}
+
+func TestDividePolyByXminusA(t *testing.T) {
+
+	const pSize = 230
+
+	// build random polynomial
+	pol := randomPolynomial(pSize)
+
+	// evaluate the polynomial at a random point
+	var point fr.Element
+	point.SetRandom()
+	evaluation := pol.Eval(&point)
+
+	// compute f-f(a)/x-a
+	h := dividePolyByXminusA(pol, &point)
+
+	if len(h) != pSize-1 {
+		t.Fatal("inconsistant size of quotient")
+	}
+
+	// probabilistic test (using Schwartz Zippel lemma, evaluation at one point is enough)
+	var randPoint, xminusa fr.Element
+	randPoint.SetRandom()
+
+	polRandpoint := pol.Eval(&randPoint)
+	polRandpoint.Sub(&polRandpoint, &evaluation) // f(rand)-f(point)
+
+	hRandPoint := h.Eval(&randPoint)
+	xminusa.Sub(&randPoint, &point) // rand-point
+
+	// f(rand)-f(point)	==? h(rand)*(rand-point)
+	hRandPoint.Mul(&hRandPoint, &xminusa)
+
+	if !hRandPoint.Equal(&polRandpoint) {
+		t.Fatal("Error f-f(a)/x-a")
+	}
+}
+
+func TestSerializationSRS(t *testing.T) {
+
+	// create a SRS
+	srs, err := NewSRS(64, new(big.Int).SetInt64(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// serialize it...
+	var buf bytes.Buffer
+	written, err := srs.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// reconstruct the SRS
+	var _srs SRS
+	read, err := _srs.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != read {
+		t.Fatal("number of bytes read and written don't match")
+	}
+
+	// compare
+	if !reflect.DeepEqual(srs, &_srs) {
+		t.Fatal("scheme serialization failed")
+	}
+}
+
+func TestCommit(t *testing.T) {
+
+	// create a polynomial
+	f := make(Polynomial, 60)
+	for i := 0; i < 60; i++ {
+		f[i].SetRandom()
+	}
+
+	// commit using the method from KZG
+	kzgCommit, err := Commit(f, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// check commitment using manual commit
+	var x fr.Element
+	x.SetString("42")
+	fx := f.Eval(&x)
+	var fxbi big.Int
+	fx.ToBigIntRegular(&fxbi)
+	var manualCommit curve.G1Affine
+	manualCommit.Set(&testSRS.G1[0])
+	manualCommit.ScalarMultiplication(&manualCommit, &fxbi)
+
+	// compare both results
+	if !kzgCommit.Equal(&manualCommit) {
+		t.Fatal("error KZG commitment")
+	}
+
+	// a polynomial larger than the SRS can't be committed
+	if _, err := Commit(randomPolynomial(len(testSRS.G1)+1), testSRS); err != ErrInvalidPolynomialSize {
+		t.Fatal("committing to a polynomial larger than the SRS should fail")
+	}
+}
+
+func TestVerifySinglePoint(t *testing.T) {
+
+	// create a polynomial
+	f := randomPolynomial(60)
+
+	// commit the polynomial
+	digest, err := Commit(f, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// compute opening proof at a random point
+	var point fr.Element
+	point.SetString("4321")
+	proof, err := Open(f, &point, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// verify the claimed valued
+	expected := f.Eval(&point)
+	if !proof.ClaimedValue.Equal(&expected) {
+		t.Fatal("inconsistant claimed value")
+	}
+
+	// verify correct proof
+	err = Verify(&digest, &proof, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	{
+		// verify wrong proof
+		proof.ClaimedValue.Double(&proof.ClaimedValue)
+		err = Verify(&digest, &proof, testSRS)
+		if err == nil {
+			t.Fatal("verifying wrong proof should have failed")
+		}
+	}
+}
+
+func TestBatchVerifySinglePoint(t *testing.T) {
+
+	// create polynomials
+	f := make([]Polynomial, 10)
+	for i := 0; i < 10; i++ {
+		f[i] = randomPolynomial(60 + i)
+	}
+
+	// commit the polynomials
+	digests := make([]Digest, 10)
+	for i := 0; i < 10; i++ {
+		digests[i], _ = Commit(f[i], testSRS)
+	}
+
+	// compute opening proof at a random point
+	var point fr.Element
+	point.SetString("4321")
+	proof, err := BatchOpenSinglePoint(f, digests, &point, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// verify the claimed values
+	for i := 0; i < 10; i++ {
+		expectedClaim := f[i].Eval(&point)
+		if !expectedClaim.Equal(&proof.ClaimedValues[i]) {
+			t.Fatal("inconsistant claimed values")
+		}
+	}
+
+	// verify correct proof
+	err = BatchVerifySinglePoint(digests, &proof, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	{
+		// verify wrong proof
+		proof.ClaimedValues[0].Double(&proof.ClaimedValues[0])
+		err = BatchVerifySinglePoint(digests, &proof, testSRS)
+		if err == nil {
+			t.Fatal("verifying wrong proof should have failed")
+		}
+	}
+}
+
+func TestBatchVerifyMultiPoints(t *testing.T) {
+
+	// create polynomials
+	f := make([]Polynomial, 10)
+	for i := 0; i < 10; i++ {
+		f[i] = randomPolynomial(60 + i)
+	}
+
+	// commit the polynomials
+	digests := make([]Digest, 10)
+	for i := 0; i < 10; i++ {
+		digests[i], _ = Commit(f[i], testSRS)
+	}
+
+	// compute 2 batch opening proofs at 2 random points
+	points := make([]fr.Element, 2)
+	batchProofs := make([]BatchOpeningProof, 2)
+	points[0].SetRandom()
+	batchProofs[0], _ = BatchOpenSinglePoint(f[:5], digests[:5], &points[0], testSRS)
+	points[1].SetRandom()
+	batchProofs[1], _ = BatchOpenSinglePoint(f[5:], digests[5:], &points[1], testSRS)
+
+	// fold the 2 batch opening proofs
+	proofs := make([]OpeningProof, 2)
+	foldedDigests := make([]Digest, 2)
+	proofs[0], foldedDigests[0] = foldBatchOpeningProof(digests[:5], &batchProofs[0])
+	proofs[1], foldedDigests[1] = foldBatchOpeningProof(digests[5:], &batchProofs[1])
+
+	// check the opening proofs
+	for i := 0; i < 2; i++ {
+		if err := Verify(&foldedDigests[i], &proofs[i], testSRS); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// batch verify the folded proofs
+	if err := BatchVerifyMultiPoints(foldedDigests, proofs, testSRS); err != nil {
+		t.Fatal(err)
+	}
+
+	// batch verify tampered folded proofs
+	proofs[0].ClaimedValue.Double(&proofs[0].ClaimedValue)
+	if err := BatchVerifyMultiPoints(foldedDigests, proofs, testSRS); err == nil {
+		t.Fatal("batch verifying wrong proofs should have failed")
+	}
+}
+
+// foldBatchOpeningProof returns the opening proof and the digest of Σ γ**i⋅f_i, where γ is
+// the challenge used by BatchOpenSinglePoint
+func foldBatchOpeningProof(digests []Digest, batchOpeningProof *BatchOpeningProof) (OpeningProof, Digest) {
+	gamma := deriveGamma(&batchOpeningProof.Point, digests, batchOpeningProof.ClaimedValues)
+
+	var foldedDigest Digest
+	var foldedEvaluation, gammaPower fr.Element
+	gammaPower.SetOne()
+	for i := 0; i < len(digests); i++ {
+		var t fr.Element
+		t.Mul(&batchOpeningProof.ClaimedValues[i], &gammaPower)
+		foldedEvaluation.Add(&foldedEvaluation, &t)
+
+		var b big.Int
+		gammaPower.ToBigIntRegular(&b)
+		var d curve.G1Affine
+		d.ScalarMultiplication(&digests[i], &b)
+		var acc, _d curve.G1Jac
+		acc.FromAffine(&foldedDigest)
+		_d.FromAffine(&d)
+		acc.AddAssign(&_d)
+		foldedDigest.FromJacobian(&acc)
+
+		gammaPower.Mul(&gammaPower, &gamma)
+	}
+
+	return OpeningProof{
+		H:            batchOpeningProof.H,
+		Point:        batchOpeningProof.Point,
+		ClaimedValue: foldedEvaluation,
+	}, foldedDigest
+}
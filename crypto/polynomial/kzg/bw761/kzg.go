@@ -0,0 +1,449 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package kzg
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	curve "github.com/consensys/gurvy/bw761"
+	"github.com/consensys/gurvy/bw761/fr"
+)
+
+var (
+	ErrInvalidNbDigests              = errors.New("number of digests is not the same as the number of polynomials")
+	ErrInvalidPolynomialSize         = errors.New("invalid polynomial size (larger than SRS or == 0)")
+	ErrVerifyOpeningProof            = errors.New("can't verify opening proof")
+	ErrVerifyBatchOpeningSinglePoint = errors.New("can't verify batch opening proof at single point")
+	ErrMinSRSSize                    = errors.New("minimum SRS size is 2")
+)
+
+// Digest commitment of a polynomial
+type Digest = curve.G1Affine
+
+// Polynomial in canonical basis: p[i] is the coefficient of X**i
+type Polynomial []fr.Element
+
+// Eval returns p(x)
+func (p Polynomial) Eval(x *fr.Element) fr.Element {
+	var res fr.Element
+	for i := len(p) - 1; i >= 0; i-- {
+		res.Mul(&res, x).Add(&res, &p[i])
+	}
+	return res
+}
+
+// SRS stores the result of the MPC
+type SRS struct {
+	G1 []curve.G1Affine  // [G1 [α]G1 , [α**2]G1, ... ]
+	G2 [2]curve.G2Affine // [G2, [α]G2 ]
+}
+
+// NewSRS returns a new SRS using alpha as randomness source
+//
+// In production, a SRS generated through MPC should be used.
+func NewSRS(size uint64, bAlpha *big.Int) (*SRS, error) {
+	if size < 2 {
+		return nil, ErrMinSRSSize
+	}
+
+	var alpha fr.Element
+	alpha.SetBigInt(bAlpha)
+
+	powers := make([]fr.Element, size)
+	powers[0].SetOne()
+	for i := 1; i < len(powers); i++ {
+		powers[i].Mul(&powers[i-1], &alpha)
+	}
+	// the scalars of the batch scalar multiplication are in regular form
+	for i := 0; i < len(powers); i++ {
+		powers[i].FromMont()
+	}
+
+	_, _, gen1Aff, gen2Aff := curve.Generators()
+
+	var srs SRS
+	srs.G1 = curve.BatchScalarMultiplicationG1(&gen1Aff, powers)
+	srs.G2[0] = gen2Aff
+	srs.G2[1].ScalarMultiplication(&gen2Aff, bAlpha)
+
+	return &srs, nil
+}
+
+// OpeningProof KZG proof for opening at a single point.
+type OpeningProof struct {
+	// H quotient polynomial (f - f(z))/(x-z)
+	H curve.G1Affine
+
+	// Point at which the polynomial is evaluated
+	Point fr.Element
+
+	// ClaimedValue purported value
+	ClaimedValue fr.Element
+}
+
+// BatchOpeningProof opening proof for many polynomials at the same point
+type BatchOpeningProof struct {
+	// H quotient polynomial Sum_i gamma**i*(f - f(z))/(x-z)
+	H curve.G1Affine
+
+	// Point at which the polynomials are evaluated
+	Point fr.Element
+
+	// ClaimedValues purported values
+	ClaimedValues []fr.Element
+}
+
+// Commit commits to a polynomial using a multi exponentiation with the SRS.
+// It is assumed that the polynomial is in canonical form, in Montgomery form.
+func Commit(p Polynomial, srs *SRS) (Digest, error) {
+	if len(p) == 0 || len(p) > len(srs.G1) {
+		return Digest{}, ErrInvalidPolynomialSize
+	}
+
+	// the scalars of the multi exponentiation are in regular form
+	scalars := make([]fr.Element, len(p))
+	for i := 0; i < len(p); i++ {
+		scalars[i] = p[i]
+		scalars[i].FromMont()
+	}
+
+	var res Digest
+	res.MultiExp(srs.G1[:len(p)], scalars)
+
+	return res, nil
+}
+
+// Open computes an opening proof of polynomial p at given point.
+func Open(p Polynomial, point *fr.Element, srs *SRS) (OpeningProof, error) {
+	if len(p) == 0 || len(p) > len(srs.G1) {
+		return OpeningProof{}, ErrInvalidPolynomialSize
+	}
+
+	// build the proof
+	res := OpeningProof{
+		Point:        *point,
+		ClaimedValue: p.Eval(point),
+	}
+
+	// compute H
+	h := dividePolyByXminusA(p, point)
+	if len(h) == 0 {
+		// p is constant, H = [0]G1
+		return res, nil
+	}
+
+	var err error
+	res.H, err = Commit(h, srs)
+	if err != nil {
+		return OpeningProof{}, err
+	}
+
+	return res, nil
+}
+
+// Verify verifies a KZG opening proof at a single point
+func Verify(commitment *Digest, proof *OpeningProof, srs *SRS) error {
+
+	// e([f(α) - f(a) + a⋅H(α)]G1, G2) == e([H(α)]G1, [α]G2)
+	var one, claimedValueNeg fr.Element
+	one.SetOne()
+	claimedValueNeg.Neg(&proof.ClaimedValue)
+	scalars := []fr.Element{one, claimedValueNeg, proof.Point}
+	for i := 0; i < len(scalars); i++ {
+		scalars[i].FromMont()
+	}
+
+	var folded curve.G1Affine
+	folded.MultiExp([]curve.G1Affine{*commitment, srs.G1[0], proof.H}, scalars)
+
+	var hNeg curve.G1Affine
+	hNeg.Neg(&proof.H)
+
+	check, err := pairingCheck([]curve.G1Affine{folded, hNeg}, []curve.G2Affine{srs.G2[0], srs.G2[1]})
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// BatchOpenSinglePoint creates a batch opening proof at a single point of several polynomials
+//
+// the polynomials are folded with the powers of a challenge γ, derived from the point,
+// the digests and the claimed values (Fiat Shamir)
+func BatchOpenSinglePoint(polynomials []Polynomial, digests []Digest, point *fr.Element, srs *SRS) (BatchOpeningProof, error) {
+
+	nbPolynomials := len(polynomials)
+	if nbPolynomials == 0 || nbPolynomials != len(digests) {
+		return BatchOpeningProof{}, ErrInvalidNbDigests
+	}
+	largestPoly := -1
+	for _, p := range polynomials {
+		if len(p) == 0 || len(p) > len(srs.G1) {
+			return BatchOpeningProof{}, ErrInvalidPolynomialSize
+		}
+		if len(p) > largestPoly {
+			largestPoly = len(p)
+		}
+	}
+
+	var res BatchOpeningProof
+
+	// compute the purported values
+	res.ClaimedValues = make([]fr.Element, nbPolynomials)
+	for i := 0; i < nbPolynomials; i++ {
+		res.ClaimedValues[i] = polynomials[i].Eval(point)
+	}
+
+	// set the point at which the evaluation is done
+	res.Point = *point
+
+	// derive the challenge γ, binded to the point and the commitments
+	gamma := deriveGamma(point, digests, res.ClaimedValues)
+
+	// fold the polynomials: Σ γ**i⋅p_i
+	folded := make(Polynomial, largestPoly)
+	var gammaPower, t fr.Element
+	gammaPower.SetOne()
+	for i := 0; i < nbPolynomials; i++ {
+		for j := 0; j < len(polynomials[i]); j++ {
+			t.Mul(&polynomials[i][j], &gammaPower)
+			folded[j].Add(&folded[j], &t)
+		}
+		gammaPower.Mul(&gammaPower, &gamma)
+	}
+
+	// compute H
+	h := dividePolyByXminusA(folded, point)
+	if len(h) == 0 {
+		// the polynomials are constant, H = [0]G1
+		return res, nil
+	}
+
+	var err error
+	res.H, err = Commit(h, srs)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+
+	return res, nil
+}
+
+// BatchVerifySinglePoint verifies a batched opening proof at a single point of a list of polynomials.
+func BatchVerifySinglePoint(digests []Digest, batchOpeningProof *BatchOpeningProof, srs *SRS) error {
+
+	nbDigests := len(digests)
+
+	// check consistancy between numbers of claims vs number of digests
+	if nbDigests == 0 || nbDigests != len(batchOpeningProof.ClaimedValues) {
+		return ErrInvalidNbDigests
+	}
+
+	// derive the challenge γ, binded to the point and the commitments
+	gamma := deriveGamma(&batchOpeningProof.Point, digests, batchOpeningProof.ClaimedValues)
+
+	// fold the claimed values and the digests
+	gammai := make([]fr.Element, nbDigests)
+	gammai[0].SetOne()
+	for i := 1; i < nbDigests; i++ {
+		gammai[i].Mul(&gammai[i-1], &gamma)
+	}
+
+	var foldedEvaluations, t fr.Element
+	for i := 0; i < nbDigests; i++ {
+		t.Mul(&batchOpeningProof.ClaimedValues[i], &gammai[i])
+		foldedEvaluations.Add(&foldedEvaluations, &t)
+	}
+
+	for i := 0; i < nbDigests; i++ {
+		gammai[i].FromMont()
+	}
+	var foldedDigests Digest
+	foldedDigests.MultiExp(digests, gammai)
+
+	// create the folded opening proof
+	foldedProof := OpeningProof{
+		H:            batchOpeningProof.H,
+		Point:        batchOpeningProof.Point,
+		ClaimedValue: foldedEvaluations,
+	}
+
+	if err := Verify(&foldedDigests, &foldedProof, srs); err != nil {
+		return ErrVerifyBatchOpeningSinglePoint
+	}
+	return nil
+}
+
+// BatchVerifyMultiPoints batch verifies a list of opening proofs at different points.
+// The purpose of the batching is to have only one pairing for verifying several proofs.
+//
+// digests[i] is the commitment of the polynomial opened by proofs[i]
+func BatchVerifyMultiPoints(digests []Digest, proofs []OpeningProof, srs *SRS) error {
+
+	// check consistancy nb proofs vs nb digests
+	nbProofs := len(proofs)
+	if nbProofs == 0 || len(digests) != nbProofs {
+		return ErrInvalidNbDigests
+	}
+
+	// if only one digest, call Verify
+	if nbProofs == 1 {
+		return Verify(&digests[0], &proofs[0], srs)
+	}
+
+	// sample random numbers λ_i for the random linear combination (λ_0 = 1)
+	randomNumbers := make([]fr.Element, nbProofs)
+	randomNumbers[0].SetOne()
+	for i := 1; i < nbProofs; i++ {
+		if _, err := randomNumbers[i].SetRandom(); err != nil {
+			return err
+		}
+	}
+
+	// e(Σ λ_i⋅(f_i(α) - f_i(a_i) + a_i⋅H_i(α)), G2) == e(Σ λ_i⋅H_i(α), [α]G2)
+	points := make([]curve.G1Affine, 0, 2*nbProofs+1)
+	scalars := make([]fr.Element, 0, 2*nbProofs+1)
+	var foldedEvaluations, t fr.Element
+	for i := 0; i < nbProofs; i++ {
+		t.Mul(&randomNumbers[i], &proofs[i].ClaimedValue)
+		foldedEvaluations.Add(&foldedEvaluations, &t)
+
+		points = append(points, digests[i], proofs[i].H)
+		t.Mul(&randomNumbers[i], &proofs[i].Point)
+		scalars = append(scalars, randomNumbers[i], t)
+	}
+	foldedEvaluations.Neg(&foldedEvaluations)
+	points = append(points, srs.G1[0])
+	scalars = append(scalars, foldedEvaluations)
+	for i := 0; i < len(scalars); i++ {
+		scalars[i].FromMont()
+	}
+	var folded curve.G1Affine
+	folded.MultiExp(points, scalars)
+
+	quotients := make([]curve.G1Affine, nbProofs)
+	for i := 0; i < nbProofs; i++ {
+		quotients[i] = proofs[i].H
+		randomNumbers[i].FromMont()
+	}
+	var foldedQuotients curve.G1Affine
+	foldedQuotients.MultiExp(quotients, randomNumbers)
+	foldedQuotients.Neg(&foldedQuotients)
+
+	check, err := pairingCheck([]curve.G1Affine{folded, foldedQuotients}, []curve.G2Affine{srs.G2[0], srs.G2[1]})
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// pairingCheck returns true if Π e(P[i], Q[i]) == 1
+//
+// the Miller loops are computed separately, as the bw761 Miller loop handles a single pair
+// and e(P, Q) == 1 is skipped when P is the point at infinity
+func pairingCheck(P []curve.G1Affine, Q []curve.G2Affine) (bool, error) {
+	var ml curve.GT
+	ml.SetOne()
+	for i := 0; i < len(P); i++ {
+		if P[i].IsInfinity() {
+			continue
+		}
+		t, err := curve.MillerLoop([]curve.G1Affine{P[i]}, []curve.G2Affine{Q[i]})
+		if err != nil {
+			return false, err
+		}
+		ml.Mul(&ml, &t)
+	}
+
+	var one curve.GT
+	one.SetOne()
+	res := curve.FinalExponentiation(&ml)
+	return res.Equal(&one), nil
+}
+
+// deriveGamma derives a challenge using Fiat Shamir to fold the polynomials
+func deriveGamma(point *fr.Element, digests []Digest, claimedValues []fr.Element) fr.Element {
+	h := sha256.New()
+
+	b := point.Bytes()
+	h.Write(b[:])
+	for i := 0; i < len(digests); i++ {
+		b := digests[i].Bytes()
+		h.Write(b[:])
+	}
+	for i := 0; i < len(claimedValues); i++ {
+		b := claimedValues[i].Bytes()
+		h.Write(b[:])
+	}
+
+	var gamma fr.Element
+	gamma.SetBytes(h.Sum(nil))
+	return gamma
+}
+
+// dividePolyByXminusA computes (f-f(a))/(x-a), in canonical basis
+func dividePolyByXminusA(f Polynomial, a *fr.Element) Polynomial {
+	if len(f) < 2 {
+		return Polynomial{}
+	}
+
+	res := make(Polynomial, len(f)-1)
+	res[len(res)-1].Set(&f[len(f)-1])
+	for i := len(res) - 2; i >= 0; i-- {
+		res[i].Mul(&res[i+1], a).Add(&res[i], &f[i+1])
+	}
+	return res
+}
+
+// WriteTo writes binary encoding of the SRS
+// points are stored in compressed form
+func (srs *SRS) WriteTo(w io.Writer) (int64, error) {
+	enc := curve.NewEncoder(w)
+
+	toEncode := []interface{}{srs.G1, &srs.G2[0], &srs.G2[1]}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes SRS data from reader.
+func (srs *SRS) ReadFrom(r io.Reader) (int64, error) {
+	dec := curve.NewDecoder(r)
+
+	toDecode := []interface{}{&srs.G1, &srs.G2[0], &srs.G2[1]}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
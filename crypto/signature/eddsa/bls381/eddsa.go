@@ -12,6 +12,8 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+// Code generated by gnark DO NOT EDIT
+
 package eddsa
 
 import (
@@ -21,13 +23,14 @@ import (
 	"hash"
 	"math/big"
 
+	"github.com/consensys/gurvy/bls381/fr"
 	"github.com/consensys/gurvy/bls381/twistededwards"
 	"golang.org/x/crypto/blake2b"
 )
 
 var errNotOnCurve = errors.New("point not on curve")
 
-const frSize = 32 // TODO assumes a 256 bits field for the twisted curve (ok for our implem)
+const frSize = fr.Bytes
 
 // Signature represents an eddsa signature
 // cf https://en.wikipedia.org/wiki/EdDSA for notation
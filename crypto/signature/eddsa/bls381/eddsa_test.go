@@ -12,13 +12,14 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+// Code generated by gnark DO NOT EDIT
+
 package eddsa
 
 import (
 	"testing"
 
 	"github.com/consensys/gnark/crypto/hash/mimc/bls381"
-	"github.com/consensys/gnark/crypto/hash/mimc/bn256"
 	"github.com/consensys/gurvy/bls381/fr"
 )
 
@@ -30,7 +31,7 @@ func TestEddsa(t *testing.T) {
 		seed[i] = v
 	}
 
-	hFunc := bn256.NewMiMC("seed")
+	hFunc := bls381.NewMiMC("seed")
 
 	// create eddsa obj and sign a message
 	pubKey, privKey := New(seed, hFunc)
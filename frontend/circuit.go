@@ -29,6 +29,40 @@ type Circuit interface {
 //
 // 3. finally, it converts that to a R1CS
 func Compile(curveID gurvy.ID, circuit Circuit) (r1cs.R1CS, error) {
+	cs, err := buildCS(curveID, circuit)
+	if err != nil {
+		return nil, err
+	}
+
+	// return R1CS
+	res, err := cs.toR1CS(curveID)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// CompileSparse will generate a sparse R1CS (PLONK arithmetization) from the given circuit
+//
+// the circuit is first compiled into an untyped R1CS (see Compile), which constraints are then
+// split into constraints of the form qL⋅xa + qR⋅xb + qO⋅xc + qM⋅(xa⋅xb) + qC == 0
+func CompileSparse(curveID gurvy.ID, circuit Circuit) (r1cs.SparseR1CS, error) {
+	cs, err := buildCS(curveID, circuit)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := cs.toR1CS(gurvy.UNKNOWN)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*r1cs.UntypedR1CS).ToSparseR1CS(curveID), nil
+}
+
+// buildCS allocates the circuit inputs and calls circuit.Define() to fill in the constraint system
+func buildCS(curveID gurvy.ID, circuit Circuit) (ConstraintSystem, error) {
 
 	// instantiate our constraint system
 	cs := newConstraintSystem()
@@ -59,21 +93,15 @@ func Compile(curveID gurvy.ID, circuit Circuit) (r1cs.R1CS, error) {
 	// recursively parse through reflection the circuits members to find all Constraints that need to be allOoutputcated
 	// (secret or public inputs)
 	if err := parseType(circuit, "", backend.Unset, handler); err != nil {
-		return nil, err
+		return cs, err
 	}
 
 	// call Define() to fill in the Constraints
 	if err := circuit.Define(curveID, &cs); err != nil {
-		return nil, err
-	}
-	// return R1CS
-	//return cs.toR1CS(curveID), nil
-	res, err := cs.toR1CS(curveID)
-	if err != nil {
-		return nil, err
+		return cs, err
 	}
 
-	return res, nil
+	return cs, nil
 }
 
 // ParseWitness will returns a map[string]interface{} to be used as input in
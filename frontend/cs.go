@@ -55,8 +55,9 @@ type ConstraintSystem struct {
 	}
 
 	// Constraints
-	constraints []r1c.R1C // list of R1C that yield an output (for example v3 == v1 * v2, return v3)
-	assertions  []r1c.R1C // list of R1C that yield no output (for example ensuring v1 == v2)
+	constraints []r1c.R1C  // list of R1C that yield an output (for example v3 == v1 * v2, return v3)
+	assertions  []r1c.R1C  // list of R1C that yield no output (for example ensuring v1 == v2)
+	hints       []r1c.Hint // list of wires computed by hint functions (see NewHint)
 	oneTerm     r1c.Term
 
 	// Coefficients in the constraints
@@ -230,6 +231,47 @@ func (cs *ConstraintSystem) completeDanglingVariable(v *Variable) {
 	}
 }
 
+// booleans returns the map keeping track of the boolean constrained wires, and the wire ID,
+// if v is a wire (its linear expression is 1⋅wire)
+func (cs *ConstraintSystem) booleans(v Variable) (map[int]struct{}, int, bool) {
+	if len(v.linExp) != 1 || v.linExp[0].CoeffValue() != 1 {
+		return nil, 0, false
+	}
+	_, _, id, visibility := v.linExp[0].Unpack()
+	switch visibility {
+	case backend.Public:
+		return cs.public.booleans, id, true
+	case backend.Secret:
+		return cs.secret.booleans, id, true
+	case backend.Internal:
+		return cs.internal.booleans, id, true
+	default:
+		return nil, 0, false
+	}
+}
+
+// isBoolean returns true if v is known to be boolean (it has been boolean constrained,
+// or is the output of a boolean operation)
+func (cs *ConstraintSystem) isBoolean(v Variable) bool {
+	if v.isBoolean {
+		return true
+	}
+	if booleans, id, ok := cs.booleans(v); ok {
+		_, ok = booleans[id]
+		return ok
+	}
+	return false
+}
+
+// markBoolean records that v is boolean, such that it is not boolean constrained again
+// (AssertIsBoolean is a no-op for v and the wire it may hold)
+func (cs *ConstraintSystem) markBoolean(v *Variable) {
+	v.isBoolean = true
+	if booleans, id, ok := cs.booleans(*v); ok {
+		booleans[id] = struct{}{}
+	}
+}
+
 // reduces redundancy in linear expression
 // Non deterministic function
 func (cs *ConstraintSystem) reduce(linExp r1c.LinearExpression) r1c.LinearExpression {
@@ -316,6 +358,17 @@ func (cs *ConstraintSystem) toR1CS(curveID gurvy.ID) (r1cs.R1CS, error) {
 		}
 	}
 
+	// and in the hints
+	res.Hints = make([]r1c.Hint, len(cs.hints))
+	copy(res.Hints, cs.hints)
+	for i := 0; i < len(res.Hints); i++ {
+		for j := 0; j < len(res.Hints[i].Inputs); j++ {
+			if err := offsetIDs(res.Hints[i].Inputs[j]); err != nil {
+				return &res, err
+			}
+		}
+	}
+
 	// we need to offset the ids in logs too
 	for i := 0; i < len(cs.logs); i++ {
 		entry := backend.LogEntry{
@@ -358,6 +411,9 @@ func (cs *ConstraintSystem) toR1CS(curveID gurvy.ID) (r1cs.R1CS, error) {
 		res.DebugInfo[i] = entry
 	}
 
+	// group the computational constraints in levels, for the solver
+	res.Levels = buildLevels(res.Constraints[:res.NbCOConstraints], res.Hints, len(cs.internal.variables))
+
 	if curveID == gurvy.UNKNOWN {
 		return &res, nil
 	}
@@ -365,6 +421,84 @@ func (cs *ConstraintSystem) toR1CS(curveID gurvy.ID) (r1cs.R1CS, error) {
 	return res.ToR1CS(curveID), nil
 }
 
+// buildLevels groups the computational constraints in levels, such that a constraint only depends
+// on inputs or on wires computed by constraints of previous levels. The constraints of a level
+// are independent and can be solved in parallel.
+//
+// It replays the sequential solver on the wire IDs: the wires a constraint computes are the
+// internal wires not yet instantiated when the constraint is reached (the wires of L for r1c.BinaryDec).
+// The solver computes the hint outputs used by a constraint first, so their inputs are also dependencies.
+func buildLevels(constraints []r1c.R1C, hints []r1c.Hint, nbInternalWires int) [][]int {
+	// wireLevel[i] == 0 if the internal wire i is not computed yet
+	// wireLevel[i] == l+1 if the internal wire i is computed by a constraint of level l
+	wireLevel := make([]int, nbInternalWires)
+
+	hintOf := make(map[int]*r1c.Hint, len(hints))
+	for i := 0; i < len(hints); i++ {
+		hintOf[hints[i].WireID] = &hints[i]
+	}
+
+	var levels [][]int
+	var outputs []int
+
+	for i := 0; i < len(constraints); i++ {
+		level := 0
+		overwrite := false
+		outputs = outputs[:0]
+
+		var visit func(exp r1c.LinearExpression, isOutput bool)
+		visit = func(exp r1c.LinearExpression, isOutput bool) {
+			for _, t := range exp {
+				wID := t.VariableID()
+				if wID >= nbInternalWires {
+					// inputs are instantiated before solving
+					continue
+				}
+				if isOutput || wireLevel[wID] == 0 {
+					if wireLevel[wID] != 0 {
+						overwrite = true
+					}
+					outputs = append(outputs, wID)
+					if h, ok := hintOf[wID]; ok {
+						for _, input := range h.Inputs {
+							visit(input, false)
+						}
+					}
+				} else if wireLevel[wID] > level {
+					level = wireLevel[wID]
+				}
+			}
+		}
+
+		switch constraints[i].Solver {
+		case r1c.BinaryDec:
+			visit(constraints[i].R, false)
+			visit(constraints[i].O, false)
+			visit(constraints[i].L, true)
+		default:
+			visit(constraints[i].L, false)
+			visit(constraints[i].R, false)
+			visit(constraints[i].O, false)
+		}
+
+		// a wire computed a second time must not be read concurrently
+		// so the constraint goes after every constraint seen so far
+		if overwrite {
+			level = len(levels)
+		}
+
+		if level == len(levels) {
+			levels = append(levels, nil)
+		}
+		levels[level] = append(levels[level], i)
+		for _, wID := range outputs {
+			wireLevel[wID] = level + 1
+		}
+	}
+
+	return levels
+}
+
 // coeffID tries to fetch the entry where b is if it exits, otherwise appends b to
 // the list of coeffs and returns the corresponding entry
 func (cs *ConstraintSystem) coeffID(b *big.Int) int {
@@ -19,8 +19,10 @@ package frontend
 import (
 	"fmt"
 	"math/big"
+	"math/bits"
 
 	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/hint"
 	"github.com/consensys/gnark/backend/r1cs/r1c"
 )
 
@@ -223,9 +225,145 @@ func (cs *ConstraintSystem) Xor(a, b Variable) Variable {
 	constraint := r1c.R1C{L: v1.getLinExpCopy(), R: b.getLinExpCopy(), O: v2.getLinExpCopy(), Solver: r1c.SingleOutput}
 	cs.constraints = append(cs.constraints, constraint)
 
+	cs.markBoolean(&res)
 	return res
 }
 
+// Not returns 1 - a, a must be boolean (no constraint is recorded)
+func (cs *ConstraintSystem) Not(a Variable) Variable {
+
+	cs.completeDanglingVariable(&a)
+
+	cs.AssertIsBoolean(a)
+
+	res := cs.Sub(1, a) // no constraint is recorded
+	cs.markBoolean(&res)
+
+	return res
+}
+
+// And returns a AND b, a and b must be boolean (1 constraint)
+func (cs *ConstraintSystem) And(a, b Variable) Variable {
+
+	cs.completeDanglingVariable(&a)
+	cs.completeDanglingVariable(&b)
+
+	cs.AssertIsBoolean(a)
+	cs.AssertIsBoolean(b)
+
+	res := cs.Mul(a, b)
+	cs.markBoolean(&res)
+
+	return res
+}
+
+// Or returns a OR b, a and b must be boolean (1 constraint)
+func (cs *ConstraintSystem) Or(a, b Variable) Variable {
+
+	cs.completeDanglingVariable(&a)
+	cs.completeDanglingVariable(&b)
+
+	cs.AssertIsBoolean(a)
+	cs.AssertIsBoolean(b)
+
+	// a⋅b == a + b - res
+	res := cs.newInternalVariable()
+	v := cs.Add(a, b)  // no constraint is recorded
+	v = cs.Sub(v, res) // no constraint is recorded
+
+	constraint := r1c.R1C{L: a.getLinExpCopy(), R: b.getLinExpCopy(), O: v.getLinExpCopy(), Solver: r1c.SingleOutput}
+	cs.constraints = append(cs.constraints, constraint)
+
+	cs.markBoolean(&res)
+	return res
+}
+
+// Nand returns NOT (a AND b), a and b must be boolean (1 constraint)
+func (cs *ConstraintSystem) Nand(a, b Variable) Variable {
+	return cs.Not(cs.And(a, b))
+}
+
+// AndN returns a[0] AND a[1] AND ..., the a[i] must be boolean
+//
+// for more than 2 inputs, the result is IsEqual(Σa[i], len(a)): 1 constraint and 1 assertion
+// whatever the number of inputs
+func (cs *ConstraintSystem) AndN(a ...Variable) Variable {
+	switch len(a) {
+	case 0:
+		panic("AndN needs at least one input")
+	case 1:
+		cs.completeDanglingVariable(&a[0])
+		cs.AssertIsBoolean(a[0])
+		return a[0]
+	case 2:
+		return cs.And(a[0], a[1])
+	}
+
+	sum := cs.sumBooleans(a)
+	return cs.IsEqual(sum, len(a))
+}
+
+// OrN returns a[0] OR a[1] OR ..., the a[i] must be boolean
+//
+// for more than 2 inputs, the result is 1 - IsZero(Σa[i]): 1 constraint and 1 assertion
+// whatever the number of inputs
+func (cs *ConstraintSystem) OrN(a ...Variable) Variable {
+	switch len(a) {
+	case 0:
+		panic("OrN needs at least one input")
+	case 1:
+		cs.completeDanglingVariable(&a[0])
+		cs.AssertIsBoolean(a[0])
+		return a[0]
+	case 2:
+		return cs.Or(a[0], a[1])
+	}
+
+	sum := cs.sumBooleans(a)
+	return cs.Not(cs.IsZero(sum))
+}
+
+// sumBooleans boolean constrains the a[i] and returns Σa[i] (no constraint is recorded)
+func (cs *ConstraintSystem) sumBooleans(a []Variable) Variable {
+	sum := cs.Constant(0)
+	for i := 0; i < len(a); i++ {
+		cs.completeDanglingVariable(&a[i])
+		cs.AssertIsBoolean(a[i])
+		sum = cs.Add(sum, a[i]) // no constraint is recorded
+	}
+	return sum
+}
+
+// BitwiseAnd returns the bitwise AND of a and b, seen as nbBits integers
+// (2⋅nbBits boolean assertions and 2 constraints for the decompositions, nbBits constraints)
+func (cs *ConstraintSystem) BitwiseAnd(a, b Variable, nbBits int) Variable {
+	return cs.bitwise(cs.And, a, b, nbBits)
+}
+
+// BitwiseOr returns the bitwise OR of a and b, seen as nbBits integers (see BitwiseAnd)
+func (cs *ConstraintSystem) BitwiseOr(a, b Variable, nbBits int) Variable {
+	return cs.bitwise(cs.Or, a, b, nbBits)
+}
+
+// BitwiseXor returns the bitwise XOR of a and b, seen as nbBits integers (see BitwiseAnd)
+func (cs *ConstraintSystem) BitwiseXor(a, b Variable, nbBits int) Variable {
+	return cs.bitwise(cs.Xor, a, b, nbBits)
+}
+
+// bitwise applies op on the bits of a and b, and packs the result
+// (the bits are already boolean constrained, no assertion is added by op nor FromBinary)
+func (cs *ConstraintSystem) bitwise(op func(a, b Variable) Variable, a, b Variable, nbBits int) Variable {
+	aBits := cs.ToBinary(a, nbBits)
+	bBits := cs.ToBinary(b, nbBits)
+
+	res := make([]Variable, nbBits)
+	for i := 0; i < nbBits; i++ {
+		res[i] = op(aBits[i], bBits[i])
+	}
+
+	return cs.FromBinary(res...)
+}
+
 // ToBinary unpacks a variable in binary, n is the number of bits of the variable
 //
 // The result in in little endian (first bit= lsb)
@@ -333,6 +471,318 @@ func (cs *ConstraintSystem) Select(b Variable, i1, i2 interface{}) Variable {
 	}
 }
 
+// IsZero returns 1 if a is zero, 0 otherwise
+//
+// 1 constraint and 1 assertion: with m = 1/a (or 0) computed by a hint,
+// a⋅m == 1 - res and a⋅res == 0
+func (cs *ConstraintSystem) IsZero(a Variable) Variable {
+
+	cs.completeDanglingVariable(&a)
+
+	m := cs.NewHint(hint.InvZero, a)
+	res := cs.newInternalVariable()
+
+	// a⋅m == 1 - res computes res
+	o := cs.Sub(1, res) // no constraint is recorded
+	constraint := r1c.R1C{L: a.getLinExpCopy(), R: m.getLinExpCopy(), O: o.getLinExpCopy(), Solver: r1c.SingleOutput}
+	cs.constraints = append(cs.constraints, constraint)
+
+	// a⋅res == 0 ensures res == 0 if a != 0, and the previous constraint res == 1 if a == 0
+	debugInfo := logEntry{format: "error IsZero"}
+	stack := getCallStack()
+	for i := 0; i < len(stack); i++ {
+		debugInfo.format += "\n" + stack[i]
+	}
+	zero := cs.Constant(0) // no constraint is recorded
+	constraint = r1c.R1C{L: a.getLinExpCopy(), R: res.getLinExpCopy(), O: zero.getLinExpCopy(), Solver: r1c.SingleOutput}
+	cs.addAssertion(constraint, debugInfo)
+
+	cs.markBoolean(&res)
+	return res
+}
+
+// IsEqual returns 1 if i1 == i2, 0 otherwise (see IsZero)
+func (cs *ConstraintSystem) IsEqual(i1, i2 interface{}) Variable {
+	return cs.IsZero(cs.Sub(i1, i2))
+}
+
+// IsLess returns 1 if i1 < i2, 0 otherwise
+//
+// i1 and i2 must be integers less than 2**nbBits, with nbBits+1 less than the size
+// of the field (the caller must ensure it, with ToBinary for instance)
+//
+// i1 - i2 + 2**nbBits is decomposed on nbBits+1 bits, the most significant bit is 0 iff i1 < i2
+// (nbBits+1 boolean assertions and 1 constraint)
+func (cs *ConstraintSystem) IsLess(i1, i2 interface{}, nbBits int) Variable {
+
+	var shift big.Int
+	shift.Lsh(bOne, uint(nbBits))
+
+	d := cs.Sub(i1, i2)  // no constraint is recorded
+	d = cs.Add(d, shift) // no constraint is recorded
+	bits := cs.ToBinary(d, nbBits+1)
+
+	res := cs.Sub(1, bits[nbBits]) // no constraint is recorded
+	cs.markBoolean(&res)
+	return res
+}
+
+// Cmp returns 1 if i1 > i2, 0 if i1 == i2, -1 if i1 < i2
+//
+// i1 and i2 must be integers less than 2**nbBits (see IsLess)
+func (cs *ConstraintSystem) Cmp(i1, i2 interface{}, nbBits int) Variable {
+	lt := cs.IsLess(i1, i2, nbBits)
+	eq := cs.IsEqual(i1, i2)
+
+	// gt = 1 - lt - eq, res = gt - lt
+	res := cs.Sub(1, eq)             // no constraint is recorded
+	res = cs.Sub(res, cs.Mul(lt, 2)) // no constraint is recorded
+	return res
+}
+
+// DivMod returns the quotient and the remainder of the integer division of a by b
+//
+// a and b must be integers less than 2**nbBits, with 2⋅nbBits+1 less than the size of the field,
+// such that q⋅b + r doesn't wrap around the modulus. A variable input is range checked, a constant
+// input larger than 2**nbBits panics.
+//
+// q and r are computed by hints, and constrained with
+//
+//	a < 2**nbBits, b < 2**nbBits (ToBinary of the variable inputs, nbBits+1 constraints each)
+//	q⋅b == a - r (1 assertion)
+//	q < 2**nbBits, r < 2**nbBits (ToBinary, 2⋅(nbBits+1) constraints)
+//	r < b (IsLess, nbBits+2 constraints, which also ensures b != 0)
+func (cs *ConstraintSystem) DivMod(a, b interface{}, nbBits int) (q, r Variable) {
+
+	// IsLess(r, b) and q⋅b == a - r are sound only if a and b fit on nbBits
+	cs.assertBitLen(a, nbBits)
+	cs.assertBitLen(b, nbBits)
+
+	_a := cs.Constant(a) // no constraint is recorded
+	_b := cs.Constant(b) // no constraint is recorded
+
+	q = cs.NewHint(hint.Quo, _a, _b)
+	r = cs.NewHint(hint.Rem, _a, _b)
+
+	// prepare debug info to be displayed in case the constraint is not solved
+	dbgInfoA := cs.buildLogEntryFromVariable(_a)
+	dbgInfoB := cs.buildLogEntryFromVariable(_b)
+	debugInfo := logEntry{
+		format:    "error DivMod " + dbgInfoA.format + " / " + dbgInfoB.format,
+		toResolve: append(dbgInfoA.toResolve, dbgInfoB.toResolve...),
+	}
+	stack := getCallStack()
+	for i := 0; i < len(stack); i++ {
+		debugInfo.format += "\n" + stack[i]
+	}
+
+	// q⋅b == a - r
+	o := cs.Sub(_a, r) // no constraint is recorded
+	constraint := r1c.R1C{L: q.getLinExpCopy(), R: _b.getLinExpCopy(), O: o.getLinExpCopy(), Solver: r1c.SingleOutput}
+	cs.addAssertion(constraint, debugInfo)
+
+	// range checks
+	cs.ToBinary(q, nbBits)
+	cs.ToBinary(r, nbBits)
+	cs.AssertIsEqual(cs.IsLess(r, _b, nbBits), 1)
+
+	return q, r
+}
+
+// Mod returns the remainder of the integer division of a by b (see DivMod)
+func (cs *ConstraintSystem) Mod(a, b interface{}, nbBits int) Variable {
+	_, r := cs.DivMod(a, b, nbBits)
+	return r
+}
+
+// assertBitLen ensures that a is an integer less than 2**nbBits: a variable is decomposed with
+// ToBinary, a constant is checked when the circuit is compiled
+func (cs *ConstraintSystem) assertBitLen(a interface{}, nbBits int) {
+	switch t := a.(type) {
+	case Variable:
+		cs.ToBinary(t, nbBits)
+	default:
+		n := backend.FromInterface(t)
+		if n.Sign() < 0 || n.BitLen() > nbBits {
+			panic(fmt.Sprintf("constant %s doesn't fit on %d bits", n.String(), nbBits))
+		}
+	}
+}
+
+// assertWordSize panics if the size nbBits of a word is not positive
+func assertWordSize(nbBits int) {
+	if nbBits <= 0 {
+		panic(fmt.Sprintf("invalid word size of %d bits", nbBits))
+	}
+}
+
+// assertShift panics if the size nbBits of a word is not positive or if shift is negative
+func assertShift(shift, nbBits int) {
+	assertWordSize(nbBits)
+	if shift < 0 {
+		panic(fmt.Sprintf("invalid negative shift %d", shift))
+	}
+}
+
+// ShiftLeft returns a << shift modulo 2**nbBits, a must be less than 2**nbBits and shift
+// must be non negative (ToBinary, nbBits+1 constraints)
+func (cs *ConstraintSystem) ShiftLeft(a Variable, shift, nbBits int) Variable {
+	assertShift(shift, nbBits)
+	bits := cs.ToBinary(a, nbBits)
+	if shift >= nbBits {
+		return cs.Constant(0)
+	}
+
+	// the bits are boolean constrained by ToBinary, FromBinary records no constraint
+	var coeff big.Int
+	coeff.Lsh(bOne, uint(shift))
+	return cs.Mul(cs.FromBinary(bits[:nbBits-shift]...), coeff)
+}
+
+// ShiftRight returns a >> shift, a must be less than 2**nbBits and shift must be non negative
+// (ToBinary, nbBits+1 constraints)
+func (cs *ConstraintSystem) ShiftRight(a Variable, shift, nbBits int) Variable {
+	assertShift(shift, nbBits)
+	bits := cs.ToBinary(a, nbBits)
+	if shift >= nbBits {
+		return cs.Constant(0)
+	}
+	return cs.FromBinary(bits[shift:]...)
+}
+
+// RotateLeft returns the rotation of the nbBits word a by shift bits to the left,
+// a must be less than 2**nbBits (ToBinary, nbBits+1 constraints)
+//
+// shift is taken modulo nbBits, a negative shift rotates to the right
+func (cs *ConstraintSystem) RotateLeft(a Variable, shift, nbBits int) Variable {
+	assertWordSize(nbBits)
+	shift %= nbBits
+	if shift < 0 {
+		shift += nbBits
+	}
+	bits := cs.ToBinary(a, nbBits)
+	if shift == 0 {
+		return cs.FromBinary(bits...)
+	}
+
+	// the bits are boolean constrained by ToBinary, FromBinary records no constraint
+	var coeff big.Int
+	coeff.Lsh(bOne, uint(shift))
+	high := cs.Mul(cs.FromBinary(bits[:nbBits-shift]...), coeff)
+	low := cs.FromBinary(bits[nbBits-shift:]...)
+	return cs.Add(high, low)
+}
+
+// RotateRight returns the rotation of the nbBits word a by shift bits to the right,
+// a must be less than 2**nbBits (ToBinary, nbBits+1 constraints)
+//
+// shift is taken modulo nbBits, a negative shift rotates to the left
+func (cs *ConstraintSystem) RotateRight(a Variable, shift, nbBits int) Variable {
+	return cs.RotateLeft(a, -shift, nbBits)
+}
+
+// Lookup2 returns i0, i1, i2 or i3 according to the selector bits b0 (least significant) and b1
+//
+// 3 constraints:
+//
+//	(i3 - i2 - i1 + i0)⋅b1 == t1 - i1 + i0
+//	t1⋅b0 == t2
+//	(i2 - i0)⋅b1 == res - t2 - i0
+func (cs *ConstraintSystem) Lookup2(b0, b1 Variable, i0, i1, i2, i3 interface{}) Variable {
+
+	cs.completeDanglingVariable(&b0)
+	cs.completeDanglingVariable(&b1)
+
+	// ensures that b0 and b1 are boolean
+	cs.AssertIsBoolean(b0)
+	cs.AssertIsBoolean(b1)
+
+	// i3 - i2 - i1 + i0
+	l := cs.Sub(i3, i2) // no constraint is recorded
+	l = cs.Sub(l, i1)   // no constraint is recorded
+	l = cs.Add(l, i0)   // no constraint is recorded
+	t1 := cs.newInternalVariable()
+	o := cs.Sub(t1, i1) // no constraint is recorded
+	o = cs.Add(o, i0)   // no constraint is recorded
+	constraint := r1c.R1C{L: l.getLinExpCopy(), R: b1.getLinExpCopy(), O: o.getLinExpCopy(), Solver: r1c.SingleOutput}
+	cs.constraints = append(cs.constraints, constraint)
+
+	t2 := cs.Mul(t1, b0)
+
+	res := cs.newInternalVariable()
+	l = cs.Sub(i2, i0)  // no constraint is recorded
+	o = cs.Sub(res, t2) // no constraint is recorded
+	o = cs.Sub(o, i0)   // no constraint is recorded
+	constraint = r1c.R1C{L: l.getLinExpCopy(), R: b1.getLinExpCopy(), O: o.getLinExpCopy(), Solver: r1c.SingleOutput}
+	cs.constraints = append(cs.constraints, constraint)
+
+	return res
+}
+
+// Mux returns values[sel]
+//
+// sel is decomposed on k bits, where 2**k is the number of values rounded up to the next power of 2
+// (the missing values are 0), and the values are selected with Lookup2 (3 constraints per 4 values)
+// or Select (1 constraint per 2 values) on the bits of sel.
+//
+// sel is only constrained to be less than 2**k, see ArrayAccess to constrain it to be a valid index.
+func (cs *ConstraintSystem) Mux(sel Variable, values ...interface{}) Variable {
+
+	cs.completeDanglingVariable(&sel)
+
+	switch len(values) {
+	case 0:
+		panic("Mux needs at least one value")
+	case 1:
+		cs.AssertIsEqual(sel, 0)
+		return cs.Constant(values[0])
+	}
+
+	nbBits := bits.Len(uint(len(values) - 1))
+	selBits := cs.ToBinary(sel, nbBits)
+
+	// pad the values with 0
+	entries := make([]interface{}, 1<<nbBits)
+	copy(entries, values)
+	for i := len(values); i < len(entries); i++ {
+		entries[i] = 0
+	}
+
+	// at each step, the number of entries is divided by 4 (Lookup2) or 2 (Select)
+	for len(selBits) > 0 {
+		if len(selBits) == 1 {
+			return cs.Select(selBits[0], entries[1], entries[0])
+		}
+		next := make([]interface{}, len(entries)/4)
+		for i := 0; i < len(next); i++ {
+			next[i] = cs.Lookup2(selBits[0], selBits[1], entries[4*i], entries[4*i+1], entries[4*i+2], entries[4*i+3])
+		}
+		entries, selBits = next, selBits[2:]
+	}
+
+	return cs.Constant(entries[0])
+}
+
+// ArrayAccess returns array[index], and ensures that index is less than len(array)
+//
+// see Mux, when len(array) is not a power of 2, index is also compared to len(array) (see IsLess)
+func (cs *ConstraintSystem) ArrayAccess(index Variable, array []Variable) Variable {
+
+	values := make([]interface{}, len(array))
+	for i := 0; i < len(array); i++ {
+		values[i] = array[i]
+	}
+	res := cs.Mux(index, values...)
+
+	// Mux ensures that index < 2**nbBits
+	nbBits := bits.Len(uint(len(array) - 1))
+	if len(array) != 1<<nbBits {
+		cs.AssertIsEqual(cs.IsLess(index, len(array), nbBits), 1)
+	}
+
+	return res
+}
+
 // Constant will return (and allocate if neccesary) a constant Variable
 //
 // input can be a Variable or must be convertible to big.Int (see backend.FromInterface)
@@ -351,6 +801,31 @@ func (cs *ConstraintSystem) Constant(input interface{}) Variable {
 	}
 }
 
+// NewHint returns a new internal Variable, computed when solving the R1CS by calling f
+// with the values of the inputs (Variables or constants)
+//
+// No constraint is recorded: the circuit must constrain the returned Variable, as a
+// malicious prover can assign it any value.
+//
+// f is registered (see hint.Register) for the R1CS to be solved in this process. A process
+// solving a deserialized R1CS must register f first.
+func (cs *ConstraintSystem) NewHint(f hint.Function, inputs ...interface{}) Variable {
+	h := r1c.Hint{
+		ID:     hint.Register(f),
+		Inputs: make([]r1c.LinearExpression, len(inputs)),
+	}
+	for i := 0; i < len(inputs); i++ {
+		v := cs.Constant(inputs[i])
+		h.Inputs[i] = v.getLinExpCopy()
+	}
+
+	res := cs.newInternalVariable()
+	h.WireID = res.id
+	cs.hints = append(cs.hints, h)
+
+	return res
+}
+
 func (cs *ConstraintSystem) buildLogEntryFromVariable(v Variable) logEntry {
 
 	var res logEntry
@@ -400,13 +875,13 @@ func (cs *ConstraintSystem) AssertIsBoolean(v Variable) {
 
 	cs.completeDanglingVariable(&v)
 
-	if v.isBoolean {
+	if cs.isBoolean(v) {
 		return
 	}
+	cs.markBoolean(&v)
 
 	_v := cs.Sub(1, v)  // no variable is recorded in the cs
 	o := cs.Constant(0) // no variable is recorded in the cs
-	v.isBoolean = true
 
 	constraint := r1c.R1C{L: v.getLinExpCopy(), R: _v.getLinExpCopy(), O: o.getLinExpCopy(), Solver: r1c.SingleOutput}
 
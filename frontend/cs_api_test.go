@@ -3,6 +3,7 @@ package frontend
 import (
 	"errors"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/consensys/gnark/backend"
@@ -376,7 +377,7 @@ func rfSelect() runfunc {
 	return res
 }
 
-var nsSelect = deltaState{1, 2, 3, 3, 4}
+var nsSelect = deltaState{1, 2, 3, 3, 1} // the selector is boolean constrained once
 
 // copy of variable
 func rfConstant() runfunc {
@@ -508,7 +509,7 @@ func rfIsBoolean() runfunc {
 	return res
 }
 
-var nsIsBoolean = deltaState{1, 1, 0, 0, 4}
+var nsIsBoolean = deltaState{1, 1, 0, 0, 2}
 
 // bound a variable by another variable
 func rfMustBeLessOrEqVar() runfunc {
@@ -570,6 +571,509 @@ func rfMustBeLessOrEqConst() runfunc {
 
 var nsMustBeLessOrEqConst = csState{1, 0, 257, 2, 511} // nb internal variables: 256+HW(bound), nb constraints: 1+HW(bound), nb assertions: 256+HW(^bound)
 
+// boolean result of a zero test
+func rfIsZero() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable(variableName.String())
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		v := systemUnderTest.(*ConstraintSystem).IsZero(a)
+		iVariablesCreated = append(iVariablesCreated, v)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			r1c.SingleOutput}
+
+		return csRes
+	}
+	return res
+}
+
+var nsIsZero = deltaState{1, 0, 2, 1, 1} // internal variables: hint output and result
+
+// boolean result of an equality test between 2 variables
+func rfIsEqualVariable() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable(variableName.String())
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		b := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, b)
+
+		v := systemUnderTest.(*ConstraintSystem).IsEqual(a, b)
+		iVariablesCreated = append(iVariablesCreated, v)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			r1c.SingleOutput}
+
+		return csRes
+	}
+	return res
+}
+
+var nsIsEqualVariable = deltaState{1, 1, 2, 1, 1}
+
+// comparison of 2 variables on 8 bits
+func rfIsLess() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable(variableName.String())
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		b := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, b)
+
+		systemUnderTest.(*ConstraintSystem).IsLess(a, b, 8)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			r1c.BinaryDec}
+
+		return csRes
+	}
+	return res
+}
+
+var nsIsLess = deltaState{1, 1, 9, 1, 9} // nbBits+1 bits, each one boolean constrained
+
+// 3-way comparison of 2 variables on 8 bits
+func rfCmp() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable(variableName.String())
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		b := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, b)
+
+		systemUnderTest.(*ConstraintSystem).Cmp(a, b, 8)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			r1c.SingleOutput}
+
+		return csRes
+	}
+	return res
+}
+
+var nsCmp = deltaState{1, 1, 11, 2, 10} // IsLess + IsEqual
+
+// boolean and of 2 variables
+func rfAnd() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable(variableName.String())
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		b := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, b)
+
+		v := systemUnderTest.(*ConstraintSystem).And(a, b)
+		iVariablesCreated = append(iVariablesCreated, v)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			r1c.SingleOutput}
+
+		return csRes
+	}
+	return res
+}
+
+var nsAnd = deltaState{1, 1, 1, 1, 2}
+
+// boolean or of 2 variables
+func rfOr() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable(variableName.String())
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		b := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, b)
+
+		v := systemUnderTest.(*ConstraintSystem).Or(a, b)
+		iVariablesCreated = append(iVariablesCreated, v)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			r1c.SingleOutput}
+
+		return csRes
+	}
+	return res
+}
+
+var nsOr = deltaState{1, 1, 1, 1, 2}
+
+// boolean negation of a variable, negated twice
+func rfNot() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable(variableName.String())
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		v := systemUnderTest.(*ConstraintSystem).Not(a)
+		systemUnderTest.(*ConstraintSystem).Not(v)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			r1c.SingleOutput}
+
+		return csRes
+	}
+	return res
+}
+
+var nsNot = deltaState{1, 0, 0, 0, 1} // the result of Not is not boolean constrained again
+
+// boolean and of 3 variables
+func rfAndN() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable(variableName.String())
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		b := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, b)
+
+		c := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, c)
+
+		systemUnderTest.(*ConstraintSystem).AndN(a, b, c)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			r1c.SingleOutput}
+
+		return csRes
+	}
+	return res
+}
+
+var nsAndN = deltaState{1, 2, 2, 1, 4} // IsEqual(a+b+c, 3)
+
+// boolean or of 3 variables
+func rfOrN() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable(variableName.String())
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		b := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, b)
+
+		c := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, c)
+
+		systemUnderTest.(*ConstraintSystem).OrN(a, b, c)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			r1c.SingleOutput}
+
+		return csRes
+	}
+	return res
+}
+
+var nsOrN = deltaState{1, 2, 2, 1, 4} // 1 - IsZero(a+b+c)
+
+// bitwise and of 2 variables on 8 bits
+func rfBitwiseAnd() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable(variableName.String())
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		b := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, b)
+
+		systemUnderTest.(*ConstraintSystem).BitwiseAnd(a, b, 8)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			r1c.BinaryDec}
+
+		return csRes
+	}
+	return res
+}
+
+var nsBitwiseAnd = deltaState{1, 1, 24, 10, 16} // the bits are boolean constrained once, by ToBinary
+
+// lookup in a table of 4 entries
+func rfLookup2() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable(variableName.String())
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		b := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, b)
+
+		c := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, c)
+
+		d := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, d)
+
+		v := systemUnderTest.(*ConstraintSystem).Lookup2(a, b, c, d, 3, 4)
+		iVariablesCreated = append(iVariablesCreated, v)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			r1c.SingleOutput}
+
+		return csRes
+	}
+	return res
+}
+
+var nsLookup2 = deltaState{1, 3, 3, 3, 2}
+
+// multiplexer with 5 entries
+func rfMux() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable(variableName.String())
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		b := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, b)
+
+		c := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, c)
+
+		systemUnderTest.(*ConstraintSystem).Mux(a, b, c, 3, 4, 5)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			r1c.SingleOutput}
+
+		return csRes
+	}
+	return res
+}
+
+var nsMux = deltaState{1, 2, 10, 8, 3} // 3 bits, 2 Lookup2 and 1 Select
+
+// access in an array of 3 variables
+func rfArrayAccess() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable(variableName.String())
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		b := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, b)
+
+		c := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, c)
+
+		d := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, d)
+
+		systemUnderTest.(*ConstraintSystem).ArrayAccess(a, []Variable{b, c, d})
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			r1c.SingleOutput}
+
+		return csRes
+	}
+	return res
+}
+
+var nsArrayAccess = deltaState{1, 3, 8, 5, 6} // Mux (2 bits, 1 Lookup2) and IsLess(a, 3, 2)
+
+// euclidean division of 2 variables on 8 bits
+func rfDivMod() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable(variableName.String())
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		b := systemUnderTest.(*ConstraintSystem).newSecretVariable(variableName.String())
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, b)
+
+		q, r := systemUnderTest.(*ConstraintSystem).DivMod(a, b, 8)
+		iVariablesCreated = append(iVariablesCreated, q, r)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			r1c.BinaryDec}
+
+		return csRes
+	}
+	return res
+}
+
+var nsDivMod = deltaState{1, 1, 43, 5, 43} // q and r (hints), ToBinary of a, b, q, r and IsLess(r, b, 8)
+
+// rotation of a variable on 8 bits
+func rfRotateLeft() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable(variableName.String())
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		systemUnderTest.(*ConstraintSystem).RotateLeft(a, 3, 8)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			r1c.BinaryDec}
+
+		return csRes
+	}
+	return res
+}
+
+var nsRotateLeft = deltaState{1, 0, 8, 1, 8} // ToBinary, the bits are repacked without constraint
+
 // ------------------------------------------------------------------------------
 // build the next state function using the delta state
 func nextStateFunc(ds deltaState) nextstatefunc {
@@ -602,6 +1106,21 @@ func TestAPI(t *testing.T) {
 		buildProtoCommands("IsEqual", rfIsEqual(), nextStateFunc(nsIsEqual)),
 		buildProtoCommands("FromBinary", rfFromBinary(), nextStateFunc(nsFromBinary)),
 		buildProtoCommands("IsBoolean", rfIsBoolean(), nextStateFunc(nsIsBoolean)), // TODO fix isBoolean to record if it was already boolean constrained
+		buildProtoCommands("IsZero", rfIsZero(), nextStateFunc(nsIsZero)),
+		buildProtoCommands("IsEqual variable", rfIsEqualVariable(), nextStateFunc(nsIsEqualVariable)),
+		buildProtoCommands("IsLess", rfIsLess(), nextStateFunc(nsIsLess)),
+		buildProtoCommands("Cmp", rfCmp(), nextStateFunc(nsCmp)),
+		buildProtoCommands("And", rfAnd(), nextStateFunc(nsAnd)),
+		buildProtoCommands("Or", rfOr(), nextStateFunc(nsOr)),
+		buildProtoCommands("Not", rfNot(), nextStateFunc(nsNot)),
+		buildProtoCommands("AndN", rfAndN(), nextStateFunc(nsAndN)),
+		buildProtoCommands("OrN", rfOrN(), nextStateFunc(nsOrN)),
+		buildProtoCommands("BitwiseAnd", rfBitwiseAnd(), nextStateFunc(nsBitwiseAnd)),
+		buildProtoCommands("Lookup2", rfLookup2(), nextStateFunc(nsLookup2)),
+		buildProtoCommands("Mux", rfMux(), nextStateFunc(nsMux)),
+		buildProtoCommands("ArrayAccess", rfArrayAccess(), nextStateFunc(nsArrayAccess)),
+		buildProtoCommands("DivMod", rfDivMod(), nextStateFunc(nsDivMod)),
+		buildProtoCommands("RotateLeft", rfRotateLeft(), nextStateFunc(nsRotateLeft)),
 		// buildProtoCommands("Must be less or eq var", rfMustBeLessOrEqVar(), nextStateFunc(nsMustBeLessOrEqVar)), // TODO restore once isBoolean is fixed
 		// buildProtoCommands("Must be less or eq const", rfMustBeLessOrEqConst(), nextStateFunc(nsMustBeLessOrEqConst)), // TODO idem
 	}
@@ -761,6 +1280,94 @@ func (c *isLessOrEq) Define(curveID gurvy.ID, cs *ConstraintSystem) error {
 	return nil
 }
 
+type isZeroCircuit struct {
+	A Variable
+}
+
+func (c *isZeroCircuit) Define(curveID gurvy.ID, cs *ConstraintSystem) error {
+	var unsetVar Variable
+	cs.IsZero(unsetVar)
+	return nil
+}
+
+type cmpCircuit struct {
+	A Variable
+}
+
+func (c *cmpCircuit) Define(curveID gurvy.ID, cs *ConstraintSystem) error {
+	var unsetVar Variable
+	cs.Cmp(unsetVar, c.A, 8)
+	return nil
+}
+
+type andCircuit struct {
+	A Variable
+}
+
+func (c *andCircuit) Define(curveID gurvy.ID, cs *ConstraintSystem) error {
+	var unsetVar Variable
+	cs.And(unsetVar, c.A)
+	return nil
+}
+
+type muxCircuit struct {
+	A Variable
+}
+
+func (c *muxCircuit) Define(curveID gurvy.ID, cs *ConstraintSystem) error {
+	var unsetVar Variable
+	cs.Mux(unsetVar, c.A, 1, 2)
+	return nil
+}
+
+type divModCircuit struct {
+	A Variable
+}
+
+func (c *divModCircuit) Define(curveID gurvy.ID, cs *ConstraintSystem) error {
+	var unsetVar Variable
+	cs.DivMod(unsetVar, c.A, 8)
+	return nil
+}
+
+type rotateCircuit struct {
+	A Variable
+}
+
+func (c *rotateCircuit) Define(curveID gurvy.ID, cs *ConstraintSystem) error {
+	var unsetVar Variable
+	cs.RotateLeft(unsetVar, 3, 8)
+	return nil
+}
+
+func TestShiftPanics(t *testing.T) {
+	shifts := map[string]func(cs *ConstraintSystem, a Variable){
+		"ShiftLeft negative shift":  func(cs *ConstraintSystem, a Variable) { cs.ShiftLeft(a, -1, 8) },
+		"ShiftRight negative shift": func(cs *ConstraintSystem, a Variable) { cs.ShiftRight(a, -1, 8) },
+		"ShiftLeft empty word":      func(cs *ConstraintSystem, a Variable) { cs.ShiftLeft(a, 1, 0) },
+		"ShiftRight empty word":     func(cs *ConstraintSystem, a Variable) { cs.ShiftRight(a, 1, 0) },
+		"RotateLeft empty word":     func(cs *ConstraintSystem, a Variable) { cs.RotateLeft(a, 1, 0) },
+		"RotateRight negative word": func(cs *ConstraintSystem, a Variable) { cs.RotateRight(a, 1, -8) },
+	}
+
+	for name, shift := range shifts {
+		t.Run(name, func(_t *testing.T) {
+			cs := newConstraintSystem()
+			a := cs.newPublicVariable("a")
+			defer func() {
+				r := recover()
+				if r == nil {
+					_t.Fatal("an invalid shift should panic")
+				}
+				if msg, ok := r.(string); !ok || !strings.HasPrefix(msg, "invalid") {
+					_t.Fatal("unexpected panic:", r)
+				}
+			}()
+			shift(&cs, a)
+		})
+	}
+}
+
 func TestUnsetVariables(t *testing.T) {
 
 	mapFuncs := map[string]Circuit{
@@ -776,6 +1383,12 @@ func TestUnsetVariables(t *testing.T) {
 		"isEqual":      &isEqualCircuit{},
 		"isBoolean":    &isBooleanCircuit{},
 		"isLessOrEq":   &isLessOrEq{},
+		"isZero":       &isZeroCircuit{},
+		"cmp":          &cmpCircuit{},
+		"and":          &andCircuit{},
+		"mux":          &muxCircuit{},
+		"divMod":       &divModCircuit{},
+		"rotate":       &rotateCircuit{},
 	}
 
 	for name, arg := range mapFuncs {
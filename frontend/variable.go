@@ -39,7 +39,7 @@ type Wire struct {
 type Variable struct {
 	Wire
 	linExp    r1c.LinearExpression
-	isBoolean bool // TODO it doesn't work, we need a pointer for that
+	isBoolean bool // boolean linear expression, boolean wires are tracked in ConstraintSystem booleans maps
 }
 
 // Assign v = value . This must called when using a Circuit as a witness data structure
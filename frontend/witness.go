@@ -0,0 +1,56 @@
+package frontend
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/r1cs"
+	backend_bls377 "github.com/consensys/gnark/internal/backend/bls377"
+	backend_bls381 "github.com/consensys/gnark/internal/backend/bls381"
+	backend_bn256 "github.com/consensys/gnark/internal/backend/bn256"
+	backend_bw761 "github.com/consensys/gnark/internal/backend/bw761"
+	"github.com/consensys/gurvy"
+)
+
+// NewWitness returns the statically typed witness of an assigned circuit
+//
+// The inputs are visited in the same order as in Compile, so the values of the returned
+// witness are ordered like the wires of the R1CS compiled from the same circuit type. Unlike
+// ParseWitness, it doesn't build a map[string]interface{}, and the returned witness is consumed
+// by groth16.Prove and groth16.Verify without name lookups.
+func NewWitness(curveID gurvy.ID, assignment Circuit) (r1cs.Witness, error) {
+	var public, secret []interface{}
+
+	var handler leafHandler = func(visibility backend.Visibility, name string, tInput reflect.Value) error {
+		v := tInput.Interface().(Variable)
+		if v.val == nil {
+			return fmt.Errorf("%q: %w", name, backend.ErrInputNotSet)
+		}
+		switch visibility {
+		case backend.Unset, backend.Secret:
+			secret = append(secret, v.val)
+		case backend.Public:
+			public = append(public, v.val)
+		}
+		return nil
+	}
+
+	if err := parseType(assignment, "", backend.Unset, handler); err != nil {
+		return nil, err
+	}
+
+	switch curveID {
+	case gurvy.BN256:
+		return backend_bn256.NewWitness(public, secret), nil
+	case gurvy.BLS377:
+		return backend_bls377.NewWitness(public, secret), nil
+	case gurvy.BLS381:
+		return backend_bls381.NewWitness(public, secret), nil
+	case gurvy.BW761:
+		return backend_bw761.NewWitness(public, secret), nil
+	default:
+		return nil, errors.New("unknown curve ID")
+	}
+}
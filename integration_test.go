@@ -17,10 +17,15 @@ limitations under the License.
 package gnark
 
 import (
+	"bytes"
+	"io"
+	"math/bits"
 	"os"
 	"testing"
 
 	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/groth16/mpcsetup"
+	"github.com/consensys/gnark/backend/plonk"
 	"github.com/consensys/gnark/internal/backend/circuits"
 	"github.com/consensys/gurvy"
 )
@@ -75,3 +80,136 @@ func TestIntegrationAPI(t *testing.T) {
 	}
 
 }
+
+func TestIntegrationAPIMPCSetup(t *testing.T) {
+
+	curves := []gurvy.ID{gurvy.BN256, gurvy.BLS377, gurvy.BLS381, gurvy.BW761}
+
+	for name, circuit := range circuits.Circuits {
+		t.Log(name)
+
+		if testing.Short() {
+			if name != "lut01" && name != "frombinary" {
+				continue
+			}
+		}
+		for _, curve := range curves {
+			t.Log(curve.String())
+			typedR1CS := circuit.R1CS.ToR1CS(curve)
+
+			// phase 1, with 2 contributions; each participant receives the serialized
+			// output of the previous one
+			power := bits.Len64(typedR1CS.GetNbConstraints() - 1)
+			srs1 := []mpcsetup.Phase1{mpcsetup.InitPhase1(curve, power)}
+			for i := 0; i < 2; i++ {
+				next := mpcsetup.NewPhase1(curve)
+				roundTrip(t, srs1[i], next)
+				if err := next.Contribute(); err != nil {
+					t.Fatal(err)
+				}
+				srs1 = append(srs1, next)
+			}
+			if err := mpcsetup.VerifyPhase1(srs1[0], srs1[1], srs1[2:]...); err != nil {
+				t.Fatal(err)
+			}
+
+			// phase 2, with 2 contributions
+			srs2init, evals, err := mpcsetup.InitPhase2(typedR1CS, srs1[len(srs1)-1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			srs2 := []mpcsetup.Phase2{srs2init}
+			for i := 0; i < 2; i++ {
+				next := mpcsetup.NewPhase2(curve)
+				roundTrip(t, srs2[i], next)
+				if err := next.Contribute(); err != nil {
+					t.Fatal(err)
+				}
+				srs2 = append(srs2, next)
+			}
+			if err := mpcsetup.VerifyPhase2(srs2[0], srs2[1], srs2[2:]...); err != nil {
+				t.Fatal(err)
+			}
+
+			pk, vk, err := mpcsetup.ExtractKeys(typedR1CS, srs1[len(srs1)-1], srs2[len(srs2)-1], evals)
+			if err != nil {
+				t.Fatal(err)
+			}
+			correctProof, err := groth16.Prove(typedR1CS, pk, circuit.Good)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wrongProof, err := groth16.Prove(typedR1CS, pk, circuit.Bad, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = groth16.Verify(correctProof, vk, circuit.Public)
+			if err != nil {
+				t.Fatal("Verify should have succeeded")
+			}
+			err = groth16.Verify(wrongProof, vk, circuit.Public)
+			if err == nil {
+				t.Fatal("Verify should have failed")
+			}
+		}
+	}
+
+}
+
+func roundTrip(t *testing.T, from io.WriterTo, to io.ReaderFrom) {
+	var buf bytes.Buffer
+	if _, err := from.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := to.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIntegrationAPIPLONK(t *testing.T) {
+
+	curves := []gurvy.ID{gurvy.BN256, gurvy.BLS377, gurvy.BLS381, gurvy.BW761}
+
+	for name, circuit := range circuits.Circuits {
+		t.Log(name)
+
+		if testing.Short() {
+			if name != "lut01" && name != "frombinary" {
+				continue
+			}
+		}
+		for _, curve := range curves {
+			t.Log(curve.String())
+			sparseR1CS := circuit.R1CS.ToSparseR1CS(curve)
+
+			srs, err := plonk.RandomSRS(curve, sparseR1CS.GetNbConstraints()+sparseR1CS.GetNbWires())
+			if err != nil {
+				t.Fatal(err)
+			}
+			pk, vk, err := plonk.Setup(sparseR1CS, srs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			correctProof, err := plonk.Prove(sparseR1CS, pk, circuit.Good)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wrongProof, err := plonk.Prove(sparseR1CS, pk, circuit.Bad, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = plonk.Verify(correctProof, vk, circuit.Public)
+			if err != nil {
+				t.Fatal("Verify should have succeeded")
+			}
+			err = plonk.Verify(wrongProof, vk, circuit.Public)
+			if err == nil {
+				t.Fatal("Verify should have failed")
+			}
+
+		}
+	}
+
+}
@@ -0,0 +1,294 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package mpcsetup
+
+import (
+	"github.com/consensys/gurvy/bls377/fr"
+
+	curve "github.com/consensys/gurvy/bls377"
+
+	"github.com/consensys/gnark/internal/backend/bls377/fft"
+
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// PublicKey is the proof of knowledge of a toxic parameter x, bound to the transcript
+// (see https://eprint.iacr.org/2017/1050.pdf, section 3.1)
+type PublicKey struct {
+	SG  curve.G1Affine // [s]₁, with s random
+	SXG curve.G1Affine // [s⋅x]₁
+	XR  curve.G2Affine // [x]R, with R = genR([s]₁, [s⋅x]₁, challenge, dst)
+}
+
+func newPublicKey(x fr.Element, challenge []byte, dst byte) PublicKey {
+	var pk PublicKey
+	_, _, g1, _ := curve.Generators()
+
+	var s fr.Element
+	var sBi big.Int
+	if _, err := s.SetRandom(); err != nil {
+		panic(err)
+	}
+	s.ToBigIntRegular(&sBi)
+	pk.SG.ScalarMultiplication(&g1, &sBi)
+
+	// compute x*sG1
+	var xBi big.Int
+	x.ToBigIntRegular(&xBi)
+	pk.SXG.ScalarMultiplication(&pk.SG, &xBi)
+
+	// generate R based on sG1, sxG1, challenge, and domain separation tag (tau, alpha or beta)
+	R := genR(pk.SG, pk.SXG, challenge, dst)
+
+	// compute x*spG2
+	pk.XR.ScalarMultiplication(&R, &xBi)
+	return pk
+}
+
+// genR define the random point R of a proof of knowledge, by hashing to G₂ the sG1, sxG1,
+// the challenge (the hash of the previous contribution) and a domain separation tag
+//
+// the hash to curve may output a point which is not in G₂: in that case, a counter is appended
+// to the message and we try again (the verifier deterministically recomputes the same point)
+func genR(sG1, sxG1 curve.G1Affine, challenge []byte, dst byte) curve.G2Affine {
+	var buf []byte
+	sG1Bytes, sxG1Bytes := sG1.Bytes(), sxG1.Bytes()
+	buf = append(buf, sG1Bytes[:]...)
+	buf = append(buf, sxG1Bytes[:]...)
+	buf = append(buf, challenge...)
+	buf = append(buf, 0)
+	for {
+		spG2, err := curve.HashToCurveG2Svdw(buf, []byte{dst})
+		if err != nil {
+			panic(err) // only fails if the domain separation tag is longer than 255 bytes
+		}
+		if spG2.IsOnCurve() && spG2.IsInSubGroup() && !spG2.IsInfinity() {
+			return spG2
+		}
+		buf[len(buf)-1]++
+	}
+}
+
+// sameRatio checks that a₁/b₁ == a₂/b₂, that is e(a₁, b₂) == e(b₁, a₂)
+//
+// points at infinity are rejected, as they would satisfy any ratio
+func sameRatio(a1, b1 curve.G1Affine, a2, b2 curve.G2Affine) bool {
+	if a1.IsInfinity() || b1.IsInfinity() || a2.IsInfinity() || b2.IsInfinity() {
+		return false
+	}
+	left, err := curve.Pair([]curve.G1Affine{a1}, []curve.G2Affine{b2})
+	if err != nil {
+		return false
+	}
+	right, err := curve.Pair([]curve.G1Affine{b1}, []curve.G2Affine{a2})
+	if err != nil {
+		return false
+	}
+	return left.Equal(&right)
+}
+
+// linearCombinationG1 returns (Σ rᵢ⋅A[i], Σ rᵢ⋅A[i+1]) for random rᵢ, i in [0, len(A)-1)
+//
+// if A[i+1] = x⋅A[i] for all i, the second point is x times the first one
+func linearCombinationG1(A []curve.G1Affine) (L1, L2 curve.G1Affine, err error) {
+	nc := len(A) - 1
+	r, err := randomScalars(nc)
+	if err != nil {
+		return
+	}
+	L1.MultiExp(A[:nc], r)
+	L2.MultiExp(A[1:], r)
+	return
+}
+
+// linearCombinationG2 returns (Σ rᵢ⋅A[i], Σ rᵢ⋅A[i+1]) for random rᵢ, i in [0, len(A)-1)
+//
+// if A[i+1] = x⋅A[i] for all i, the second point is x times the first one
+func linearCombinationG2(A []curve.G2Affine) (L1, L2 curve.G2Affine, err error) {
+	nc := len(A) - 1
+	r, err := randomScalars(nc)
+	if err != nil {
+		return
+	}
+	L1.MultiExp(A[:nc], r)
+	L2.MultiExp(A[1:], r)
+	return
+}
+
+// merge returns (Σ rᵢ⋅A[i], Σ rᵢ⋅B[i]) for random rᵢ
+//
+// if B[i] = x⋅A[i] for all i, the second point is x times the first one
+func merge(A, B []curve.G1Affine) (a, b curve.G1Affine, err error) {
+	r, err := randomScalars(len(A))
+	if err != nil {
+		return
+	}
+	a.MultiExp(A, r)
+	b.MultiExp(B, r)
+	return
+}
+
+func randomScalars(n int) ([]fr.Element, error) {
+	r := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		if _, err := r[i].SetRandom(); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// powers returns [1, a, a², ..., aⁿ⁻¹ ]
+func powers(a fr.Element, n int) []fr.Element {
+	result := make([]fr.Element, n)
+	result[0].SetOne()
+	for i := 1; i < n; i++ {
+		result[i].Mul(&result[i-1], &a)
+	}
+	return result
+}
+
+// scaleG1InPlace returns [a[0]⋅b[0], ..., a[n-1]⋅b[n-1]]
+func scaleG1InPlace(a []curve.G1Affine, b []fr.Element) {
+	utils.Parallelize(len(a), func(start, end int) {
+		var bi big.Int
+		for i := start; i < end; i++ {
+			b[i].ToBigIntRegular(&bi)
+			a[i].ScalarMultiplication(&a[i], &bi)
+		}
+	})
+}
+
+// scaleG2InPlace returns [a[0]⋅b[0], ..., a[n-1]⋅b[n-1]]
+func scaleG2InPlace(a []curve.G2Affine, b []fr.Element) {
+	utils.Parallelize(len(a), func(start, end int) {
+		var bi big.Int
+		for i := start; i < end; i++ {
+			b[i].ToBigIntRegular(&bi)
+			a[i].ScalarMultiplication(&a[i], &bi)
+		}
+	})
+}
+
+// scaleG1 sets a[i] to b⋅a[i]
+func scaleG1(a []curve.G1Affine, b fr.Element) {
+	var bi big.Int
+	b.ToBigIntRegular(&bi)
+	utils.Parallelize(len(a), func(start, end int) {
+		for i := start; i < end; i++ {
+			a[i].ScalarMultiplication(&a[i], &bi)
+		}
+	})
+}
+
+// lagrangeCoeffsG1 returns the [Lᵢ(τ)]₁ from the [τⁱ]₁, i < domain.Cardinality, where Lᵢ
+// is the i-th Lagrange polynomial of the domain: Lᵢ(τ) = 1/n Σⱼ ω⁻ⁱʲ τʲ (inverse FFT)
+func lagrangeCoeffsG1(taus []curve.G1Affine, domain *fft.Domain) []curve.G1Affine {
+	n := int(domain.Cardinality)
+	coeffs := make([]curve.G1Jac, n)
+	for i := 0; i < n; i++ {
+		coeffs[i].FromAffine(&taus[i])
+	}
+	bitReverse(n, func(i, j int) { coeffs[i], coeffs[j] = coeffs[j], coeffs[i] })
+
+	var t, u curve.G1Jac
+	for m := 2; m <= n; m <<= 1 {
+		twiddles := twiddlesInv(domain, m)
+		for k := 0; k < n; k += m {
+			for j := 0; j < m/2; j++ {
+				t.ScalarMultiplication(&coeffs[k+j+m/2], &twiddles[j])
+				u.Set(&coeffs[k+j])
+				coeffs[k+j].AddAssign(&t)
+				coeffs[k+j+m/2].Set(&u).SubAssign(&t)
+			}
+		}
+	}
+
+	var nInv big.Int
+	domain.CardinalityInv.ToBigIntRegular(&nInv)
+	utils.Parallelize(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			coeffs[i].ScalarMultiplication(&coeffs[i], &nInv)
+		}
+	})
+
+	res := make([]curve.G1Affine, n)
+	curve.BatchJacobianToAffineG1Affine(coeffs, res)
+	return res
+}
+
+// lagrangeCoeffsG2 returns the [Lᵢ(τ)]₂ from the [τⁱ]₂, i < domain.Cardinality, where Lᵢ
+// is the i-th Lagrange polynomial of the domain: Lᵢ(τ) = 1/n Σⱼ ω⁻ⁱʲ τʲ (inverse FFT)
+func lagrangeCoeffsG2(taus []curve.G2Affine, domain *fft.Domain) []curve.G2Affine {
+	n := int(domain.Cardinality)
+	coeffs := make([]curve.G2Jac, n)
+	for i := 0; i < n; i++ {
+		coeffs[i].FromAffine(&taus[i])
+	}
+	bitReverse(n, func(i, j int) { coeffs[i], coeffs[j] = coeffs[j], coeffs[i] })
+
+	var t, u curve.G2Jac
+	for m := 2; m <= n; m <<= 1 {
+		twiddles := twiddlesInv(domain, m)
+		for k := 0; k < n; k += m {
+			for j := 0; j < m/2; j++ {
+				t.ScalarMultiplication(&coeffs[k+j+m/2], &twiddles[j])
+				u.Set(&coeffs[k+j])
+				coeffs[k+j].AddAssign(&t)
+				coeffs[k+j+m/2].Set(&u).SubAssign(&t)
+			}
+		}
+	}
+
+	var nInv big.Int
+	domain.CardinalityInv.ToBigIntRegular(&nInv)
+	res := make([]curve.G2Affine, n)
+	utils.Parallelize(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			coeffs[i].ScalarMultiplication(&coeffs[i], &nInv)
+			res[i].FromJacobian(&coeffs[i])
+		}
+	})
+	return res
+}
+
+// twiddlesInv returns the m/2 first powers of ω⁻ⁿᐟᵐ (ω⁻ⁿᐟᵐ is a m-th root of unity)
+func twiddlesInv(domain *fft.Domain, m int) []big.Int {
+	var w fr.Element
+	w.Exp(domain.GeneratorInv, new(big.Int).SetUint64(domain.Cardinality/uint64(m)))
+	wPowers := powers(w, m/2)
+	res := make([]big.Int, m/2)
+	for i := 0; i < len(res); i++ {
+		wPowers[i].ToBigIntRegular(&res[i])
+	}
+	return res
+}
+
+// bitReverse applies the bit reversal permutation to a slice of size n, through the swap closure
+func bitReverse(n int, swap func(i, j int)) {
+	nn := uint(bits.UintSize - bits.TrailingZeros(uint(n)))
+
+	for i := 0; i < n; i++ {
+		irev := int(bits.Reverse(uint(i)) >> nn)
+		if irev > i {
+			swap(i, irev)
+		}
+	}
+}
@@ -21,17 +21,56 @@ import (
 
 	curve "github.com/consensys/gurvy/bls377"
 
+	bls377backend "github.com/consensys/gnark/internal/backend/bls377"
+
 	"errors"
+	"fmt"
 	"github.com/consensys/gnark/backend"
+	"math/big"
 )
 
 var (
 	errPairingCheckFailed         = errors.New("pairing doesn't match")
 	errCorrectSubgroupCheckFailed = errors.New("points in the proof are not in the correct subgroup")
+	errBatchSizeMismatch          = errors.New("number of proofs and public inputs don't match")
+	errPublicWitnessSize          = errors.New("number of values in public witness doesn't match the number of public inputs")
 )
 
 // Verify verifies a proof
 func Verify(proof *Proof, vk *VerifyingKey, inputs map[string]interface{}) error {
+	kInputs, err := ParsePublicInput(vk.PublicInputs, inputs)
+	if err != nil {
+		return err
+	}
+	return verify(proof, vk, kInputs)
+}
+
+// VerifyWitness verifies a proof like Verify, reading the public inputs from a statically
+// typed witness (witness.Secret is ignored)
+func VerifyWitness(proof *Proof, vk *VerifyingKey, witness *bls377backend.Witness) error {
+	public := witness.Public
+	kInputs := make([]fr.Element, len(vk.PublicInputs))
+	j := 0
+	for i := 0; i < len(vk.PublicInputs); i++ {
+		if vk.PublicInputs[i] == backend.OneWire {
+			kInputs[i].SetOne()
+		} else {
+			if j >= len(public) {
+				return errPublicWitnessSize
+			}
+			kInputs[i] = public[j]
+			j++
+		}
+		kInputs[i].FromMont()
+	}
+	if j != len(public) {
+		return errPublicWitnessSize
+	}
+	return verify(proof, vk, kInputs)
+}
+
+// verify verifies a proof, kInputs being the public inputs in regular form
+func verify(proof *Proof, vk *VerifyingKey, kInputs []fr.Element) error {
 
 	// check that the points in the proof are in the correct subgroup
 	if !proof.isValid() {
@@ -53,10 +92,6 @@ func Verify(proof *Proof, vk *VerifyingKey, inputs map[string]interface{}) error
 
 	// compute e(Σx.[Kvk(t)]1, -[γ]2)
 	var kSum curve.G1Affine
-	kInputs, err := ParsePublicInput(vk.PublicInputs, inputs)
-	if err != nil {
-		return err
-	}
 	kSum.MultiExp(vk.G1.K, kInputs)
 
 	right, err := curve.MillerLoop([]curve.G1Affine{kSum}, []curve.G2Affine{vk.G2.GammaNeg})
@@ -77,6 +112,108 @@ func Verify(proof *Proof, vk *VerifyingKey, inputs map[string]interface{}) error
 	return nil
 }
 
+// BatchVerify verifies a batch of proofs generated with the same proving key
+//
+// The proofs are combined with random coefficients rᵢ and a single check is performed:
+// Πe(rᵢ.Arᵢ, Bsᵢ) . e(Σrᵢ.Krsᵢ, -[δ]2) . e(Σrᵢ.Σx.[Kvk(t)]1, -[γ]2) == e([α]1, [β]2)^Σrᵢ
+// costing len(proofs)+2 Miller loops and one final exponentiation.
+//
+// If the batched check fails, the proofs are verified one by one and the returned
+// error identifies the first invalid proof.
+func BatchVerify(proofs []*Proof, vk *VerifyingKey, inputs []map[string]interface{}) error {
+	if len(proofs) != len(inputs) {
+		return errBatchSizeMismatch
+	}
+
+	if err := batchVerify(proofs, vk, inputs); err != nil {
+		for i := 0; i < len(proofs); i++ {
+			if err := Verify(proofs[i], vk, inputs[i]); err != nil {
+				return fmt.Errorf("proof %d: %w", i, err)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func batchVerify(proofs []*Proof, vk *VerifyingKey, inputs []map[string]interface{}) error {
+	n := len(proofs)
+	if n == 0 {
+		return nil
+	}
+
+	// check that the points in the proofs are in the correct subgroup
+	for i := 0; i < n; i++ {
+		if !proofs[i].isValid() {
+			return errCorrectSubgroupCheckFailed
+		}
+	}
+
+	// sample the random coefficients rᵢ
+	r := make([]fr.Element, n)
+	var rSum fr.Element
+	for i := 0; i < n; i++ {
+		if _, err := r[i].SetRandom(); err != nil {
+			return err
+		}
+		rSum.Add(&rSum, &r[i])
+	}
+
+	// combine the public inputs first so that Σrᵢ.Σx.[Kvk(t)]1 costs a single multi exponentiation
+	kScalars := make([]fr.Element, len(vk.G1.K))
+	for i := 0; i < n; i++ {
+		kInputs, err := ParsePublicInput(vk.PublicInputs, inputs[i])
+		if err != nil {
+			return err
+		}
+		for j := 0; j < len(kInputs); j++ {
+			kInputs[j].ToMont().Mul(&kInputs[j], &r[i])
+			kScalars[j].Add(&kScalars[j], &kInputs[j])
+		}
+	}
+	for j := 0; j < len(kScalars); j++ {
+		kScalars[j].FromMont()
+	}
+	var kSum curve.G1Affine
+	kSum.MultiExp(vk.G1.K, kScalars)
+
+	// compute Σrᵢ.Krsᵢ and rᵢ.Arᵢ
+	rRegular := make([]fr.Element, n)
+	krs := make([]curve.G1Affine, n)
+	for i := 0; i < n; i++ {
+		rRegular[i] = r[i]
+		rRegular[i].FromMont()
+		krs[i] = proofs[i].Krs
+	}
+	var krsSum curve.G1Affine
+	krsSum.MultiExp(krs, rRegular)
+
+	left := make([]curve.G1Affine, n+2)
+	right := make([]curve.G2Affine, n+2)
+	var bi big.Int
+	for i := 0; i < n; i++ {
+		r[i].ToBigIntRegular(&bi)
+		left[i].ScalarMultiplication(&proofs[i].Ar, &bi)
+		right[i] = proofs[i].Bs
+	}
+	left[n], right[n] = krsSum, vk.G2.DeltaNeg
+	left[n+1], right[n+1] = kSum, vk.G2.GammaNeg
+
+	ml, err := curve.MillerLoop(left, right)
+	if err != nil {
+		return err
+	}
+
+	ml = curve.FinalExponentiation(&ml)
+
+	var expected curve.GT
+	expected.Exp(&vk.E, *rSum.ToBigIntRegular(&bi))
+	if !expected.Equal(&ml) {
+		return errPairingCheckFailed
+	}
+	return nil
+}
+
 // ParsePublicInput return the ordered public input values
 // in regular form (used as scalars for multi exponentiation).
 // The function is public because it's needed for the recursive snark.
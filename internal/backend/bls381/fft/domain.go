@@ -153,7 +153,11 @@ func precomputeExpTable(w fr.Element, table []fr.Element) {
 	n := len(table)
 
 	// see if it makes sense to parallelize exp tables pre-computation
-	interval := (n - 1) / (runtime.NumCPU() / 4)
+	// (on machines with less than 4 CPUs, we don't)
+	interval := 0
+	if nbCPU := runtime.NumCPU() / 4; nbCPU > 0 {
+		interval = (n - 1) / nbCPU
+	}
 	// this ratio roughly correspond to the number of multiplication one can do in place of a Exp operation
 	const ratioExpMul = 6000 / 17
 
@@ -0,0 +1,569 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package plonk
+
+import (
+	"github.com/consensys/gurvy/bls381/fr"
+
+	curve "github.com/consensys/gurvy/bls381"
+
+	bls381backend "github.com/consensys/gnark/internal/backend/bls381"
+
+	"github.com/consensys/gnark/internal/backend/bls381/fft"
+
+	kzg "github.com/consensys/gnark/crypto/polynomial/kzg/bls381"
+
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/consensys/gurvy"
+)
+
+// Proof PLONK proofs, consisting of opening proofs
+type Proof struct {
+	// Commitments to the solution vectors
+	LRO [3]curve.G1Affine
+
+	// Commitment to Z, the permutation polynomial
+	Z curve.G1Affine
+
+	// Commitments to h1, h2, h3 such that h = h1 + X**(n+2)*h2 + X**(2n+4)*h3 is the quotient polynomial
+	H [3]curve.G1Affine
+
+	// Claimed values at ζ of l, r, o, s1, s2, the linearization polynomial and the quotient polynomial
+	ClaimedValues [7]fr.Element
+
+	// Claimed value of Z at ζ⋅ω
+	ZShiftedEval fr.Element
+
+	// Batch opening proof of h1 + ζ**(n+2)*h2 + ζ**(2n+4)*h3, linearizedPolynomial, l, r, o, s1, s2 at ζ
+	BatchedProof curve.G1Affine
+
+	// Opening proof of Z at ζ⋅ω
+	ZShiftedProof curve.G1Affine
+}
+
+// indexes of the claimed values in the proof
+const (
+	idxL = iota
+	idxR
+	idxO
+	idxS1
+	idxS2
+	idxLinearizedPolynomial
+	idxQuotient
+)
+
+// isValid ensures proof elements are in the correct subgroup
+func (proof *Proof) isValid() bool {
+	points := []*curve.G1Affine{
+		&proof.LRO[0], &proof.LRO[1], &proof.LRO[2],
+		&proof.Z,
+		&proof.H[0], &proof.H[1], &proof.H[2],
+		&proof.BatchedProof, &proof.ZShiftedProof,
+	}
+	for _, p := range points {
+		if !p.IsInSubGroup() {
+			return false
+		}
+	}
+	return true
+}
+
+// GetCurveID returns the curveID
+func (proof *Proof) GetCurveID() gurvy.ID {
+	return curve.ID
+}
+
+// Prove from the public data
+// if force flag is set, Prove ignores the solver errors (ie invalid solution) and computes an
+// (invalid) Proof object
+func Prove(spr *bls381backend.SparseR1CS, pk *ProvingKey, solution map[string]interface{}, force bool) (*Proof, error) {
+
+	n := int(pk.DomainNum.Cardinality)
+	nbPublic := int(spr.NbPublicWires)
+	offset := int(spr.NbWires) - nbPublic // public input start index
+
+	// solve the sparse R1CS
+	wireValues := make([]fr.Element, spr.NbWires)
+	if err := spr.Solve(solution, wireValues); err != nil && !force {
+		return nil, err
+	}
+
+	// evaluations of l, r, o on the domain
+	wires := wireIDs(spr, n)
+	evalL := make([]fr.Element, n)
+	evalR := make([]fr.Element, n)
+	evalO := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		evalL[i].Set(&wireValues[wires[i]])
+		evalR[i].Set(&wireValues[wires[n+i]])
+		evalO[i].Set(&wireValues[wires[2*n+i]])
+	}
+
+	// the public inputs polynomial PI, such that the first rows are xa + PI == 0
+	pi := make([]fr.Element, n)
+	for i := 0; i < nbPublic; i++ {
+		pi[i].Neg(&wireValues[offset+i])
+	}
+	toCanonical(pi, &pk.DomainNum)
+
+	// l, r, o in canonical basis, blinded
+	bl, err := blind(lagrangeToCanonical(evalL, &pk.DomainNum), 1)
+	if err != nil {
+		return nil, err
+	}
+	br, err := blind(lagrangeToCanonical(evalR, &pk.DomainNum), 1)
+	if err != nil {
+		return nil, err
+	}
+	bo, err := blind(lagrangeToCanonical(evalO, &pk.DomainNum), 1)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &Proof{}
+	if err := commit([]kzg.Polynomial{bl, br, bo}, pk.SRS, &proof.LRO[0], &proof.LRO[1], &proof.LRO[2]); err != nil {
+		return nil, err
+	}
+
+	// derive β, γ
+	fs := newTranscript(pk.Vk, wireValues[offset:])
+	fs.bind(proof.LRO[:]...)
+	beta := fs.challenge()
+	gamma := fs.challenge()
+
+	// compute Z, the permutation accumulator, and blind it
+	z, err := blind(computeZ(evalL, evalR, evalO, pk, beta, gamma), 2)
+	if err != nil {
+		return nil, err
+	}
+	if err := commit([]kzg.Polynomial{z}, pk.SRS, &proof.Z); err != nil {
+		return nil, err
+	}
+
+	// derive α
+	fs.bind(proof.Z)
+	alpha := fs.challenge()
+
+	// compute the quotient h = h1 + X**(n+2)*h2 + X**(2n+4)*h3, and commit to h1, h2, h3
+	h := computeH(pk, bl, br, bo, z, pi, alpha, beta, gamma)
+	h1 := h[:n+2]
+	h2 := h[n+2 : 2*(n+2)]
+	h3 := h[2*(n+2) : 3*(n+2)]
+	if err := commit([]kzg.Polynomial{h1, h2, h3}, pk.SRS, &proof.H[0], &proof.H[1], &proof.H[2]); err != nil {
+		return nil, err
+	}
+
+	// derive ζ
+	fs.bind(proof.H[:]...)
+	zeta := fs.challenge()
+
+	// claimed values at ζ
+	var zetaShifted fr.Element
+	zetaShifted.Mul(&zeta, &pk.DomainNum.Generator)
+
+	claimed := &proof.ClaimedValues
+	claimed[idxL] = kzg.Polynomial(bl).Eval(&zeta)
+	claimed[idxR] = kzg.Polynomial(br).Eval(&zeta)
+	claimed[idxO] = kzg.Polynomial(bo).Eval(&zeta)
+	claimed[idxS1] = kzg.Polynomial(pk.S1Canonical).Eval(&zeta)
+	claimed[idxS2] = kzg.Polynomial(pk.S2Canonical).Eval(&zeta)
+	proof.ZShiftedEval = kzg.Polynomial(z).Eval(&zetaShifted)
+
+	linearizedPolynomial := computeLinearizedPolynomial(pk, claimed, proof.ZShiftedEval, alpha, beta, gamma, zeta, z)
+	claimed[idxLinearizedPolynomial] = kzg.Polynomial(linearizedPolynomial).Eval(&zeta)
+
+	// h1 + ζ**(n+2)*h2 + ζ**(2n+4)*h3
+	var zetaNPlusTwo fr.Element
+	zetaNPlusTwo.Exp(zeta, new(big.Int).SetUint64(uint64(n+2)))
+	foldedH := make([]fr.Element, n+2)
+	copy(foldedH, h3)
+	for i := 0; i < n+2; i++ {
+		foldedH[i].Mul(&foldedH[i], &zetaNPlusTwo).Add(&foldedH[i], &h2[i])
+		foldedH[i].Mul(&foldedH[i], &zetaNPlusTwo).Add(&foldedH[i], &h1[i])
+	}
+	claimed[idxQuotient] = kzg.Polynomial(foldedH).Eval(&zeta)
+
+	// derive v
+	fs.bindElements(claimed[:]...)
+	fs.bindElements(proof.ZShiftedEval)
+	v := fs.challenge()
+
+	// batch opening of foldedH + v*linearizedPolynomial + v**2*l + v**3*r + v**4*o + v**5*s1 + v**6*s2 at ζ
+	polynomials := [][]fr.Element{
+		foldedH,
+		linearizedPolynomial,
+		bl, br, bo,
+		pk.S1Canonical, pk.S2Canonical,
+	}
+	batched := make([]fr.Element, len(linearizedPolynomial))
+	var vPow fr.Element
+	vPow.SetOne()
+	for _, p := range polynomials {
+		var t fr.Element
+		for i := 0; i < len(p); i++ {
+			t.Mul(&p[i], &vPow)
+			batched[i].Add(&batched[i], &t)
+		}
+		vPow.Mul(&vPow, &v)
+	}
+	batchedProof, err := kzg.Open(batched, &zeta, pk.SRS)
+	if err != nil {
+		return nil, err
+	}
+	proof.BatchedProof = batchedProof.H
+
+	// opening of z at ζ⋅ω
+	zShiftedProof, err := kzg.Open(z, &zetaShifted, pk.SRS)
+	if err != nil {
+		return nil, err
+	}
+	proof.ZShiftedProof = zShiftedProof.H
+
+	return proof, nil
+}
+
+// computeZ computes Z (in canonical basis), the permutation accumulator:
+// Z(1) = 1, Z(ω**(i+1)) = Z(ω**i)⋅Π_k (f_k(ω**i)+β⋅id_k(ω**i)+γ) / Π_k (f_k(ω**i)+β⋅σ_k(ω**i)+γ)
+// where f_0, f_1, f_2 are l, r, o and id_k(X) = u_k⋅X, with u_0 = 1, u_1, u_2 = pk.Vk.Shifter
+func computeZ(l, r, o []fr.Element, pk *ProvingKey, beta, gamma fr.Element) []fr.Element {
+	n := int(pk.DomainNum.Cardinality)
+
+	num := make([]fr.Element, n)
+	den := make([]fr.Element, n)
+
+	var x, t fr.Element
+	x.SetOne()
+	for i := 0; i < n; i++ {
+
+		// (l + β⋅X + γ)⋅(r + β⋅u_1⋅X + γ)⋅(o + β⋅u_2⋅X + γ)
+		t.Mul(&beta, &x).Add(&t, &gamma).Add(&t, &l[i])
+		num[i].Set(&t)
+		t.Mul(&beta, &x).Mul(&t, &pk.Vk.Shifter[0]).Add(&t, &gamma).Add(&t, &r[i])
+		num[i].Mul(&num[i], &t)
+		t.Mul(&beta, &x).Mul(&t, &pk.Vk.Shifter[1]).Add(&t, &gamma).Add(&t, &o[i])
+		num[i].Mul(&num[i], &t)
+
+		// (l + β⋅s1 + γ)⋅(r + β⋅s2 + γ)⋅(o + β⋅s3 + γ)
+		t.Mul(&beta, &pk.LS1[i]).Add(&t, &gamma).Add(&t, &l[i])
+		den[i].Set(&t)
+		t.Mul(&beta, &pk.LS2[i]).Add(&t, &gamma).Add(&t, &r[i])
+		den[i].Mul(&den[i], &t)
+		t.Mul(&beta, &pk.LS3[i]).Add(&t, &gamma).Add(&t, &o[i])
+		den[i].Mul(&den[i], &t)
+
+		x.Mul(&x, &pk.DomainNum.Generator)
+	}
+
+	den = batchInvert(den)
+
+	z := make([]fr.Element, n)
+	z[0].SetOne()
+	for i := 0; i < n-1; i++ {
+		z[i+1].Mul(&z[i], &num[i]).Mul(&z[i+1], &den[i])
+	}
+
+	toCanonical(z, &pk.DomainNum)
+	return z
+}
+
+// computeH computes h in canonical basis, where
+// h⋅Z_H = qL⋅l + qR⋅r + qM⋅l⋅r + qO⋅o + qK + PI +
+//
+//	α⋅(Z(X)⋅(l+β⋅X+γ)⋅(r+β⋅u_1⋅X+γ)⋅(o+β⋅u_2⋅X+γ) - Z(ω⋅X)⋅(l+β⋅s1+γ)⋅(r+β⋅s2+γ)⋅(o+β⋅s3+γ)) +
+//	α**2⋅(Z-1)⋅L1
+//
+// the numerator is evaluated on a coset of DomainH, then divided by Z_H and interpolated back
+func computeH(pk *ProvingKey, l, r, o, z, pi []fr.Element, alpha, beta, gamma fr.Element) []fr.Element {
+	n := int(pk.DomainNum.Cardinality)
+	domainH := &pk.DomainH
+	nn := int(domainH.Cardinality)
+	ratio := nn / n
+
+	// Z(ω⋅X)
+	zShifted := make([]fr.Element, len(z))
+	var acc fr.Element
+	acc.SetOne()
+	for i := 0; i < len(z); i++ {
+		zShifted[i].Mul(&z[i], &acc)
+		acc.Mul(&acc, &pk.DomainNum.Generator)
+	}
+
+	// L1 = (1/n)⋅(X**n-1)/(X-1) = (1/n)⋅(1 + X + ... + X**(n-1))
+	lOne := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		lOne[i].Set(&pk.DomainNum.CardinalityInv)
+	}
+
+	polynomials := [][]fr.Element{
+		pk.Ql, pk.Qr, pk.Qm, pk.Qo, pk.Qk,
+		pk.S1Canonical, pk.S2Canonical, pk.S3Canonical,
+		l, r, o, z, zShifted, pi, lOne,
+	}
+	evaluations := make([][]fr.Element, len(polynomials))
+	for i, p := range polynomials {
+		evaluations[i] = evaluateOnCoset(p, domainH)
+	}
+	ql, qr, qm, qo, qk := evaluations[0], evaluations[1], evaluations[2], evaluations[3], evaluations[4]
+	s1, s2, s3 := evaluations[5], evaluations[6], evaluations[7]
+	el, er, eo, ez, ezShifted, epi, elOne := evaluations[8], evaluations[9], evaluations[10], evaluations[11], evaluations[12], evaluations[13], evaluations[14]
+
+	// Z_H = X**n - 1 takes ratio values on the coset g⋅<ω_H>: (g⋅ω_H**i)**n - 1
+	zh := make([]fr.Element, ratio)
+	var gn, wn fr.Element
+	bn := new(big.Int).SetUint64(uint64(n))
+	gn.Exp(domainH.GeneratorSqRt, bn)
+	wn.Exp(domainH.Generator, bn)
+	zh[0].Set(&gn)
+	for i := 1; i < ratio; i++ {
+		zh[i].Mul(&zh[i-1], &wn)
+	}
+	var one fr.Element
+	one.SetOne()
+	for i := 0; i < ratio; i++ {
+		zh[i].Sub(&zh[i], &one)
+	}
+	zh = batchInvert(zh)
+
+	var alphaSquare fr.Element
+	alphaSquare.Square(&alpha)
+
+	h := make([]fr.Element, nn)
+	var x fr.Element
+	x.Set(&domainH.GeneratorSqRt)
+	for i := 0; i < nn; i++ {
+		var gate, perm, t, u fr.Element
+
+		// qL⋅l + qR⋅r + qM⋅l⋅r + qO⋅o + qK + PI
+		gate.Mul(&el[i], &er[i]).Mul(&gate, &qm[i])
+		t.Mul(&ql[i], &el[i])
+		gate.Add(&gate, &t)
+		t.Mul(&qr[i], &er[i])
+		gate.Add(&gate, &t)
+		t.Mul(&qo[i], &eo[i])
+		gate.Add(&gate, &t).Add(&gate, &qk[i]).Add(&gate, &epi[i])
+
+		// Z(X)⋅(l+β⋅X+γ)⋅(r+β⋅u_1⋅X+γ)⋅(o+β⋅u_2⋅X+γ)
+		u.Mul(&beta, &x)
+		perm.Add(&el[i], &u).Add(&perm, &gamma).Mul(&perm, &ez[i])
+		t.Mul(&u, &pk.Vk.Shifter[0]).Add(&t, &er[i]).Add(&t, &gamma)
+		perm.Mul(&perm, &t)
+		t.Mul(&u, &pk.Vk.Shifter[1]).Add(&t, &eo[i]).Add(&t, &gamma)
+		perm.Mul(&perm, &t)
+
+		// - Z(ω⋅X)⋅(l+β⋅s1+γ)⋅(r+β⋅s2+γ)⋅(o+β⋅s3+γ)
+		t.Mul(&beta, &s1[i]).Add(&t, &el[i]).Add(&t, &gamma)
+		u.Mul(&t, &ezShifted[i])
+		t.Mul(&beta, &s2[i]).Add(&t, &er[i]).Add(&t, &gamma)
+		u.Mul(&u, &t)
+		t.Mul(&beta, &s3[i]).Add(&t, &eo[i]).Add(&t, &gamma)
+		u.Mul(&u, &t)
+		perm.Sub(&perm, &u).Mul(&perm, &alpha)
+
+		// α**2⋅(Z-1)⋅L1
+		t.Sub(&ez[i], &one).Mul(&t, &elOne[i]).Mul(&t, &alphaSquare)
+
+		h[i].Add(&gate, &perm).Add(&h[i], &t).Mul(&h[i], &zh[i%ratio])
+
+		x.Mul(&x, &domainH.Generator)
+	}
+
+	// interpolate h back on the coset
+	domainH.FFTInverse(h, fft.DIF)
+	for i := 0; i < nn; i++ {
+		h[i].Mul(&h[i], &domainH.CosetTableInv[i])
+	}
+	fft.BitReverse(h)
+
+	return h
+}
+
+// computeLinearizedPolynomial computes the linearized polynomial in canonical basis
+// r = l(ζ)⋅r(ζ)⋅qM + l(ζ)⋅qL + r(ζ)⋅qR + o(ζ)⋅qO + qK +
+//
+//	(α⋅(l(ζ)+β⋅ζ+γ)⋅(r(ζ)+β⋅u_1⋅ζ+γ)⋅(o(ζ)+β⋅u_2⋅ζ+γ) + α**2⋅L1(ζ))⋅Z -
+//	α⋅(l(ζ)+β⋅s1(ζ)+γ)⋅(r(ζ)+β⋅s2(ζ)+γ)⋅β⋅Z(ω⋅ζ)⋅s3
+func computeLinearizedPolynomial(pk *ProvingKey, claimed *[7]fr.Element, zShiftedEval, alpha, beta, gamma, zeta fr.Element, z []fr.Element) []fr.Element {
+	coeffZ, coeffS3 := linearizationCoefficients(pk.Vk, claimed, zShiftedEval, alpha, beta, gamma, zeta)
+
+	var lr fr.Element
+	lr.Mul(&claimed[idxL], &claimed[idxR])
+
+	res := make([]fr.Element, len(z))
+	var t fr.Element
+	for i := 0; i < len(pk.Ql); i++ {
+		res[i].Mul(&pk.Qm[i], &lr)
+		t.Mul(&pk.Ql[i], &claimed[idxL])
+		res[i].Add(&res[i], &t)
+		t.Mul(&pk.Qr[i], &claimed[idxR])
+		res[i].Add(&res[i], &t)
+		t.Mul(&pk.Qo[i], &claimed[idxO])
+		res[i].Add(&res[i], &t).Add(&res[i], &pk.Qk[i])
+		t.Mul(&pk.S3Canonical[i], &coeffS3)
+		res[i].Sub(&res[i], &t)
+	}
+	for i := 0; i < len(z); i++ {
+		t.Mul(&z[i], &coeffZ)
+		res[i].Add(&res[i], &t)
+	}
+
+	return res
+}
+
+// linearizationCoefficients returns the coefficients of Z and s3 in the linearized polynomial
+func linearizationCoefficients(vk *VerifyingKey, claimed *[7]fr.Element, zShiftedEval, alpha, beta, gamma, zeta fr.Element) (coeffZ, coeffS3 fr.Element) {
+	var t, u fr.Element
+
+	// α⋅(l(ζ)+β⋅ζ+γ)⋅(r(ζ)+β⋅u_1⋅ζ+γ)⋅(o(ζ)+β⋅u_2⋅ζ+γ) + α**2⋅L1(ζ)
+	u.Mul(&beta, &zeta)
+	coeffZ.Add(&claimed[idxL], &u).Add(&coeffZ, &gamma)
+	t.Mul(&u, &vk.Shifter[0]).Add(&t, &claimed[idxR]).Add(&t, &gamma)
+	coeffZ.Mul(&coeffZ, &t)
+	t.Mul(&u, &vk.Shifter[1]).Add(&t, &claimed[idxO]).Add(&t, &gamma)
+	coeffZ.Mul(&coeffZ, &t).Mul(&coeffZ, &alpha)
+	lOne := evalLagrange(vk, 0, zeta)
+	t.Square(&alpha).Mul(&t, &lOne)
+	coeffZ.Add(&coeffZ, &t)
+
+	// α⋅(l(ζ)+β⋅s1(ζ)+γ)⋅(r(ζ)+β⋅s2(ζ)+γ)⋅β⋅Z(ω⋅ζ)
+	coeffS3.Mul(&beta, &claimed[idxS1]).Add(&coeffS3, &claimed[idxL]).Add(&coeffS3, &gamma)
+	t.Mul(&beta, &claimed[idxS2]).Add(&t, &claimed[idxR]).Add(&t, &gamma)
+	coeffS3.Mul(&coeffS3, &t).Mul(&coeffS3, &beta).Mul(&coeffS3, &zShiftedEval).Mul(&coeffS3, &alpha)
+
+	return
+}
+
+// evalLagrange returns L_i(ζ) = ω**i⋅(ζ**n-1) / (n⋅(ζ-ω**i)), the i-th Lagrange polynomial
+// of the domain evaluated at ζ
+func evalLagrange(vk *VerifyingKey, i int, zeta fr.Element) fr.Element {
+	var wi, num, den fr.Element
+	wi.Exp(vk.Generator, new(big.Int).SetUint64(uint64(i)))
+
+	var one fr.Element
+	one.SetOne()
+	num.Exp(zeta, new(big.Int).SetUint64(vk.Size)).Sub(&num, &one).Mul(&num, &wi).Mul(&num, &vk.SizeInv)
+	den.Sub(&zeta, &wi)
+
+	return *num.Div(&num, &den)
+}
+
+// blind returns p + (b_0 + b_1⋅X + .. + b_{k-1}⋅X**(k-1))⋅(X**n-1), with b_i random and n = len(p)
+// p is in canonical basis
+func blind(p []fr.Element, k int) ([]fr.Element, error) {
+	n := len(p)
+	res := make([]fr.Element, n+k)
+	copy(res, p)
+	for i := 0; i < k; i++ {
+		if _, err := res[n+i].SetRandom(); err != nil {
+			return nil, err
+		}
+		res[i].Sub(&res[i], &res[n+i])
+	}
+	return res, nil
+}
+
+// lagrangeToCanonical returns a copy of p (Lagrange basis on domain) in canonical basis
+func lagrangeToCanonical(p []fr.Element, domain *fft.Domain) []fr.Element {
+	res := make([]fr.Element, len(p))
+	copy(res, p)
+	toCanonical(res, domain)
+	return res
+}
+
+// evaluateOnCoset returns the evaluations of p (canonical basis) on the coset g⋅<ω>,
+// where ω generates domain and g = domain.GeneratorSqRt
+func evaluateOnCoset(p []fr.Element, domain *fft.Domain) []fr.Element {
+	res := make([]fr.Element, domain.Cardinality)
+	copy(res, p)
+	fft.BitReverse(res)
+	for i := 0; i < len(res); i++ {
+		res[i].Mul(&res[i], &domain.CosetTable[i])
+	}
+	domain.FFT(res, fft.DIT)
+	return res
+}
+
+// batchInvert returns the inverses of a, computed with a single inversion
+// (zeroes are mapped to zeroes)
+func batchInvert(a []fr.Element) []fr.Element {
+	res := make([]fr.Element, len(a))
+
+	var acc fr.Element
+	acc.SetOne()
+	for i := 0; i < len(a); i++ {
+		res[i].Set(&acc)
+		if !a[i].IsZero() {
+			acc.Mul(&acc, &a[i])
+		}
+	}
+
+	acc.Inverse(&acc)
+	for i := len(a) - 1; i >= 0; i-- {
+		if a[i].IsZero() {
+			res[i].SetZero()
+			continue
+		}
+		res[i].Mul(&res[i], &acc)
+		acc.Mul(&acc, &a[i])
+	}
+
+	return res
+}
+
+// transcript derives the challenges of the protocol (Fiat Shamir), by hashing
+// the previous challenge and the data bound since then
+type transcript struct {
+	state []byte
+	data  []byte
+}
+
+// newTranscript returns a transcript bound to the verifying key and the public inputs
+func newTranscript(vk *VerifyingKey, publicInputs []fr.Element) *transcript {
+	fs := &transcript{}
+	fs.bind(vk.Ql, vk.Qr, vk.Qm, vk.Qo, vk.Qk)
+	fs.bind(vk.S[:]...)
+	fs.bindElements(publicInputs...)
+	return fs
+}
+
+func (fs *transcript) bind(points ...curve.G1Affine) {
+	for i := 0; i < len(points); i++ {
+		b := points[i].Bytes()
+		fs.data = append(fs.data, b[:]...)
+	}
+}
+
+func (fs *transcript) bindElements(elements ...fr.Element) {
+	for i := 0; i < len(elements); i++ {
+		b := elements[i].Bytes()
+		fs.data = append(fs.data, b[:]...)
+	}
+}
+
+// challenge returns hash(state || data) as a field element, and resets the bound data
+func (fs *transcript) challenge() fr.Element {
+	h := sha256.New()
+	h.Write(fs.state)
+	h.Write(fs.data)
+	fs.state = h.Sum(nil)
+	fs.data = fs.data[:0]
+
+	var res fr.Element
+	res.SetBytes(fs.state)
+	return res
+}
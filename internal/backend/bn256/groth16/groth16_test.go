@@ -25,6 +25,8 @@ import (
 
 	"bytes"
 	"github.com/fxamacker/cbor/v2"
+	"reflect"
+	"strings"
 	"testing"
 
 	bn256groth16 "github.com/consensys/gnark/internal/backend/bn256/groth16"
@@ -84,6 +86,156 @@ func TestParsePublicInput(t *testing.T) {
 
 }
 
+func TestBatchVerify(t *testing.T) {
+	const nbConstraints = 3
+	circuit := refCircuit{
+		nbConstraints: nbConstraints,
+	}
+	r1cs, err := frontend.Compile(curve.ID, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, vk, err := groth16.Setup(r1cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const nbProofs = 4
+	proofs := make([]*bn256groth16.Proof, nbProofs)
+	inputs := make([]map[string]interface{}, nbProofs)
+	for i := 0; i < nbProofs; i++ {
+		var x, y fr.Element
+		x.SetUint64(uint64(i + 2))
+		y.Set(&x)
+		for j := 0; j < nbConstraints; j++ {
+			y.Square(&y)
+		}
+		inputs[i] = map[string]interface{}{"X": x, "Y": y}
+		proof, err := groth16.Prove(r1cs, pk, inputs[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		proofs[i] = proof.(*bn256groth16.Proof)
+	}
+
+	_vk := vk.(*bn256groth16.VerifyingKey)
+	if err := bn256groth16.BatchVerify(proofs, _vk, inputs); err != nil {
+		t.Fatal(err)
+	}
+
+	// a bad public input must be detected and attributed to the right proof
+	inputs[2]["Y"] = inputs[1]["Y"]
+	err = bn256groth16.BatchVerify(proofs, _vk, inputs)
+	if err == nil {
+		t.Fatal("batch verifying a proof with a bad public input should fail")
+	}
+	if !strings.HasPrefix(err.Error(), "proof 2:") {
+		t.Fatal("unexpected error", err)
+	}
+
+	if err := bn256groth16.BatchVerify(proofs, _vk, inputs[:2]); err == nil {
+		t.Fatal("batch verifying with mismatching number of public inputs should fail")
+	}
+}
+
+func TestProveWitness(t *testing.T) {
+	const nbConstraints = 3
+	circuit := refCircuit{
+		nbConstraints: nbConstraints,
+	}
+	_r1cs, err := frontend.Compile(curve.ID, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r1cs := _r1cs.(*bn256backend.R1CS)
+	var pk bn256groth16.ProvingKey
+	var vk bn256groth16.VerifyingKey
+	if err := bn256groth16.Setup(r1cs, &pk, &vk); err != nil {
+		t.Fatal(err)
+	}
+
+	var x, y fr.Element
+	x.SetUint64(2)
+	y.Set(&x)
+	for i := 0; i < nbConstraints; i++ {
+		y.Square(&y)
+	}
+
+	// statically typed witness, built from the assigned circuit
+	var assignment refCircuit
+	assignment.X.Assign(x)
+	assignment.Y.Assign(y)
+	_witness, err := frontend.NewWitness(curve.ID, &assignment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	witness := _witness.(*bn256backend.Witness)
+	if len(witness.Public) != 1 || !witness.Public[0].Equal(&y) || len(witness.Secret) != 1 || !witness.Secret[0].Equal(&x) {
+		t.Fatal("witness values are not ordered like the R1CS wires")
+	}
+
+	// binary witness round trip
+	var buf bytes.Buffer
+	written, err := r1cs.WriteWitness(&buf, map[string]interface{}{"X": x, "Y": y}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var read bn256backend.Witness
+	n, err := read.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != n {
+		t.Fatal("number of bytes read and written don't match")
+	}
+	if !reflect.DeepEqual(witness, &read) {
+		t.Fatal("binary witness round trip failed")
+	}
+
+	// a header announcing 2^32-1 values isn't trusted, the values must be read
+	header := []byte{0, byte(curve.ID), 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0}
+	if _, err := read.ReadFrom(bytes.NewReader(header)); err == nil {
+		t.Fatal("reading a truncated witness should fail")
+	}
+
+	// a value larger than the modulus is not a canonical encoding
+	buf.Reset()
+	if _, err := witness.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	encoded := buf.Bytes()
+	fr.Modulus().FillBytes(encoded[10 : 10+fr.Limbs*8])
+	if _, err := read.ReadFrom(bytes.NewReader(encoded)); err == nil {
+		t.Fatal("reading a non canonical witness value should fail")
+	}
+
+	proof, err := bn256groth16.ProveWitness(r1cs, &pk, witness, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bn256groth16.VerifyWitness(proof, &vk, witness); err != nil {
+		t.Fatal(err)
+	}
+	bad := bn256backend.Witness{Public: []fr.Element{x}}
+	if err := bn256groth16.VerifyWitness(proof, &vk, &bad); err == nil {
+		t.Fatal("verifying a proof with a bad public witness should fail")
+	}
+	bad.Public = nil
+	if err := bn256groth16.VerifyWitness(proof, &vk, &bad); err == nil {
+		t.Fatal("verifying a proof with a public witness of wrong size should fail")
+	}
+	bad.Public = witness.Public
+	if _, err := bn256groth16.ProveWitness(r1cs, &pk, &bad, false); err == nil {
+		t.Fatal("proving with a witness of wrong size should fail")
+	}
+
+	var missing refCircuit
+	missing.Y.Assign(y)
+	if _, err := frontend.NewWitness(curve.ID, &missing); err == nil {
+		t.Fatal("building a witness from a partially assigned circuit should fail")
+	}
+}
+
 //--------------------//
 //     benches		  //
 //--------------------//
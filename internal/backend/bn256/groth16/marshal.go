@@ -20,10 +20,20 @@ import (
 	curve "github.com/consensys/gurvy/bn256"
 
 	"encoding/binary"
+	"errors"
 	"github.com/fxamacker/cbor/v2"
 	"io"
 )
 
+// the encoding of a VerifyingKey starts with vkVersionFlag|vkVersion (8 bytes, big endian)
+//
+// version 1 adds [α]1 and [β]2, needed by ExportSolidity. The keys of version 0 don't have a
+// version prefix, they are still read, without [α]1 and [β]2.
+const (
+	vkVersionFlag uint64 = 1 << 63
+	vkVersion     uint64 = 1
+)
+
 // WriteTo writes binary encoding of the Proof elements to writer
 // points are stored in compressed form Ar | Krs | Bs
 // use WriteRawTo(...) to encode the proof without point compression
@@ -95,6 +105,13 @@ func (vk *VerifyingKey) WriteRawTo(w io.Writer) (n int64, err error) {
 func (vk *VerifyingKey) writeTo(w io.Writer, raw bool) (n int64, err error) {
 	var written int
 
+	// write the version of the encoding
+	err = binary.Write(w, binary.BigEndian, vkVersionFlag|vkVersion)
+	if err != nil {
+		return
+	}
+	n += 8
+
 	// encode public input names
 	var pBytes []byte
 	pBytes, err = cbor.Marshal(vk.PublicInputs)
@@ -127,6 +144,18 @@ func (vk *VerifyingKey) writeTo(w io.Writer, raw bool) (n int64, err error) {
 		enc = curve.NewEncoder(w)
 	}
 
+	err = enc.Encode(&vk.G1.Alpha)
+	n += enc.BytesWritten()
+	if err != nil {
+		return
+	}
+
+	err = enc.Encode(&vk.G2.Beta)
+	n += enc.BytesWritten()
+	if err != nil {
+		return
+	}
+
 	err = enc.Encode(&vk.G2.GammaNeg)
 	n += enc.BytesWritten()
 	if err != nil {
@@ -160,6 +189,23 @@ func (vk *VerifyingKey) ReadFrom(r io.Reader) (n int64, err error) {
 	}
 	lPublicInputs := binary.BigEndian.Uint64(buf[:8])
 
+	// the keys encoded before the versioning of the encoding (version 0) start with the length
+	// of the public inputs, whose most significant bit is not set
+	var version uint64
+	if lPublicInputs&vkVersionFlag != 0 {
+		version = lPublicInputs &^ vkVersionFlag
+		if version > vkVersion {
+			err = errors.New("unsupported verifying key encoding version")
+			return
+		}
+		read, err = io.ReadFull(r, buf[:8])
+		n += int64(read)
+		if err != nil {
+			return
+		}
+		lPublicInputs = binary.BigEndian.Uint64(buf[:8])
+	}
+
 	bPublicInputs := make([]byte, lPublicInputs)
 	read, err = io.ReadFull(r, bPublicInputs)
 	n += int64(read)
@@ -185,6 +231,20 @@ func (vk *VerifyingKey) ReadFrom(r io.Reader) (n int64, err error) {
 
 	dec := curve.NewDecoder(r)
 
+	if version >= 1 {
+		err = dec.Decode(&vk.G1.Alpha)
+		n += dec.BytesRead()
+		if err != nil {
+			return
+		}
+
+		err = dec.Decode(&vk.G2.Beta)
+		n += dec.BytesRead()
+		if err != nil {
+			return
+		}
+	}
+
 	err = dec.Decode(&vk.G2.GammaNeg)
 	n += dec.BytesRead()
 	if err != nil {
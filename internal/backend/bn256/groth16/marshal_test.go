@@ -20,11 +20,13 @@ import (
 	curve "github.com/consensys/gurvy/bn256"
 
 	"bytes"
+	"encoding/binary"
 	"math/big"
 	"reflect"
 
 	"github.com/consensys/gnark/internal/backend/bn256/fft"
 
+	"github.com/fxamacker/cbor/v2"
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
 	"github.com/leanovate/gopter/prop"
@@ -101,6 +103,8 @@ func TestVerifyingKeySerialization(t *testing.T) {
 			nbWires := 6
 
 			vk.E.SetRandom()
+			vk.G1.Alpha = p1
+			vk.G2.Beta = p2
 			vk.G2.GammaNeg = p2
 			vk.G2.DeltaNeg = p2
 
@@ -160,6 +164,56 @@ func TestVerifyingKeySerialization(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestVerifyingKeyVersion0(t *testing.T) {
+	_, _, g1, g2 := curve.Generators()
+
+	var vk VerifyingKey
+	vk.E.SetRandom()
+	vk.G2.GammaNeg = g2
+	vk.G2.DeltaNeg.Neg(&g2)
+	vk.G1.K = []curve.G1Affine{g1, g1}
+	vk.PublicInputs = []string{"ONE_WIRE", "X"}
+
+	// encoding before the versioning: len(public inputs) | public inputs | E | -[γ]2 | -[δ]2 | [Kvk]1
+	var buf bytes.Buffer
+	pBytes, err := cbor.Marshal(vk.PublicInputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := binary.Write(&buf, binary.BigEndian, uint64(len(pBytes))); err != nil {
+		t.Fatal(err)
+	}
+	buf.Write(pBytes)
+	e := vk.E.Bytes()
+	buf.Write(e[:])
+	enc := curve.NewEncoder(&buf)
+	for _, v := range []interface{}{&vk.G2.GammaNeg, &vk.G2.DeltaNeg, vk.G1.K} {
+		if err := enc.Encode(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var vkRead VerifyingKey
+	if _, err := vkRead.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&vk, &vkRead) {
+		t.Fatal("a verifying key of version 0 should be read without [α]1 and [β]2")
+	}
+	if err := vkRead.ExportSolidity(&buf); err == nil {
+		t.Fatal("a verifying key without [α]1 and [β]2 can't be exported")
+	}
+
+	// unknown version
+	buf.Reset()
+	if err := binary.Write(&buf, binary.BigEndian, vkVersionFlag|(vkVersion+1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vkRead.ReadFrom(&buf); err == nil {
+		t.Fatal("reading a verifying key of an unknown version should fail")
+	}
+}
+
 func TestProvingKeySerialization(t *testing.T) {
 	parameters := gopter.DefaultTestParameters()
 	parameters.MinSuccessfulTests = 10
@@ -60,16 +60,19 @@ type VerifyingKey struct {
 	// e(α, β)
 	E curve.GT
 
-	// -[γ]2, -[δ]2
+	// [β]2, -[γ]2, -[δ]2
 	// note: storing GammaNeg and DeltaNeg instead of Gamma and Delta
 	// see proof.Verify() for more details
 	G2 struct {
-		GammaNeg, DeltaNeg curve.G2Affine
+		Beta, GammaNeg, DeltaNeg curve.G2Affine
 	}
 
-	// [Kvk]1
+	// [α]1, [Kvk]1
+	// note: [α]1 and [β]2 are not needed by Verify (which uses E) but by verifiers
+	// that can't store E, such as the exported Solidity contract
 	G1 struct {
-		K []curve.G1Affine // The indexes correspond to the public wires
+		Alpha curve.G1Affine
+		K     []curve.G1Affine // The indexes correspond to the public wires
 	}
 }
 
@@ -223,7 +226,9 @@ func Setup(r1cs *bn256backend.R1CS, pk *ProvingKey, vk *VerifyingKey) error {
 	pk.G2.Beta = g2PointsAff[nbWires+0]
 	pk.G2.Delta = g2PointsAff[nbWires+1]
 
-	// sets vk: -[δ]2, -[γ]2
+	// sets vk: [α]1, [β]2, -[δ]2, -[γ]2
+	vk.G1.Alpha = pk.G1.Alpha
+	vk.G2.Beta = pk.G2.Beta
 	vk.G2.DeltaNeg = g2PointsAff[nbWires+1]
 	vk.G2.GammaNeg = g2PointsAff[nbWires+2]
 	vk.G2.DeltaNeg.Neg(&vk.G2.DeltaNeg)
@@ -0,0 +1,246 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package mpcsetup
+
+import (
+	"github.com/consensys/gurvy/bw761/fr"
+
+	curve "github.com/consensys/gurvy/bw761"
+
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// Phase1 represents the Phase1 of the MPC described in
+// https://eprint.iacr.org/2017/1050.pdf
+//
+// Also known as "Powers of Tau"
+type Phase1 struct {
+	Parameters struct {
+		G1 struct {
+			Tau      []curve.G1Affine // {[τ⁰]₁, [τ¹]₁, [τ²]₁, …, [τ²ⁿ⁻¹]₁}
+			AlphaTau []curve.G1Affine // {α[τ⁰]₁, α[τ¹]₁, α[τ²]₁, …, α[τⁿ⁻¹]₁}
+			BetaTau  []curve.G1Affine // {β[τ⁰]₁, β[τ¹]₁, β[τ²]₁, …, β[τⁿ⁻¹]₁}
+		}
+		G2 struct {
+			Tau  []curve.G2Affine // {[τ⁰]₂, [τ¹]₂, [τ²]₂, …, [τⁿ⁻¹]₂}
+			Beta curve.G2Affine   // [β]₂
+		}
+	}
+	PublicKeys struct {
+		Tau, Alpha, Beta PublicKey
+	}
+	Hash []byte // sha256 hash
+}
+
+// domain separation tags of the proofs of knowledge of τ, α, β
+const (
+	dstTau byte = iota + 1
+	dstAlpha
+	dstBeta
+)
+
+// InitPhase1 initialize phase 1 of the MPC. This is called once by the coordinator before
+// any randomness contribution is made (see Contribute()).
+//
+// the resulting parameters support circuits up to 2**power constraints
+func InitPhase1(power int) (phase1 Phase1) {
+	N := int(1 << power)
+
+	// Generate key pairs
+	var tau, alpha, beta fr.Element
+	tau.SetOne()
+	alpha.SetOne()
+	beta.SetOne()
+	phase1.PublicKeys.Tau = newPublicKey(tau, nil, dstTau)
+	phase1.PublicKeys.Alpha = newPublicKey(alpha, nil, dstAlpha)
+	phase1.PublicKeys.Beta = newPublicKey(beta, nil, dstBeta)
+
+	// First contribution use generators
+	_, _, g1, g2 := curve.Generators()
+	phase1.Parameters.G2.Beta.Set(&g2)
+	phase1.Parameters.G1.Tau = make([]curve.G1Affine, 2*N)
+	phase1.Parameters.G2.Tau = make([]curve.G2Affine, N)
+	phase1.Parameters.G1.AlphaTau = make([]curve.G1Affine, N)
+	phase1.Parameters.G1.BetaTau = make([]curve.G1Affine, N)
+	for i := 0; i < len(phase1.Parameters.G1.Tau); i++ {
+		phase1.Parameters.G1.Tau[i].Set(&g1)
+	}
+	for i := 0; i < N; i++ {
+		phase1.Parameters.G2.Tau[i].Set(&g2)
+		phase1.Parameters.G1.AlphaTau[i].Set(&g1)
+		phase1.Parameters.G1.BetaTau[i].Set(&g1)
+	}
+
+	// Hash initial contribution
+	phase1.Hash = phase1.hash(nil)
+	return
+}
+
+// Contribute contributes randomness to the phase1 object. This mutates phase1.
+//
+// the toxic waste (τ, α, β) is sampled at random and discarded once the parameters are updated
+func (phase1 *Phase1) Contribute() error {
+	N := len(phase1.Parameters.G2.Tau)
+
+	// Generate key pairs
+	var tau, alpha, beta fr.Element
+	for _, x := range []*fr.Element{&tau, &alpha, &beta} {
+		if _, err := x.SetRandom(); err != nil {
+			return err
+		}
+	}
+	challenge := phase1.Hash
+	phase1.PublicKeys.Tau = newPublicKey(tau, challenge, dstTau)
+	phase1.PublicKeys.Alpha = newPublicKey(alpha, challenge, dstAlpha)
+	phase1.PublicKeys.Beta = newPublicKey(beta, challenge, dstBeta)
+
+	// Compute powers of τ, ατ, and βτ
+	taus := powers(tau, 2*N)
+	alphaTau := make([]fr.Element, N)
+	betaTau := make([]fr.Element, N)
+	for i := 0; i < N; i++ {
+		alphaTau[i].Mul(&taus[i], &alpha)
+		betaTau[i].Mul(&taus[i], &beta)
+	}
+
+	// Update using previous parameters
+	scaleG1InPlace(phase1.Parameters.G1.Tau, taus)
+	scaleG2InPlace(phase1.Parameters.G2.Tau, taus[0:N])
+	scaleG1InPlace(phase1.Parameters.G1.AlphaTau, alphaTau)
+	scaleG1InPlace(phase1.Parameters.G1.BetaTau, betaTau)
+	var betaBI big.Int
+	beta.ToBigIntRegular(&betaBI)
+	phase1.Parameters.G2.Beta.ScalarMultiplication(&phase1.Parameters.G2.Beta, &betaBI)
+
+	// Compute hash of Contribution
+	phase1.Hash = phase1.hash(challenge)
+
+	return nil
+}
+
+// VerifyPhase1 checks that each contribution in c0, c1, c ... was correctly computed from the
+// previous one
+func VerifyPhase1(c0, c1 *Phase1, c ...*Phase1) error {
+	contribs := append([]*Phase1{c0, c1}, c...)
+	for i := 0; i < len(contribs)-1; i++ {
+		if err := verifyPhase1(contribs[i], contribs[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyPhase1 checks that a contribution is based on a known previous Phase1 state.
+func verifyPhase1(current, contribution *Phase1) error {
+	// Compute R for τ, α, β
+	challenge := current.Hash
+	tauR := genR(contribution.PublicKeys.Tau.SG, contribution.PublicKeys.Tau.SXG, challenge, dstTau)
+	alphaR := genR(contribution.PublicKeys.Alpha.SG, contribution.PublicKeys.Alpha.SXG, challenge, dstAlpha)
+	betaR := genR(contribution.PublicKeys.Beta.SG, contribution.PublicKeys.Beta.SXG, challenge, dstBeta)
+
+	// the sizes of the parameters must not change
+	if len(contribution.Parameters.G1.Tau) != len(current.Parameters.G1.Tau) ||
+		len(contribution.Parameters.G2.Tau) != len(current.Parameters.G2.Tau) ||
+		len(contribution.Parameters.G1.AlphaTau) != len(current.Parameters.G1.AlphaTau) ||
+		len(contribution.Parameters.G1.BetaTau) != len(current.Parameters.G1.BetaTau) {
+		return errors.New("couldn't verify size of the parameters")
+	}
+
+	// the first powers of τ must be the generators
+	_, _, g1, g2 := curve.Generators()
+	if !contribution.Parameters.G1.Tau[0].Equal(&g1) || !contribution.Parameters.G2.Tau[0].Equal(&g2) {
+		return errors.New("couldn't verify that [τ⁰]₁ and [τ⁰]₂ are the generators")
+	}
+
+	// Check for knowledge of toxic parameters
+	if !sameRatio(contribution.PublicKeys.Tau.SXG, contribution.PublicKeys.Tau.SG, contribution.PublicKeys.Tau.XR, tauR) {
+		return errors.New("couldn't verify public key of τ")
+	}
+	if !sameRatio(contribution.PublicKeys.Alpha.SXG, contribution.PublicKeys.Alpha.SG, contribution.PublicKeys.Alpha.XR, alphaR) {
+		return errors.New("couldn't verify public key of α")
+	}
+	if !sameRatio(contribution.PublicKeys.Beta.SXG, contribution.PublicKeys.Beta.SG, contribution.PublicKeys.Beta.XR, betaR) {
+		return errors.New("couldn't verify public key of β")
+	}
+
+	// Check for valid updates using previous parameters
+	if !sameRatio(contribution.Parameters.G1.Tau[1], current.Parameters.G1.Tau[1], contribution.PublicKeys.Tau.XR, tauR) {
+		return errors.New("couldn't verify that [τ]₁ is based on previous contribution")
+	}
+	if !sameRatio(contribution.Parameters.G1.AlphaTau[0], current.Parameters.G1.AlphaTau[0], contribution.PublicKeys.Alpha.XR, alphaR) {
+		return errors.New("couldn't verify that [α]₁ is based on previous contribution")
+	}
+	if !sameRatio(contribution.Parameters.G1.BetaTau[0], current.Parameters.G1.BetaTau[0], contribution.PublicKeys.Beta.XR, betaR) {
+		return errors.New("couldn't verify that [β]₁ is based on previous contribution")
+	}
+	if !sameRatio(contribution.PublicKeys.Tau.SXG, contribution.PublicKeys.Tau.SG, contribution.Parameters.G2.Tau[1], current.Parameters.G2.Tau[1]) {
+		return errors.New("couldn't verify that [τ]₂ is based on previous contribution")
+	}
+	if !sameRatio(contribution.PublicKeys.Beta.SXG, contribution.PublicKeys.Beta.SG, contribution.Parameters.G2.Beta, current.Parameters.G2.Beta) {
+		return errors.New("couldn't verify that [β]₂ is based on previous contribution")
+	}
+
+	// Check for valid updates using powers of τ
+	tauL1, tauL2, err := linearCombinationG1(contribution.Parameters.G1.Tau)
+	if err != nil {
+		return err
+	}
+	if !sameRatio(tauL1, tauL2, g2, contribution.Parameters.G2.Tau[1]) {
+		return errors.New("couldn't verify valid powers of τ in G₁")
+	}
+	alphaL1, alphaL2, err := linearCombinationG1(contribution.Parameters.G1.AlphaTau)
+	if err != nil {
+		return err
+	}
+	if !sameRatio(alphaL1, alphaL2, g2, contribution.Parameters.G2.Tau[1]) {
+		return errors.New("couldn't verify valid powers of α(τ) in G₁")
+	}
+	betaL1, betaL2, err := linearCombinationG1(contribution.Parameters.G1.BetaTau)
+	if err != nil {
+		return err
+	}
+	if !sameRatio(betaL1, betaL2, g2, contribution.Parameters.G2.Tau[1]) {
+		return errors.New("couldn't verify valid powers of β(τ) in G₁")
+	}
+	tau2L1, tau2L2, err := linearCombinationG2(contribution.Parameters.G2.Tau)
+	if err != nil {
+		return err
+	}
+	if !sameRatio(contribution.Parameters.G1.Tau[1], g1, tau2L2, tau2L1) {
+		return errors.New("couldn't verify valid powers of τ in G₂")
+	}
+
+	// Check hash of the contribution
+	if !bytes.Equal(contribution.Hash, contribution.hash(challenge)) {
+		return errors.New("couldn't verify hash of contribution")
+	}
+
+	return nil
+}
+
+// hash returns sha256(parameters, public keys, challenge)
+func (phase1 *Phase1) hash(challenge []byte) []byte {
+	sha := sha256.New()
+	if _, err := phase1.writeTo(sha); err != nil {
+		panic(err) // hash.Hash.Write never returns an error
+	}
+	sha.Write(challenge)
+	return sha.Sum(nil)
+}
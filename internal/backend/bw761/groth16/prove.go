@@ -53,8 +53,6 @@ func (proof *Proof) GetCurveID() gurvy.ID {
 // if force flag is set, Prove ignores R1CS solving error (ie invalid solution) and executes
 // the FFTs and MultiExponentiations to compute an (invalid) Proof object
 func Prove(r1cs *bw761backend.R1CS, pk *ProvingKey, solution map[string]interface{}, force bool) (*Proof, error) {
-	nbPrivateWires := r1cs.NbWires - r1cs.NbPublicWires
-
 	// solve the R1CS and compute the a, b, c vectors
 	a := make([]fr.Element, r1cs.NbConstraints, pk.Domain.Cardinality)
 	b := make([]fr.Element, r1cs.NbConstraints, pk.Domain.Cardinality)
@@ -64,6 +62,28 @@ func Prove(r1cs *bw761backend.R1CS, pk *ProvingKey, solution map[string]interfac
 		return nil, err
 	}
 
+	return prove(r1cs, pk, a, b, c, wireValues)
+}
+
+// ProveWitness generates the proof of knoweldge of a r1cs like Prove, reading the inputs
+// from a statically typed witness
+func ProveWitness(r1cs *bw761backend.R1CS, pk *ProvingKey, witness *bw761backend.Witness, force bool) (*Proof, error) {
+	// solve the R1CS and compute the a, b, c vectors
+	a := make([]fr.Element, r1cs.NbConstraints, pk.Domain.Cardinality)
+	b := make([]fr.Element, r1cs.NbConstraints, pk.Domain.Cardinality)
+	c := make([]fr.Element, r1cs.NbConstraints, pk.Domain.Cardinality)
+	wireValues := make([]fr.Element, r1cs.NbWires)
+	if err := r1cs.SolveWitness(witness, a, b, c, wireValues); err != nil && !force {
+		return nil, err
+	}
+
+	return prove(r1cs, pk, a, b, c, wireValues)
+}
+
+// prove computes the proof from the solved R1CS
+func prove(r1cs *bw761backend.R1CS, pk *ProvingKey, a, b, c, wireValues []fr.Element) (*Proof, error) {
+	nbPrivateWires := r1cs.NbWires - r1cs.NbPublicWires
+
 	// set the wire values in regular form
 	utils.Parallelize(len(wireValues), func(start, end int) {
 		for i := start; i < end; i++ {
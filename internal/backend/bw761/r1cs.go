@@ -21,12 +21,14 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"sync"
 
 	"github.com/fxamacker/cbor/v2"
 
 	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/r1cs/r1c"
 	"github.com/consensys/gnark/internal/backend/ioutils"
+	"github.com/consensys/gnark/internal/utils"
 
 	"github.com/consensys/gurvy"
 
@@ -45,9 +47,11 @@ type R1CS struct {
 	DebugInfo     []backend.LogEntry
 
 	// Constraints
-	NbConstraints   uint64 // total number of constraints
-	NbCOConstraints uint64 // number of constraints that need to be solved, the first of the Constraints slice
+	NbConstraints   uint64  // total number of constraints
+	NbCOConstraints uint64  // number of constraints that need to be solved, the first of the Constraints slice
+	Levels          [][]int // computational constraints grouped in levels of independent constraints
 	Constraints     []r1c.R1C
+	Hints           []r1c.Hint   // wires computed by hint functions
 	Coefficients    []fr.Element // R1C coefficients indexes point here
 }
 
@@ -114,10 +118,10 @@ func (r1cs *R1CS) Solve(assignment map[string]interface{}, a, b, c, wireValues [
 	wireInstantiated := make([]bool, r1cs.NbWires)
 
 	// instantiate the public/ private inputs
-	// note that currently, there is a convertion from interface{} to fr.Element for each entry in the
+	// note that there is a convertion from interface{} to fr.Element for each entry in the
 	// assignment map. It can cost a SetBigInt() which converts from Regular ton Montgomery rep (1 mul)
 	// while it's unlikely to be noticeable compared to the FFT and the MultiExp compute times,
-	// there should be a faster (statically typed) path
+	// SolveWitness is the faster (statically typed) path
 	instantiateInputs := func(offset int, inputNames []string) error {
 		for i := 0; i < len(inputNames); i++ {
 			name := inputNames[i]
@@ -150,6 +154,54 @@ func (r1cs *R1CS) Solve(assignment map[string]interface{}, a, b, c, wireValues [
 		}
 	}
 
+	return r1cs.solve(a, b, c, wireValues, wireInstantiated)
+}
+
+// SolveWitness sets all the wires and returns the a, b, c vectors, like Solve.
+// The inputs are read from witness, which values are already ordered like the wires.
+func (r1cs *R1CS) SolveWitness(witness *Witness, a, b, c, wireValues []fr.Element) error {
+	if len(a) != int(r1cs.NbConstraints) || len(b) != int(r1cs.NbConstraints) || len(c) != int(r1cs.NbConstraints) || len(wireValues) != int(r1cs.NbWires) {
+		return errors.New("invalid input size: len(a, b, c) == r1cs.NbConstraints and len(wireValues) == r1cs.NbWires")
+	}
+
+	// keep track of wire that have a value
+	wireInstantiated := make([]bool, r1cs.NbWires)
+
+	instantiateInputs := func(offset int, inputNames []string, values []fr.Element) error {
+		j := 0
+		for i := 0; i < len(inputNames); i++ {
+			if inputNames[i] == backend.OneWire {
+				wireValues[i+offset].SetOne()
+			} else {
+				if j >= len(values) {
+					return errWitnessSize
+				}
+				wireValues[i+offset] = values[j]
+				j++
+			}
+			wireInstantiated[i+offset] = true
+		}
+		if j != len(values) {
+			return errWitnessSize
+		}
+		return nil
+	}
+	// instantiate private inputs
+	offset := int(r1cs.NbWires - r1cs.NbPublicWires - r1cs.NbSecretWires) // private input start index
+	if err := instantiateInputs(offset, r1cs.SecretWires, witness.Secret); err != nil {
+		return err
+	}
+	// instantiate public inputs
+	offset = int(r1cs.NbWires - r1cs.NbPublicWires) // public input start index
+	if err := instantiateInputs(offset, r1cs.PublicWires, witness.Public); err != nil {
+		return err
+	}
+
+	return r1cs.solve(a, b, c, wireValues, wireInstantiated)
+}
+
+// solve computes the missing wires and the a, b, c vectors once the inputs are instantiated
+func (r1cs *R1CS) solve(a, b, c, wireValues []fr.Element, wireInstantiated []bool) error {
 	// now that we know all inputs are set, defer log printing once all wireValues are computed
 	// (or sooner, if a constraint is not satisfied)
 	defer r1cs.printLogs(wireValues, wireInstantiated)
@@ -157,19 +209,36 @@ func (r1cs *R1CS) Solve(assignment map[string]interface{}, a, b, c, wireValues [
 	// check if there is an inconsistant constraint
 	var check fr.Element
 
-	// Loop through computational constraints (the one wwe need to solve and compute a wire in)
-	for i := 0; i < int(r1cs.NbCOConstraints); i++ {
-
-		// solve the constraint, this will compute the missing wire of the gate
-		r1cs.solveR1C(&r1cs.Constraints[i], wireInstantiated, wireValues)
-
-		// at this stage we are guaranteed that a[i]*b[i]=c[i]
-		// if not, it means there is a bug in the solver
-		a[i], b[i], c[i] = instantiateR1C(&r1cs.Constraints[i], r1cs, wireValues)
+	// hint outputs are computed when a constraint needs them
+	hints := newHintSolver(r1cs.Hints, r1cs.Coefficients)
 
-		check.Mul(&a[i], &b[i])
-		if !check.Equal(&c[i]) {
-			panic("error solving r1c: " + a[i].String() + "*" + b[i].String() + "=" + c[i].String())
+	// Loop through computational constraints (the one wwe need to solve and compute a wire in)
+	if len(r1cs.Levels) == 0 {
+		// no dependency information (R1CS serialized before levels were recorded)
+		if err := r1cs.solveCOConstraints(0, int(r1cs.NbCOConstraints), nil, &hints, a, b, c, wireValues, wireInstantiated); err != nil {
+			return err
+		}
+	} else {
+		// constraints of a level only depend on constraints of previous levels
+		for _, level := range r1cs.Levels {
+			if len(level) < minParallelLevelSize {
+				if err := r1cs.solveCOConstraints(0, len(level), level, &hints, a, b, c, wireValues, wireInstantiated); err != nil {
+					return err
+				}
+				continue
+			}
+			var errLock sync.Mutex
+			var err error
+			utils.Parallelize(len(level), func(start, end int) {
+				if _err := r1cs.solveCOConstraints(start, end, level, &hints, a, b, c, wireValues, wireInstantiated); _err != nil {
+					errLock.Lock()
+					err = _err
+					errLock.Unlock()
+				}
+			})
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -177,6 +246,11 @@ func (r1cs *R1CS) Solve(assignment map[string]interface{}, a, b, c, wireValues [
 	// if a[i] * b[i] != c[i]; it means the constraint is not satisfied
 	for i := int(r1cs.NbCOConstraints); i < len(r1cs.Constraints); i++ {
 
+		// hint outputs may only be used in assertions
+		if err := hints.solveTerms(wireValues, wireInstantiated, r1cs.Constraints[i].L, r1cs.Constraints[i].R, r1cs.Constraints[i].O); err != nil {
+			return err
+		}
+
 		// A this stage we are not guaranteed that a[i+sizecg]*b[i+sizecg]=c[i+sizecg] because we only query the values (computed
 		// at the previous step)
 		a[i], b[i], c[i] = instantiateR1C(&r1cs.Constraints[i], r1cs, wireValues)
@@ -193,12 +267,45 @@ func (r1cs *R1CS) Solve(assignment map[string]interface{}, a, b, c, wireValues [
 	return nil
 }
 
+// minParallelLevelSize is the number of constraints under which a level is solved
+// sequentially, the goroutines overhead being greater than the work
+const minParallelLevelSize = 64
+
+// solveCOConstraints solves the computational constraints level[start:end]
+// (or Constraints[start:end] if level is nil), computing the missing wires and a, b, c
+func (r1cs *R1CS) solveCOConstraints(start, end int, level []int, hints *hintSolver, a, b, c, wireValues []fr.Element, wireInstantiated []bool) error {
+	var check fr.Element
+	for j := start; j < end; j++ {
+		i := j
+		if level != nil {
+			i = level[j]
+		}
+
+		// solve the constraint, this will compute the missing wire of the gate
+		if err := r1cs.solveR1C(&r1cs.Constraints[i], hints, wireInstantiated, wireValues); err != nil {
+			return err
+		}
+
+		// at this stage a[i]*b[i]=c[i], unless the missing wire can't satisfy the constraint
+		// (binary decomposition of a value larger than 2^nbBits, division by zero)
+		a[i], b[i], c[i] = instantiateR1C(&r1cs.Constraints[i], r1cs, wireValues)
+
+		check.Mul(&a[i], &b[i])
+		if !check.Equal(&c[i]) {
+			return fmt.Errorf("%w: computational constraint %d: %s*%s=%s", backend.ErrUnsatisfiedConstraint, i, a[i].String(), b[i].String(), c[i].String())
+		}
+	}
+	return nil
+}
+
 func (r1cs *R1CS) logValue(entry backend.LogEntry, wireValues []fr.Element, wireInstantiated []bool) string {
 	var toResolve []interface{}
 	for j := 0; j < len(entry.ToResolve); j++ {
 		wireID := entry.ToResolve[j]
 		if !wireInstantiated[wireID] {
-			panic("wire values was not instantiated")
+			// the solver stopped before computing this wire, a constraint is not satisfied
+			toResolve = append(toResolve, "<unsolved>")
+			continue
 		}
 		toResolve = append(toResolve, wireValues[wireID].String())
 	}
@@ -276,7 +383,13 @@ func instantiateR1C(r *r1c.R1C, r1cs *R1CS, wireValues []fr.Element) (a, b, c fr
 // alone, or it can be computed without ambiguity using the other computed wires
 // , eg when doing a binary decomposition: either way the missing wire can
 // be computed without ambiguity because the r1cs is correctly ordered)
-func (r1cs *R1CS) solveR1C(r *r1c.R1C, wireInstantiated []bool, wireValues []fr.Element) {
+//
+// the hint outputs the constraint uses are computed first
+func (r1cs *R1CS) solveR1C(r *r1c.R1C, hints *hintSolver, wireInstantiated []bool, wireValues []fr.Element) error {
+
+	if err := hints.solveTerms(wireValues, wireInstantiated, r.L, r.R, r.O); err != nil {
+		return err
+	}
 
 	switch r.Solver {
 
@@ -318,7 +431,7 @@ func (r1cs *R1CS) solveR1C(r *r1c.R1C, wireInstantiated []bool, wireValues []fr.
 		// ensure we found the unset wire
 		if loc == 0 {
 			// this wire may have been instantiated as part of moExpression already
-			return
+			return nil
 		}
 
 		// we compute the wire value and instantiate it
@@ -404,4 +517,6 @@ func (r1cs *R1CS) solveR1C(r *r1c.R1C, wireInstantiated []bool, wireValues []fr.
 	default:
 		panic("unimplemented solving method")
 	}
+
+	return nil
 }
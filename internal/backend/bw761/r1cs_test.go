@@ -19,7 +19,12 @@ package backend_test
 import (
 	bw761backend "github.com/consensys/gnark/internal/backend/bw761"
 
+	"github.com/consensys/gurvy/bw761/fr"
+
 	"bytes"
+	"errors"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/internal/backend/circuits"
 	"github.com/consensys/gurvy"
 	"reflect"
@@ -59,3 +64,122 @@ func TestSerialization(t *testing.T) {
 		})
 	}
 }
+
+// sumOfSquares has many independent constraints, so that the parallel solver path is exercised
+type sumOfSquares struct {
+	X []frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (circuit *sumOfSquares) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	sum := cs.Constant(0)
+	for i := 0; i < len(circuit.X); i++ {
+		sum = cs.Add(sum, cs.Mul(circuit.X[i], circuit.X[i]))
+	}
+	cs.AssertIsEqual(sum, circuit.Y)
+	return nil
+}
+
+func TestSolveLevels(t *testing.T) {
+	const n = 300
+	var circuit, witness sumOfSquares
+	circuit.X = make([]frontend.Variable, n)
+	witness.X = make([]frontend.Variable, n)
+	y := 0
+	for i := 0; i < n; i++ {
+		witness.X[i].Assign(i)
+		y += i * i
+	}
+	witness.Y.Assign(y)
+
+	_r1cs, err := frontend.Compile(gurvy.BW761, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sumOfSquaresR1CS := _r1cs.(*bw761backend.R1CS)
+
+	testCircuits := map[string]struct {
+		r1cs    *bw761backend.R1CS
+		witness frontend.Circuit
+	}{
+		"sum_of_squares": {sumOfSquaresR1CS, &witness},
+	}
+	for name, circuit := range circuits.Circuits {
+		testCircuits[name] = struct {
+			r1cs    *bw761backend.R1CS
+			witness frontend.Circuit
+		}{circuit.R1CS.ToR1CS(gurvy.BW761).(*bw761backend.R1CS), circuit.Good}
+	}
+
+	for name, tc := range testCircuits {
+		t.Run(name, func(t *testing.T) {
+			assignment, err := frontend.ParseWitness(tc.witness)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// solve with the constraints grouped in levels
+			nbConstraints, nbWires := tc.r1cs.NbConstraints, tc.r1cs.NbWires
+			a, b, c := make([]fr.Element, nbConstraints), make([]fr.Element, nbConstraints), make([]fr.Element, nbConstraints)
+			wireValues := make([]fr.Element, nbWires)
+			if err := tc.r1cs.Solve(assignment, a, b, c, wireValues); err != nil {
+				t.Fatal(err)
+			}
+
+			// solve sequentially
+			sequential := *tc.r1cs
+			sequential.Levels = nil
+			_a, _b, _c := make([]fr.Element, nbConstraints), make([]fr.Element, nbConstraints), make([]fr.Element, nbConstraints)
+			_wireValues := make([]fr.Element, nbWires)
+			if err := sequential.Solve(assignment, _a, _b, _c, _wireValues); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(a, _a) || !reflect.DeepEqual(b, _b) || !reflect.DeepEqual(c, _c) || !reflect.DeepEqual(wireValues, _wireValues) {
+				t.Fatal("solving constraints by levels doesn't match sequential solving")
+			}
+		})
+	}
+}
+
+// tooLarge decomposes X on 8 bits, and logs a wire computed after the decomposition
+type tooLarge struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (circuit *tooLarge) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	cs.ToBinary(circuit.X, 8)
+	x2 := cs.Mul(circuit.X, circuit.X)
+	cs.Println("x^2:", x2)
+	cs.AssertIsEqual(x2, circuit.Y)
+	return nil
+}
+
+func TestUnsatisfiedCOConstraint(t *testing.T) {
+	var circuit, sparseCircuit, witness tooLarge
+
+	// X doesn't fit on 8 bits, the binary decomposition (a computational constraint) can't be solved
+	witness.X.Assign(300)
+	witness.Y.Assign(90000)
+	assignment, err := frontend.ParseWitness(&witness)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r1cs, err := frontend.Compile(gurvy.BW761, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r1cs.(*bw761backend.R1CS).IsSolved(assignment); !errors.Is(err, backend.ErrUnsatisfiedConstraint) {
+		t.Fatal("solving the R1CS should fail with ErrUnsatisfiedConstraint, got", err)
+	}
+
+	sparseR1CS, err := frontend.CompileSparse(gurvy.BW761, &sparseCircuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sparseR1CS.(*bw761backend.SparseR1CS).IsSolved(assignment); !errors.Is(err, backend.ErrUnsatisfiedConstraint) {
+		t.Fatal("solving the sparse R1CS should fail with ErrUnsatisfiedConstraint, got", err)
+	}
+}
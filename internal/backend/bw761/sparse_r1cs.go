@@ -0,0 +1,289 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/r1cs/r1c"
+	"github.com/consensys/gnark/internal/backend/ioutils"
+
+	"github.com/consensys/gurvy"
+
+	"github.com/consensys/gurvy/bw761/fr"
+)
+
+// SparseR1CS decsribes a set of sparse constraints (PLONK arithmetization)
+// qL⋅xa + qR⋅xb + qO⋅xc + qM⋅(xa⋅xb) + qC == 0
+type SparseR1CS struct {
+	// Wires
+	NbWires       uint64
+	NbPublicWires uint64 // includes ONE wire
+	NbSecretWires uint64
+	SecretWires   []string // private wire names, correctly ordered (the i-th entry is the name of the (offset+)i-th wire)
+	PublicWires   []string // public wire names, correctly ordered (the i-th entry is the name of the (offset+)i-th wire)
+	Logs          []backend.LogEntry
+	DebugInfo     []backend.LogEntry
+
+	// Constraints
+	NbConstraints   uint64 // total number of constraints
+	NbCOConstraints uint64 // number of constraints that need to be solved, the first of the Constraints slice
+	Constraints     []r1c.SparseR1C
+	Hints           []r1c.Hint   // wires computed by hint functions
+	Coefficients    []fr.Element // SparseR1C coefficients indexes point here
+}
+
+// GetNbConstraints returns the total number of constraints
+func (r1cs *SparseR1CS) GetNbConstraints() uint64 {
+	return r1cs.NbConstraints
+}
+
+// GetNbWires returns the number of wires
+func (r1cs *SparseR1CS) GetNbWires() uint64 {
+	return r1cs.NbWires
+}
+
+// GetNbCoefficients return the number of unique coefficients needed in the SparseR1CS
+func (r1cs *SparseR1CS) GetNbCoefficients() int {
+	return len(r1cs.Coefficients)
+}
+
+// GetCurveID returns curve ID as defined in gurvy (gurvy.BW761)
+func (r1cs *SparseR1CS) GetCurveID() gurvy.ID {
+	return gurvy.BW761
+}
+
+// WriteTo encodes SparseR1CS into provided io.Writer using cbor
+func (r1cs *SparseR1CS) WriteTo(w io.Writer) (int64, error) {
+	_w := ioutils.WriterCounter{W: w} // wraps writer to count the bytes written
+	encoder := cbor.NewEncoder(&_w)
+
+	// encode our object
+	err := encoder.Encode(r1cs)
+	return _w.N, err
+}
+
+// ReadFrom attempts to decode SparseR1CS from io.Reader using cbor
+func (r1cs *SparseR1CS) ReadFrom(r io.Reader) (int64, error) {
+	decoder := cbor.NewDecoder(r)
+
+	err := decoder.Decode(r1cs)
+	return int64(decoder.NumBytesRead()), err
+}
+
+// IsSolved returns nil if given assignment solves the SparseR1CS and error otherwise
+// this method wraps r1cs.Solve() and allocates r1cs.Solve() inputs
+func (r1cs *SparseR1CS) IsSolved(assignment map[string]interface{}) error {
+	wireValues := make([]fr.Element, r1cs.NbWires)
+	return r1cs.Solve(assignment, wireValues)
+}
+
+// Solve sets all the wires. The entries in wireValues are in Montgomery form.
+// assignment: map[string]value: contains the input variables
+// wireValues =  [intermediateVariables | privateInputs | publicInputs]
+func (r1cs *SparseR1CS) Solve(assignment map[string]interface{}, wireValues []fr.Element) error {
+	if len(wireValues) != int(r1cs.NbWires) {
+		return errors.New("invalid input size: len(wireValues) == r1cs.NbWires")
+	}
+
+	// keep track of wire that have a value
+	wireInstantiated := make([]bool, r1cs.NbWires)
+
+	// instantiate the public/ private inputs
+	instantiateInputs := func(offset int, inputNames []string) error {
+		for i := 0; i < len(inputNames); i++ {
+			name := inputNames[i]
+			if name == backend.OneWire {
+				wireValues[i+offset].SetOne()
+				wireInstantiated[i+offset] = true
+			} else {
+				if val, ok := assignment[name]; ok {
+					wireValues[i+offset].SetInterface(val)
+					wireInstantiated[i+offset] = true
+				} else {
+					return fmt.Errorf("%q: %w", name, backend.ErrInputNotSet)
+				}
+			}
+		}
+		return nil
+	}
+	// instantiate private inputs
+	if r1cs.NbSecretWires != 0 {
+		offset := int(r1cs.NbWires - r1cs.NbPublicWires - r1cs.NbSecretWires) // private input start index
+		if err := instantiateInputs(offset, r1cs.SecretWires); err != nil {
+			return err
+		}
+	}
+	// instantiate public inputs
+	{
+		offset := int(r1cs.NbWires - r1cs.NbPublicWires) // public input start index
+		if err := instantiateInputs(offset, r1cs.PublicWires); err != nil {
+			return err
+		}
+	}
+
+	// now that we know all inputs are set, defer log printing once all wireValues are computed
+	// (or sooner, if a constraint is not satisfied)
+	defer r1cs.printLogs(wireValues, wireInstantiated)
+
+	// hint outputs are computed when a constraint needs them
+	hints := newHintSolver(r1cs.Hints, r1cs.Coefficients)
+	solveHints := func(c *r1c.SparseR1C) error {
+		return hints.solveTerms(wireValues, wireInstantiated, r1c.LinearExpression{c.L, c.R, c.O})
+	}
+
+	// Loop through computational constraints (the one we need to solve and compute a wire in)
+	for i := 0; i < int(r1cs.NbCOConstraints); i++ {
+
+		if err := solveHints(&r1cs.Constraints[i]); err != nil {
+			return err
+		}
+
+		// solve the constraint, this will compute the missing wire(s) of the gate
+		r1cs.solveConstraint(&r1cs.Constraints[i], wireInstantiated, wireValues)
+
+		// a computational constraint is not satisfied if it couldn't be solved
+		// (for instance, if it was only a check on already computed wires)
+		if !r1cs.isSatisfied(&r1cs.Constraints[i], wireValues) {
+			return fmt.Errorf("%w: constraint #%d", backend.ErrUnsatisfiedConstraint, i)
+		}
+	}
+
+	// Loop through the assertions -- here all wireValues should be instantiated
+	for i := int(r1cs.NbCOConstraints); i < len(r1cs.Constraints); i++ {
+		if err := solveHints(&r1cs.Constraints[i]); err != nil {
+			return err
+		}
+		if !r1cs.isSatisfied(&r1cs.Constraints[i], wireValues) {
+			debugInfo := r1cs.DebugInfo[i-int(r1cs.NbCOConstraints)]
+			debugInfoStr := r1cs.logValue(debugInfo, wireValues, wireInstantiated)
+			return fmt.Errorf("%w: %s", backend.ErrUnsatisfiedConstraint, debugInfoStr)
+		}
+	}
+
+	return nil
+}
+
+func (r1cs *SparseR1CS) logValue(entry backend.LogEntry, wireValues []fr.Element, wireInstantiated []bool) string {
+	var toResolve []interface{}
+	for j := 0; j < len(entry.ToResolve); j++ {
+		wireID := entry.ToResolve[j]
+		if !wireInstantiated[wireID] {
+			// the solver stopped before computing this wire, a constraint is not satisfied
+			toResolve = append(toResolve, "<unsolved>")
+			continue
+		}
+		toResolve = append(toResolve, wireValues[wireID].String())
+	}
+	return fmt.Sprintf(entry.Format, toResolve...)
+}
+
+func (r1cs *SparseR1CS) printLogs(wireValues []fr.Element, wireInstantiated []bool) {
+
+	// for each log, resolve the wire values and print the log to stdout
+	for i := 0; i < len(r1cs.Logs); i++ {
+		fmt.Print(r1cs.logValue(r1cs.Logs[i], wireValues, wireInstantiated))
+	}
+}
+
+// isSatisfied returns true if qL⋅xa + qR⋅xb + qO⋅xc + qM⋅(xa⋅xb) + qC == 0
+func (r1cs *SparseR1CS) isSatisfied(c *r1c.SparseR1C, wireValues []fr.Element) bool {
+	var res, t fr.Element
+	xa, xb, xc := &wireValues[c.L.VariableID()], &wireValues[c.R.VariableID()], &wireValues[c.O.VariableID()]
+
+	res.Mul(xa, xb).Mul(&res, &r1cs.Coefficients[c.M])
+	t.Mul(xa, &r1cs.Coefficients[c.L.CoeffID()])
+	res.Add(&res, &t)
+	t.Mul(xb, &r1cs.Coefficients[c.R.CoeffID()])
+	res.Add(&res, &t)
+	t.Mul(xc, &r1cs.Coefficients[c.O.CoeffID()])
+	res.Add(&res, &t).Add(&res, &r1cs.Coefficients[c.K])
+
+	return res.IsZero()
+}
+
+// solveConstraint computes the uninstantiated wire of a sparse constraint
+// (as in the R1CS solver, if the wire can't be computed because of a division by 0, it is set to 0)
+//
+// if c.Solver is r1c.BinaryDec, the constraint is xc == 2⋅xa + xb where xb is a bit, and xa, xb are computed
+// from xc
+func (r1cs *SparseR1CS) solveConstraint(c *r1c.SparseR1C, wireInstantiated []bool, wireValues []fr.Element) {
+	a, b, o := c.L.VariableID(), c.R.VariableID(), c.O.VariableID()
+
+	switch c.Solver {
+
+	case r1c.SingleOutput:
+		qL, qR, qO := &r1cs.Coefficients[c.L.CoeffID()], &r1cs.Coefficients[c.R.CoeffID()], &r1cs.Coefficients[c.O.CoeffID()]
+		qM, qC := &r1cs.Coefficients[c.M], &r1cs.Coefficients[c.K]
+
+		var num, den, t fr.Element
+		switch {
+		case !wireInstantiated[a]:
+			// xa⋅(qL + qM⋅xb) == -(qR⋅xb + qO⋅xc + qC)
+			den.Mul(qM, &wireValues[b]).Add(&den, qL)
+			num.Mul(qR, &wireValues[b])
+			t.Mul(qO, &wireValues[o])
+			num.Add(&num, &t).Add(&num, qC).Neg(&num)
+			r1cs.setWire(a, &num, &den, wireInstantiated, wireValues)
+		case !wireInstantiated[b]:
+			// xb⋅(qR + qM⋅xa) == -(qL⋅xa + qO⋅xc + qC)
+			den.Mul(qM, &wireValues[a]).Add(&den, qR)
+			num.Mul(qL, &wireValues[a])
+			t.Mul(qO, &wireValues[o])
+			num.Add(&num, &t).Add(&num, qC).Neg(&num)
+			r1cs.setWire(b, &num, &den, wireInstantiated, wireValues)
+		case !wireInstantiated[o]:
+			// xc⋅qO == -(qL⋅xa + qR⋅xb + qM⋅(xa⋅xb) + qC)
+			num.Mul(&wireValues[a], &wireValues[b]).Mul(&num, qM)
+			t.Mul(qL, &wireValues[a])
+			num.Add(&num, &t)
+			t.Mul(qR, &wireValues[b])
+			num.Add(&num, &t).Add(&num, qC).Neg(&num)
+			r1cs.setWire(o, &num, qO, wireInstantiated, wireValues)
+		}
+
+	case r1c.BinaryDec:
+		// the binary decomposition must be called on the non Mont form of the number
+		var n big.Int
+		wireValues[o].ToBigIntRegular(&n)
+
+		wireValues[b].SetUint64(uint64(n.Bit(0)))
+		n.Rsh(&n, 1)
+		wireValues[a].SetBigInt(&n)
+		wireInstantiated[a] = true
+		wireInstantiated[b] = true
+
+	default:
+		panic("unimplemented solving method")
+	}
+}
+
+// setWire sets wireValues[id] = num / den (or 0 if den == 0)
+func (r1cs *SparseR1CS) setWire(id int, num, den *fr.Element, wireInstantiated []bool, wireValues []fr.Element) {
+	if den.IsZero() {
+		wireValues[id].SetZero()
+	} else {
+		wireValues[id].Div(num, den)
+	}
+	wireInstantiated[id] = true
+}
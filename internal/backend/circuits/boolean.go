@@ -0,0 +1,54 @@
+package circuits
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gurvy"
+)
+
+type booleanCircuit struct {
+	A, B, C frontend.Variable // bits
+	X, Y    frontend.Variable // bytes
+	Z       frontend.Variable `gnark:",public"`
+}
+
+func (circuit *booleanCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	cs.AssertIsEqual(cs.And(circuit.A, circuit.B), 0)
+	cs.AssertIsEqual(cs.Or(circuit.A, circuit.B), 1)
+	cs.AssertIsEqual(cs.Nand(circuit.A, circuit.C), 0)
+	cs.AssertIsEqual(cs.AndN(circuit.A, circuit.B, circuit.C), 0)
+	cs.AssertIsEqual(cs.OrN(circuit.A, circuit.B, circuit.C), 1)
+	cs.AssertIsEqual(cs.OrN(cs.Not(circuit.A), circuit.B, circuit.B), 0)
+
+	and := cs.BitwiseAnd(circuit.X, circuit.Y, 8)
+	or := cs.BitwiseOr(circuit.X, circuit.Y, 8)
+	xor := cs.BitwiseXor(circuit.X, circuit.Y, 8)
+	cs.AssertIsEqual(cs.Sub(or, and), xor)
+	cs.AssertIsEqual(and, circuit.Z)
+	return nil
+}
+
+func init() {
+	var circuit, good, bad, public booleanCircuit
+	r1cs, err := frontend.Compile(gurvy.UNKNOWN, &circuit)
+	if err != nil {
+		panic(err)
+	}
+
+	good.A.Assign(1)
+	good.B.Assign(0)
+	good.C.Assign(1)
+	good.X.Assign(0b10110101)
+	good.Y.Assign(0b01100110)
+	good.Z.Assign(0b00100100)
+
+	bad.A.Assign(1)
+	bad.B.Assign(0)
+	bad.C.Assign(1)
+	bad.X.Assign(0b10110101)
+	bad.Y.Assign(0b01100110)
+	bad.Z.Assign(0b11110111)
+
+	public.Z.Assign(0b00100100)
+
+	addEntry("boolean", r1cs, &good, &bad, &public)
+}
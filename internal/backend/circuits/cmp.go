@@ -0,0 +1,38 @@
+package circuits
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gurvy"
+)
+
+type cmpCircuit struct {
+	X, Y frontend.Variable
+	Z    frontend.Variable `gnark:",public"`
+}
+
+func (circuit *cmpCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	cs.AssertIsEqual(cs.Cmp(circuit.X, circuit.Y, 8), circuit.Z)
+	cs.AssertIsEqual(cs.Cmp(circuit.Y, circuit.X, 8), cs.Sub(0, circuit.Z))
+	cs.AssertIsEqual(cs.Cmp(circuit.X, circuit.X, 8), 0)
+	return nil
+}
+
+func init() {
+	var circuit, good, bad, public cmpCircuit
+	r1cs, err := frontend.Compile(gurvy.UNKNOWN, &circuit)
+	if err != nil {
+		panic(err)
+	}
+
+	good.X.Assign(12)
+	good.Y.Assign(35)
+	good.Z.Assign(-1)
+
+	bad.X.Assign(12)
+	bad.Y.Assign(35)
+	bad.Z.Assign(1)
+
+	public.Z.Assign(-1)
+
+	addEntry("cmp", r1cs, &good, &bad, &public)
+}
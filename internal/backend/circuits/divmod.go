@@ -0,0 +1,95 @@
+package circuits
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gurvy"
+)
+
+type divModCircuit struct {
+	X, Y frontend.Variable
+	Q, R frontend.Variable `gnark:",public"`
+}
+
+func (circuit *divModCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	q, r := cs.DivMod(circuit.X, circuit.Y, 16)
+	cs.AssertIsEqual(q, circuit.Q)
+	cs.AssertIsEqual(r, circuit.R)
+	cs.AssertIsEqual(cs.Mod(circuit.X, 7, 16), 2)
+
+	// 8 bits words
+	cs.AssertIsEqual(cs.ShiftLeft(circuit.Y, 3, 8), 0xa8)
+	cs.AssertIsEqual(cs.ShiftRight(circuit.Y, 2, 8), 0x0d)
+	cs.AssertIsEqual(cs.RotateLeft(circuit.Y, 3, 8), 0xa9)
+	cs.AssertIsEqual(cs.RotateRight(circuit.Y, 3, 8), 0xa6)
+
+	// out of range shifts
+	cs.AssertIsEqual(cs.ShiftLeft(circuit.Y, 8, 8), 0)
+	cs.AssertIsEqual(cs.ShiftRight(circuit.Y, 9, 8), 0)
+	cs.AssertIsEqual(cs.RotateLeft(circuit.Y, 11, 8), 0xa9)
+	cs.AssertIsEqual(cs.RotateLeft(circuit.Y, -3, 8), 0xa6)
+	cs.AssertIsEqual(cs.RotateRight(circuit.Y, -3, 8), 0xa9)
+	return nil
+}
+
+func init() {
+	divMod()
+	divModRange()
+}
+
+func divMod() {
+	var circuit, good, bad, public divModCircuit
+	r1cs, err := frontend.Compile(gurvy.UNKNOWN, &circuit)
+	if err != nil {
+		panic(err)
+	}
+
+	// 1500 == 28 * 53 + 16, 1500 == 214 * 7 + 2, 53 == 0b00110101
+	good.X.Assign(1500)
+	good.Y.Assign(53)
+	good.Q.Assign(28)
+	good.R.Assign(16)
+
+	// the remainder is not reduced
+	bad.X.Assign(1500)
+	bad.Y.Assign(53)
+	bad.Q.Assign(27)
+	bad.R.Assign(69)
+
+	public.Q.Assign(28)
+	public.R.Assign(16)
+
+	addEntry("divmod", r1cs, &good, &bad, &public)
+}
+
+type divModRangeCircuit struct {
+	X, Y frontend.Variable
+	Q    frontend.Variable `gnark:",public"`
+}
+
+func (circuit *divModRangeCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	q, _ := cs.DivMod(circuit.X, circuit.Y, 16)
+	cs.AssertIsEqual(q, circuit.Q)
+	return nil
+}
+
+func divModRange() {
+	var circuit, good, bad, public divModRangeCircuit
+	r1cs, err := frontend.Compile(gurvy.UNKNOWN, &circuit)
+	if err != nil {
+		panic(err)
+	}
+
+	// 1500 == 28 * 53 + 16
+	good.X.Assign(1500)
+	good.Y.Assign(53)
+	good.Q.Assign(28)
+
+	// 70000 == 1320 * 53 + 40, q and r fit on 16 bits but the dividend doesn't
+	bad.X.Assign(70000)
+	bad.Y.Assign(53)
+	bad.Q.Assign(1320)
+
+	public.Q.Assign(28)
+
+	addEntry("divmod_range", r1cs, &good, &bad, &public)
+}
@@ -0,0 +1,59 @@
+package circuits
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gurvy"
+)
+
+// euclidean division, computed by hints and constrained by X == Q*Y + R
+type hintCircuit struct {
+	X, Y frontend.Variable
+	Q    frontend.Variable `gnark:",public"`
+}
+
+func (circuit *hintCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	q := cs.NewHint(quotientHint, circuit.X, circuit.Y)
+	r := cs.NewHint(remainderHint, circuit.X, circuit.Y)
+	cs.AssertIsEqual(cs.Add(cs.Mul(q, circuit.Y), r), circuit.X)
+	cs.AssertIsEqual(q, circuit.Q)
+	return nil
+}
+
+func quotientHint(curveID gurvy.ID, inputs []*big.Int, result *big.Int) error {
+	if inputs[1].Sign() == 0 {
+		return errors.New("division by zero")
+	}
+	result.Quo(inputs[0], inputs[1])
+	return nil
+}
+
+func remainderHint(curveID gurvy.ID, inputs []*big.Int, result *big.Int) error {
+	if inputs[1].Sign() == 0 {
+		return errors.New("division by zero")
+	}
+	result.Rem(inputs[0], inputs[1])
+	return nil
+}
+
+func init() {
+	var circuit, good, bad, public hintCircuit
+	r1cs, err := frontend.Compile(gurvy.UNKNOWN, &circuit)
+	if err != nil {
+		panic(err)
+	}
+
+	good.X.Assign(41)
+	good.Y.Assign(5)
+	good.Q.Assign(8)
+
+	bad.X.Assign(41)
+	bad.Y.Assign(5)
+	bad.Q.Assign(7)
+
+	public.Q.Assign(8)
+
+	addEntry("hint", r1cs, &good, &bad, &public)
+}
@@ -0,0 +1,51 @@
+package circuits
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gurvy"
+)
+
+type muxCircuit struct {
+	B0, B1 frontend.Variable
+	Sel    frontend.Variable
+	Array  [5]frontend.Variable
+	X      frontend.Variable `gnark:",public"`
+}
+
+func (circuit *muxCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	// table of constants and variables
+	cs.AssertIsEqual(cs.Lookup2(circuit.B0, circuit.B1, 10, circuit.Array[0], 12, circuit.Array[1]), circuit.Array[0])
+	cs.AssertIsEqual(cs.Lookup2(circuit.B1, circuit.B0, 10, circuit.Array[0], 12, circuit.Array[1]), 12)
+
+	cs.AssertIsEqual(cs.Mux(circuit.Sel, 3, 4, 5, 6, 7, 8), 7)
+	cs.AssertIsEqual(cs.ArrayAccess(circuit.Sel, circuit.Array[:]), circuit.X)
+	return nil
+}
+
+func init() {
+	var circuit, good, bad, public muxCircuit
+	r1cs, err := frontend.Compile(gurvy.UNKNOWN, &circuit)
+	if err != nil {
+		panic(err)
+	}
+
+	good.B0.Assign(1)
+	good.B1.Assign(0)
+	good.Sel.Assign(4)
+	for i := 0; i < len(good.Array); i++ {
+		good.Array[i].Assign(100 + i)
+	}
+	good.X.Assign(104)
+
+	bad.B0.Assign(1)
+	bad.B1.Assign(0)
+	bad.Sel.Assign(4)
+	for i := 0; i < len(bad.Array); i++ {
+		bad.Array[i].Assign(100 + i)
+	}
+	bad.X.Assign(103)
+
+	public.X.Assign(104)
+
+	addEntry("mux", r1cs, &good, &bad, &public)
+}
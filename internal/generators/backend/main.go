@@ -45,9 +45,17 @@ func main() {
 			if err := os.MkdirAll(d.RootPath+"groth16", 0700); err != nil {
 				panic(err)
 			}
+			if err := os.MkdirAll(d.RootPath+"groth16/mpcsetup", 0700); err != nil {
+				panic(err)
+			}
+			if err := os.MkdirAll(d.RootPath+"plonk", 0700); err != nil {
+				panic(err)
+			}
 
 			fftDir := filepath.Join(d.RootPath, "fft")
 			groth16Dir := filepath.Join(d.RootPath, "groth16")
+			mpcSetupDir := filepath.Join(groth16Dir, "mpcsetup")
+			plonkDir := filepath.Join(d.RootPath, "plonk")
 			backendDir := d.RootPath
 			r1csDir := "../../../backend/r1cs/"
 
@@ -58,6 +66,13 @@ func main() {
 				panic(err)
 			}
 
+			if err := bgen.GenerateF(d, "r1cs", "./template/representations/", bavard.EntryF{
+				File:      filepath.Join(r1csDir, "sparse_r1cs_"+strings.ToLower(d.Curve)+".go"),
+				TemplateF: []string{"sparse_r1cs.convertor.go.tmpl", importCurve},
+			}); err != nil {
+				panic(err)
+			}
+
 			if err := bgen.GenerateF(d, "backend", "./template/representations/", bavard.EntryF{
 				File:      filepath.Join(backendDir, "r1cs.go"),
 				TemplateF: []string{"r1cs.go.tmpl", importCurve},
@@ -65,6 +80,27 @@ func main() {
 				panic(err)
 			}
 
+			if err := bgen.GenerateF(d, "backend", "./template/representations/", bavard.EntryF{
+				File:      filepath.Join(backendDir, "sparse_r1cs.go"),
+				TemplateF: []string{"sparse_r1cs.go.tmpl", importCurve},
+			}); err != nil {
+				panic(err)
+			}
+
+			if err := bgen.GenerateF(d, "backend", "./template/representations/", bavard.EntryF{
+				File:      filepath.Join(backendDir, "hint.go"),
+				TemplateF: []string{"hint.go.tmpl", importCurve},
+			}); err != nil {
+				panic(err)
+			}
+
+			if err := bgen.GenerateF(d, "backend", "./template/representations/", bavard.EntryF{
+				File:      filepath.Join(backendDir, "witness.go"),
+				TemplateF: []string{"witness.go.tmpl", importCurve},
+			}); err != nil {
+				panic(err)
+			}
+
 			if err := bgen.GenerateF(d, "backend_test", "./template/representations/", bavard.EntryF{
 				File:      filepath.Join(backendDir, "r1cs_test.go"),
 				TemplateF: []string{"tests/r1cs.go.tmpl", importCurve},
@@ -101,6 +137,53 @@ func main() {
 			}); err != nil {
 				panic(err)
 			}
+
+			if d.Curve == "BN256" {
+				// the solidity verifier relies on the EVM precompiled contracts, which only support BN256
+				if err := bgen.GenerateF(d, "groth16", "./template/zkpschemes/", bavard.EntryF{
+					File:      filepath.Join(groth16Dir, "solidity.go"),
+					TemplateF: []string{"groth16.solidity.go.tmpl", importCurve},
+				}); err != nil {
+					panic(err)
+				}
+
+				if err := bgen.GenerateF(d, "groth16_test", "./template/zkpschemes/", bavard.EntryF{
+					File:      filepath.Join(groth16Dir, "solidity_test.go"),
+					TemplateF: []string{"tests/groth16.solidity.go.tmpl", importCurve},
+				}); err != nil {
+					panic(err)
+				}
+			}
+
+			entries = []bavard.EntryF{
+				{File: filepath.Join(mpcSetupDir, "phase1.go"), TemplateF: []string{"groth16.mpcsetup.phase1.go.tmpl", importCurve}},
+				{File: filepath.Join(mpcSetupDir, "phase2.go"), TemplateF: []string{"groth16.mpcsetup.phase2.go.tmpl", importCurve}},
+				{File: filepath.Join(mpcSetupDir, "utils.go"), TemplateF: []string{"groth16.mpcsetup.utils.go.tmpl", importCurve}},
+				{File: filepath.Join(mpcSetupDir, "marshal.go"), TemplateF: []string{"groth16.mpcsetup.marshal.go.tmpl", importCurve}},
+				{File: filepath.Join(mpcSetupDir, "setup_test.go"), TemplateF: []string{"tests/groth16.mpcsetup.go.tmpl", importCurve}},
+			}
+
+			if err := bgen.GenerateF(d, "mpcsetup", "./template/zkpschemes/", entries...); err != nil {
+				panic(err)
+			}
+
+			entries = []bavard.EntryF{
+				{File: filepath.Join(plonkDir, "verify.go"), TemplateF: []string{"plonk.verify.go.tmpl", importCurve}},
+				{File: filepath.Join(plonkDir, "prove.go"), TemplateF: []string{"plonk.prove.go.tmpl", importCurve}},
+				{File: filepath.Join(plonkDir, "setup.go"), TemplateF: []string{"plonk.setup.go.tmpl", importCurve}},
+				{File: filepath.Join(plonkDir, "marshal.go"), TemplateF: []string{"plonk.marshal.go.tmpl", importCurve}},
+			}
+
+			if err := bgen.GenerateF(d, "plonk", "./template/zkpschemes/", entries...); err != nil {
+				panic(err)
+			}
+
+			if err := bgen.GenerateF(d, "plonk_test", "./template/zkpschemes/", bavard.EntryF{
+				File:      filepath.Join(plonkDir, "plonk_test.go"),
+				TemplateF: []string{"tests/plonk.go.tmpl", importCurve},
+			}); err != nil {
+				panic(err)
+			}
 		}(d)
 
 	}
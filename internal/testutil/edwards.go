@@ -0,0 +1,256 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testutil provides the native computations needed to build the witnesses of the tests of
+// the std gadgets, behind the gurvy.ID of the snark curve
+//
+// It is only imported by _test.go files. The points and scalars are returned as big.Int in regular form.
+package testutil
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark/backend"
+	edbls377 "github.com/consensys/gnark/crypto/algebra/twistededwards/bls377"
+	edbw761 "github.com/consensys/gnark/crypto/algebra/twistededwards/bw761"
+	mimc_bls377 "github.com/consensys/gnark/crypto/hash/mimc/bls377"
+	mimc_bls381 "github.com/consensys/gnark/crypto/hash/mimc/bls381"
+	mimc_bn256 "github.com/consensys/gnark/crypto/hash/mimc/bn256"
+	mimc_bw761 "github.com/consensys/gnark/crypto/hash/mimc/bw761"
+	eddsa_bls377 "github.com/consensys/gnark/crypto/signature/eddsa/bls377"
+	eddsa_bls381 "github.com/consensys/gnark/crypto/signature/eddsa/bls381"
+	eddsa_bn256 "github.com/consensys/gnark/crypto/signature/eddsa/bn256"
+	eddsa_bw761 "github.com/consensys/gnark/crypto/signature/eddsa/bw761"
+	schnorr_bls377 "github.com/consensys/gnark/crypto/signature/schnorr/bls377"
+	schnorr_bls381 "github.com/consensys/gnark/crypto/signature/schnorr/bls381"
+	schnorr_bn256 "github.com/consensys/gnark/crypto/signature/schnorr/bn256"
+	schnorr_bw761 "github.com/consensys/gnark/crypto/signature/schnorr/bw761"
+	"github.com/consensys/gurvy"
+	fr_bls377 "github.com/consensys/gurvy/bls377/fr"
+	fr_bls381 "github.com/consensys/gurvy/bls381/fr"
+	edbls381 "github.com/consensys/gurvy/bls381/twistededwards"
+	fr_bn256 "github.com/consensys/gurvy/bn256/fr"
+	edbn256 "github.com/consensys/gurvy/bn256/twistededwards"
+	fr_bw761 "github.com/consensys/gurvy/bw761/fr"
+)
+
+var (
+	errUnknownCurve = errors.New("unknown curve id")
+	errVerify       = errors.New("the native verification of the signature failed")
+)
+
+// hashSeed is the seed of the MiMC hash of the signatures, as in the gadgets
+const hashSeed = "seed"
+
+// Signature is a signature of a message, with the public key A which verifies it
+//
+// an eddsa signature is (R, S), a schnorr signature is (E, S)
+type Signature struct {
+	A, R [2]big.Int
+	E, S big.Int
+}
+
+// SignEdDSA signs msg (reduced modulo the scalar field, as in the circuit) with the eddsa key derived
+// from seed, and checks the signature with the native Verify
+func SignEdDSA(curveID gurvy.ID, seed [32]byte, msg *big.Int) (Signature, error) {
+	var res Signature
+	switch curveID {
+	case gurvy.BN256:
+		m := message(msg, fr_bn256.Modulus(), fr_bn256.Bytes)
+		pub, priv := eddsa_bn256.New(seed, mimc_bn256.NewMiMC(hashSeed))
+		sig, err := eddsa_bn256.Sign(m, pub, priv)
+		if err != nil {
+			return res, err
+		}
+		if ok, err := eddsa_bn256.Verify(sig, m, pub); err != nil || !ok {
+			return res, errVerify
+		}
+		res.A, res.R = toBigInts(pub.A.X, pub.A.Y), toBigInts(sig.R.X, sig.R.Y)
+		res.S.Set(&sig.S)
+	case gurvy.BLS381:
+		m := message(msg, fr_bls381.Modulus(), fr_bls381.Bytes)
+		pub, priv := eddsa_bls381.New(seed, mimc_bls381.NewMiMC(hashSeed))
+		sig, err := eddsa_bls381.Sign(m, pub, priv)
+		if err != nil {
+			return res, err
+		}
+		if ok, err := eddsa_bls381.Verify(sig, m, pub); err != nil || !ok {
+			return res, errVerify
+		}
+		res.A, res.R = toBigInts(pub.A.X, pub.A.Y), toBigInts(sig.R.X, sig.R.Y)
+		res.S.Set(&sig.S)
+	case gurvy.BLS377:
+		m := message(msg, fr_bls377.Modulus(), fr_bls377.Bytes)
+		pub, priv := eddsa_bls377.New(seed, mimc_bls377.NewMiMC(hashSeed))
+		sig, err := eddsa_bls377.Sign(m, pub, priv)
+		if err != nil {
+			return res, err
+		}
+		if ok, err := eddsa_bls377.Verify(sig, m, pub); err != nil || !ok {
+			return res, errVerify
+		}
+		res.A, res.R = toBigInts(pub.A.X, pub.A.Y), toBigInts(sig.R.X, sig.R.Y)
+		res.S.Set(&sig.S)
+	case gurvy.BW761:
+		m := message(msg, fr_bw761.Modulus(), fr_bw761.Bytes)
+		pub, priv := eddsa_bw761.New(seed, mimc_bw761.NewMiMC(hashSeed))
+		sig, err := eddsa_bw761.Sign(m, pub, priv)
+		if err != nil {
+			return res, err
+		}
+		if ok, err := eddsa_bw761.Verify(sig, m, pub); err != nil || !ok {
+			return res, errVerify
+		}
+		res.A, res.R = toBigInts(pub.A.X, pub.A.Y), toBigInts(sig.R.X, sig.R.Y)
+		res.S.Set(&sig.S)
+	default:
+		return res, errUnknownCurve
+	}
+	return res, nil
+}
+
+// SignSchnorr signs msg (reduced modulo the scalar field, as in the circuit) with the schnorr key derived
+// from seed, and checks the signature with the native Verify
+func SignSchnorr(curveID gurvy.ID, seed [32]byte, msg *big.Int) (Signature, error) {
+	var res Signature
+	switch curveID {
+	case gurvy.BN256:
+		m := message(msg, fr_bn256.Modulus(), fr_bn256.Bytes)
+		pub, priv := schnorr_bn256.New(seed, mimc_bn256.NewMiMC(hashSeed))
+		sig, err := schnorr_bn256.Sign(m, pub, priv)
+		if err != nil {
+			return res, err
+		}
+		if ok, err := schnorr_bn256.Verify(sig, m, pub); err != nil || !ok {
+			return res, errVerify
+		}
+		res.A = toBigInts(pub.A.X, pub.A.Y)
+		res.E.Set(&sig.E)
+		res.S.Set(&sig.S)
+	case gurvy.BLS381:
+		m := message(msg, fr_bls381.Modulus(), fr_bls381.Bytes)
+		pub, priv := schnorr_bls381.New(seed, mimc_bls381.NewMiMC(hashSeed))
+		sig, err := schnorr_bls381.Sign(m, pub, priv)
+		if err != nil {
+			return res, err
+		}
+		if ok, err := schnorr_bls381.Verify(sig, m, pub); err != nil || !ok {
+			return res, errVerify
+		}
+		res.A = toBigInts(pub.A.X, pub.A.Y)
+		res.E.Set(&sig.E)
+		res.S.Set(&sig.S)
+	case gurvy.BLS377:
+		m := message(msg, fr_bls377.Modulus(), fr_bls377.Bytes)
+		pub, priv := schnorr_bls377.New(seed, mimc_bls377.NewMiMC(hashSeed))
+		sig, err := schnorr_bls377.Sign(m, pub, priv)
+		if err != nil {
+			return res, err
+		}
+		if ok, err := schnorr_bls377.Verify(sig, m, pub); err != nil || !ok {
+			return res, errVerify
+		}
+		res.A = toBigInts(pub.A.X, pub.A.Y)
+		res.E.Set(&sig.E)
+		res.S.Set(&sig.S)
+	case gurvy.BW761:
+		m := message(msg, fr_bw761.Modulus(), fr_bw761.Bytes)
+		pub, priv := schnorr_bw761.New(seed, mimc_bw761.NewMiMC(hashSeed))
+		sig, err := schnorr_bw761.Sign(m, pub, priv)
+		if err != nil {
+			return res, err
+		}
+		if ok, err := schnorr_bw761.Verify(sig, m, pub); err != nil || !ok {
+			return res, errVerify
+		}
+		res.A = toBigInts(pub.A.X, pub.A.Y)
+		res.E.Set(&sig.E)
+		res.S.Set(&sig.S)
+	default:
+		return res, errUnknownCurve
+	}
+	return res, nil
+}
+
+// Base returns the base point of the twisted Edwards curve
+func Base(curveID gurvy.ID) ([2]big.Int, error) {
+	switch curveID {
+	case gurvy.BN256:
+		base := edbn256.GetEdwardsCurve().Base
+		return toBigInts(base.X, base.Y), nil
+	case gurvy.BLS381:
+		base := edbls381.GetEdwardsCurve().Base
+		return toBigInts(base.X, base.Y), nil
+	case gurvy.BLS377:
+		base := edbls377.GetEdwardsCurve().Base
+		return toBigInts(base.X, base.Y), nil
+	case gurvy.BW761:
+		base := edbw761.GetEdwardsCurve().Base
+		return toBigInts(base.X, base.Y), nil
+	}
+	return [2]big.Int{}, errUnknownCurve
+}
+
+// ScalarMulAdd returns s*p + q on the twisted Edwards curve, the points are on the curve (q = (0, 1)
+// gives s*p)
+func ScalarMulAdd(curveID gurvy.ID, s *big.Int, p, q [2]big.Int) ([2]big.Int, error) {
+	switch curveID {
+	case gurvy.BN256:
+		var _p, _q edbn256.Point
+		_p.X.SetBigInt(&p[0])
+		_p.Y.SetBigInt(&p[1])
+		_q.X.SetBigInt(&q[0])
+		_q.Y.SetBigInt(&q[1])
+		_p.ScalarMul(&_p, s).Add(&_p, &_q)
+		return toBigInts(_p.X, _p.Y), nil
+	case gurvy.BLS381:
+		var _p, _q edbls381.Point
+		_p.X.SetBigInt(&p[0])
+		_p.Y.SetBigInt(&p[1])
+		_q.X.SetBigInt(&q[0])
+		_q.Y.SetBigInt(&q[1])
+		_p.ScalarMul(&_p, s).Add(&_p, &_q)
+		return toBigInts(_p.X, _p.Y), nil
+	case gurvy.BLS377:
+		var _p, _q edbls377.Point
+		_p.X.SetBigInt(&p[0])
+		_p.Y.SetBigInt(&p[1])
+		_q.X.SetBigInt(&q[0])
+		_q.Y.SetBigInt(&q[1])
+		_p.ScalarMul(&_p, s).Add(&_p, &_q)
+		return toBigInts(_p.X, _p.Y), nil
+	case gurvy.BW761:
+		var _p, _q edbw761.Point
+		_p.X.SetBigInt(&p[0])
+		_p.Y.SetBigInt(&p[1])
+		_q.X.SetBigInt(&q[0])
+		_q.Y.SetBigInt(&q[1])
+		_p.ScalarMul(&_p, s).Add(&_p, &_q)
+		return toBigInts(_p.X, _p.Y), nil
+	}
+	return [2]big.Int{}, errUnknownCurve
+}
+
+// message returns the bytes of msg mod r, as fr.Element.Bytes does
+func message(msg, r *big.Int, size int) []byte {
+	return new(big.Int).Mod(msg, r).FillBytes(make([]byte, size))
+}
+
+// toBigInts returns the coordinates x, y (field elements) in regular form
+func toBigInts(x, y interface{}) [2]big.Int {
+	return [2]big.Int{backend.FromInterface(x), backend.FromInterface(y)}
+}
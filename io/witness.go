@@ -12,6 +12,7 @@ import (
 )
 
 // TODO this is deprecated, we might need a type Witness = map[string]interface{}
+// see backend/witness for a compact binary encoding distinguishing public and secret inputs
 
 // WriteWitness serialize variable map[name]value into writer
 //
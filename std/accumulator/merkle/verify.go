@@ -143,3 +143,27 @@ func VerifyProof(cs *frontend.ConstraintSystem, h mimc.MiMC, merkleRoot frontend
 	cs.AssertIsEqual(sum, merkleRoot)
 
 }
+
+// VerifyProofAtIndex is like VerifyProof, but the helper is derived in the circuit from the binary
+// decomposition of leafIndex, such that the proof is bound to the position of the leaf.
+//
+// The tree must be complete, that is have 2^(len(proofSet)-1) leaves, since the position of the
+// elevated orphans of an incomplete tree depends on the number of leaves. leafIndex is
+// constrained to be less than the number of leaves.
+func VerifyProofAtIndex(cs *frontend.ConstraintSystem, h mimc.MiMC, merkleRoot frontend.Variable, proofSet []frontend.Variable, leafIndex frontend.Variable) {
+
+	path := cs.ToBinary(leafIndex, len(proofSet)-1)
+
+	sum := leafSum(cs, h, proofSet[0])
+
+	for i := 1; i < len(proofSet); i++ {
+		// bit i-1 of the index is set if the node is a right child (helper 0)
+		d1 := cs.Select(path[i-1], proofSet[i], sum)
+		d2 := cs.Select(path[i-1], sum, proofSet[i])
+		sum = nodeSum(cs, h, d1, d2)
+	}
+
+	// Compare our calculated Merkle root to the desired Merkle root.
+	cs.AssertIsEqual(sum, merkleRoot)
+
+}
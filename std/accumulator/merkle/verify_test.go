@@ -96,3 +96,56 @@ func TestVerify(t *testing.T) {
 	assert := groth16.NewAssert(t)
 	assert.ProverSucceeded(r1cs, assignment)
 }
+
+type merkleAtIndexCircuit struct {
+	RootHash  frontend.Variable `gnark:",public"`
+	LeafIndex frontend.Variable `gnark:",public"`
+	Path      [4]frontend.Variable
+}
+
+func (circuit *merkleAtIndexCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	hFunc, err := mimc.NewMiMC("seed", curveID)
+	if err != nil {
+		return err
+	}
+	VerifyProofAtIndex(cs, hFunc, circuit.RootHash, circuit.Path[:], circuit.LeafIndex)
+	return nil
+}
+
+func TestVerifyAtIndex(t *testing.T) {
+	// complete tree of 8 leaves
+	var buf bytes.Buffer
+	for i := 0; i < 8; i++ {
+		var leaf fr.Element
+		if _, err := leaf.SetRandom(); err != nil {
+			t.Fatal(err)
+		}
+		b := leaf.Bytes()
+		buf.Write(b[:])
+	}
+
+	proofIndex := uint64(5)
+	merkleRoot, proof, _, err := merkletree.BuildReaderProof(&buf, bn256.NewMiMC("seed"), 32, proofIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var circuit, good, bad merkleAtIndexCircuit
+	r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	good.RootHash.Assign(merkleRoot)
+	good.LeafIndex.Assign(proofIndex)
+	bad.RootHash.Assign(merkleRoot)
+	bad.LeafIndex.Assign(proofIndex - 1)
+	for i := 0; i < len(proof); i++ {
+		good.Path[i].Assign(proof[i])
+		bad.Path[i].Assign(proof[i])
+	}
+
+	assert := groth16.NewAssert(t)
+	assert.SolvingSucceeded(r1cs, &good)
+	assert.SolvingFailed(r1cs, &bad)
+}
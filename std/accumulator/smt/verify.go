@@ -0,0 +1,69 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package smt verifies proofs of the fixed depth sparse Merkle tree of crypto/accumulator/smt
+//
+// The depth of the tree is the length of the proof set. The key is decomposed in depth bits,
+// which constrains it to be less than 2^depth. A key which is not set maps to the zero value, which
+// can't be stored in the tree.
+package smt
+
+import (
+	"github.com/consensys/gnark/crypto/accumulator/smt"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// VerifyInclusion asserts that value is stored at key in the tree whose root is merkleRoot.
+// proofSet contains the siblings of the nodes on the path from the leaf to the root.
+//
+// value is asserted to be non zero, as the zero value marks a key which is not set.
+func VerifyInclusion(cs *frontend.ConstraintSystem, h mimc.MiMC, merkleRoot, key, value frontend.Variable, proofSet []frontend.Variable) {
+	cs.Inverse(value)
+	path := cs.ToBinary(key, len(proofSet))
+	cs.AssertIsEqual(root(cs, h, path, value, proofSet), merkleRoot)
+}
+
+// VerifyExclusion asserts that key is not set (its leaf stores the zero value) in the tree whose
+// root is merkleRoot
+func VerifyExclusion(cs *frontend.ConstraintSystem, h mimc.MiMC, merkleRoot, key frontend.Variable, proofSet []frontend.Variable) {
+	path := cs.ToBinary(key, len(proofSet))
+	cs.AssertIsEqual(root(cs, h, path, cs.Constant(0), proofSet), merkleRoot)
+}
+
+// VerifyUpdate asserts that setting key from oldValue to newValue changes the root of the tree
+// from oldRoot to newRoot. Both roots are computed from the same proof set, such that no other
+// leaf is modified.
+//
+// An insertion is an update where oldValue is 0, a removal an update where newValue is 0.
+func VerifyUpdate(cs *frontend.ConstraintSystem, h mimc.MiMC, oldRoot, newRoot, key, oldValue, newValue frontend.Variable, proofSet []frontend.Variable) {
+	path := cs.ToBinary(key, len(proofSet))
+	cs.AssertIsEqual(root(cs, h, path, oldValue, proofSet), oldRoot)
+	cs.AssertIsEqual(root(cs, h, path, newValue, proofSet), newRoot)
+}
+
+// root returns the root of the tree computed from the leaf storing value, following path
+// (bit i set means the node at height i is a right child). Leaves and nodes are hashed with
+// the tags smt.LeafTag and smt.NodeTag.
+func root(cs *frontend.ConstraintSystem, h mimc.MiMC, path []frontend.Variable, value frontend.Variable, proofSet []frontend.Variable) frontend.Variable {
+	sum := h.Hash(cs, cs.Constant(smt.LeafTag), value)
+	for i := 0; i < len(proofSet); i++ {
+		left := cs.Select(path[i], proofSet[i], sum)
+		right := cs.Select(path[i], sum, proofSet[i])
+		sum = h.Hash(cs, cs.Constant(smt.NodeTag), left, right)
+	}
+	return sum
+}
@@ -0,0 +1,213 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package smt
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark/backend/groth16"
+	smt "github.com/consensys/gnark/crypto/accumulator/smt/bn256"
+	"github.com/consensys/gnark/crypto/hash/mimc/bn256"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gurvy"
+	"github.com/consensys/gurvy/bn256/fr"
+)
+
+const depth = 8
+
+type inclusionCircuit struct {
+	Root, Key, Value frontend.Variable `gnark:",public"`
+	Path             [depth]frontend.Variable
+}
+
+func (circuit *inclusionCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	h, err := mimc.NewMiMC("seed", curveID)
+	if err != nil {
+		return err
+	}
+	VerifyInclusion(cs, h, circuit.Root, circuit.Key, circuit.Value, circuit.Path[:])
+	return nil
+}
+
+type exclusionCircuit struct {
+	Root, Key frontend.Variable `gnark:",public"`
+	Path      [depth]frontend.Variable
+}
+
+func (circuit *exclusionCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	h, err := mimc.NewMiMC("seed", curveID)
+	if err != nil {
+		return err
+	}
+	VerifyExclusion(cs, h, circuit.Root, circuit.Key, circuit.Path[:])
+	return nil
+}
+
+type updateCircuit struct {
+	OldRoot, NewRoot frontend.Variable `gnark:",public"`
+	Key              frontend.Variable
+	OldValue         frontend.Variable
+	NewValue         frontend.Variable
+	Path             [depth]frontend.Variable
+}
+
+func (circuit *updateCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	h, err := mimc.NewMiMC("seed", curveID)
+	if err != nil {
+		return err
+	}
+	VerifyUpdate(cs, h, circuit.OldRoot, circuit.NewRoot, circuit.Key, circuit.OldValue, circuit.NewValue, circuit.Path[:])
+	return nil
+}
+
+// element returns the field element v
+func element(v uint64) fr.Element {
+	var e fr.Element
+	e.SetUint64(v)
+	return e
+}
+
+// newTree returns a tree with keys 3 and 200 set
+func newTree(t *testing.T) *smt.Tree {
+	tree, err := smt.New(bn256.NewMiMC("seed"), depth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tree.Set(element(3), element(42)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tree.Set(element(200), element(7)); err != nil {
+		t.Fatal(err)
+	}
+	return tree
+}
+
+func TestVerifyInclusion(t *testing.T) {
+	tree := newTree(t)
+	value, proof, err := tree.Prove(element(200))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var circuit, good, bad inclusionCircuit
+	r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	good.Root.Assign(tree.Root())
+	good.Key.Assign(200)
+	good.Value.Assign(value)
+	bad.Root.Assign(tree.Root())
+	bad.Key.Assign(201)
+	bad.Value.Assign(value)
+	for i := 0; i < depth; i++ {
+		good.Path[i].Assign(proof[i])
+		bad.Path[i].Assign(proof[i])
+	}
+
+	assert := groth16.NewAssert(t)
+	assert.SolvingSucceeded(r1cs, &good)
+	assert.SolvingFailed(r1cs, &bad)
+
+	// the zero value of a key which is not set is not an inclusion
+	proof17, err := tree.ProveNonMembership(element(17))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var zero inclusionCircuit
+	zero.Root.Assign(tree.Root())
+	zero.Key.Assign(17)
+	zero.Value.Assign(0)
+	for i := 0; i < depth; i++ {
+		zero.Path[i].Assign(proof17[i])
+	}
+	assert.SolvingFailed(r1cs, &zero)
+}
+
+func TestVerifyExclusion(t *testing.T) {
+	tree := newTree(t)
+	proof, err := tree.ProveNonMembership(element(17))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var circuit, good, bad exclusionCircuit
+	r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	good.Root.Assign(tree.Root())
+	good.Key.Assign(17)
+	bad.Root.Assign(tree.Root())
+	bad.Key.Assign(3)
+	_, proof3, err := tree.Prove(element(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < depth; i++ {
+		good.Path[i].Assign(proof[i])
+		bad.Path[i].Assign(proof3[i])
+	}
+
+	assert := groth16.NewAssert(t)
+	assert.SolvingSucceeded(r1cs, &good)
+	assert.SolvingFailed(r1cs, &bad)
+}
+
+func TestVerifyUpdate(t *testing.T) {
+	var circuit updateCircuit
+	r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert := groth16.NewAssert(t)
+
+	// insertion of key 17, then update of key 3
+	tree := newTree(t)
+	for _, u := range []struct{ key, value uint64 }{{17, 5}, {3, 43}} {
+		oldRoot := tree.Root()
+		oldValue, proof, err := tree.Prove(element(u.key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tree.Set(element(u.key), element(u.value)); err != nil {
+			t.Fatal(err)
+		}
+
+		var good, bad updateCircuit
+		good.OldRoot.Assign(oldRoot)
+		good.NewRoot.Assign(tree.Root())
+		good.Key.Assign(u.key)
+		good.OldValue.Assign(oldValue)
+		good.NewValue.Assign(u.value)
+		bad.OldRoot.Assign(oldRoot)
+		bad.NewRoot.Assign(tree.Root())
+		bad.Key.Assign(u.key)
+		bad.OldValue.Assign(oldValue)
+		bad.NewValue.Assign(u.value + 1)
+		for i := 0; i < depth; i++ {
+			good.Path[i].Assign(proof[i])
+			bad.Path[i].Assign(proof[i])
+		}
+
+		assert.SolvingSucceeded(r1cs, &good)
+		assert.SolvingFailed(r1cs, &bad)
+	}
+}
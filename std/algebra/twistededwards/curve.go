@@ -21,10 +21,14 @@ import (
 	"math/big"
 
 	"github.com/consensys/gnark/backend"
+	edbls377 "github.com/consensys/gnark/crypto/algebra/twistededwards/bls377"
+	edbw761 "github.com/consensys/gnark/crypto/algebra/twistededwards/bw761"
 	"github.com/consensys/gurvy"
+	frbls377 "github.com/consensys/gurvy/bls377/fr"
 	edbls381 "github.com/consensys/gurvy/bls381/twistededwards"
 	"github.com/consensys/gurvy/bn256/fr"
 	edbn256 "github.com/consensys/gurvy/bn256/twistededwards"
+	frbw761 "github.com/consensys/gurvy/bw761/fr"
 )
 
 // EdCurve stores the info on the chosen edwards curve
@@ -39,6 +43,8 @@ func init() {
 	newTwistedEdwards = make(map[gurvy.ID]func() EdCurve)
 	newTwistedEdwards[gurvy.BLS381] = newEdBLS381
 	newTwistedEdwards[gurvy.BN256] = newEdBN256
+	newTwistedEdwards[gurvy.BLS377] = newEdBLS377
+	newTwistedEdwards[gurvy.BW761] = newEdBW761
 }
 
 // NewEdCurve returns an Edwards curve parameters
@@ -94,3 +100,67 @@ func newEdBLS381() EdCurve {
 	return res
 
 }
+
+func newEdBLS377() EdCurve {
+
+	edcurve := edbls377.GetEdwardsCurve()
+	var cofactorReg big.Int
+	edcurve.Cofactor.ToBigInt(&cofactorReg)
+
+	res := EdCurve{
+		A:        backend.FromInterface(edcurve.A),
+		D:        backend.FromInterface(edcurve.D),
+		Cofactor: backend.FromInterface(cofactorReg),
+		Order:    backend.FromInterface(edcurve.Order),
+		BaseX:    backend.FromInterface(edcurve.Base.X),
+		BaseY:    backend.FromInterface(edcurve.Base.Y),
+		ID:       gurvy.BLS377,
+	}
+	res.Modulus.Set(frbls377.Modulus())
+
+	return res
+
+}
+
+func newEdBW761() EdCurve {
+
+	edcurve := edbw761.GetEdwardsCurve()
+	var cofactorReg big.Int
+	edcurve.Cofactor.ToBigInt(&cofactorReg)
+
+	res := EdCurve{
+		A:        backend.FromInterface(edcurve.A),
+		D:        backend.FromInterface(edcurve.D),
+		Cofactor: backend.FromInterface(cofactorReg),
+		Order:    backend.FromInterface(edcurve.Order),
+		BaseX:    backend.FromInterface(edcurve.Base.X),
+		BaseY:    backend.FromInterface(edcurve.Base.Y),
+		ID:       gurvy.BW761,
+	}
+	res.Modulus.Set(frbw761.Modulus())
+
+	return res
+
+}
+
+// add returns p1+p2, p1 and p2 being constant points (x, y) on the curve
+func (curve EdCurve) add(p1, p2 [2]big.Int) [2]big.Int {
+
+	var xu, yv, xv, yu, dxyuv, one, den big.Int
+	var res [2]big.Int
+
+	xv.Mul(&p1[0], &p2[1])
+	yu.Mul(&p1[1], &p2[0])
+	xu.Mul(&p1[0], &p2[0]).Mul(&xu, &curve.A)
+	yv.Mul(&p1[1], &p2[1])
+	dxyuv.Mul(&xv, &yu).Mul(&dxyuv, &curve.D).Mod(&dxyuv, &curve.Modulus)
+	one.SetUint64(1)
+
+	// x = (xv+yu)/(1+dxyuv), y = (yv-axu)/(1-dxyuv)
+	den.Add(&one, &dxyuv).ModInverse(&den, &curve.Modulus)
+	res[0].Add(&xv, &yu).Mul(&res[0], &den).Mod(&res[0], &curve.Modulus)
+	den.Sub(&one, &dxyuv).Mod(&den, &curve.Modulus).ModInverse(&den, &curve.Modulus)
+	res[1].Sub(&yv, &xu).Mul(&res[1], &den).Mod(&res[1], &curve.Modulus)
+
+	return res
+}
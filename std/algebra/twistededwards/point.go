@@ -19,6 +19,7 @@ package twistededwards
 import (
 	"math/big"
 
+	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/frontend"
 )
 
@@ -110,7 +111,7 @@ func (p *Point) Double(cs *frontend.ConstraintSystem, p1 *Point, curve EdCurve)
 func (p *Point) ScalarMulNonFixedBase(cs *frontend.ConstraintSystem, p1 *Point, scalar frontend.Variable, curve EdCurve) *Point {
 
 	// first unpack the scalar
-	b := cs.ToBinary(scalar, 256)
+	b := cs.ToBinary(scalar, curve.Modulus.BitLen())
 
 	res := Point{
 		cs.Constant(0),
@@ -131,26 +132,151 @@ func (p *Point) ScalarMulNonFixedBase(cs *frontend.ConstraintSystem, p1 *Point,
 }
 
 // ScalarMulFixedBase computes the scalar multiplication of a point on a twisted Edwards curve
-// x, y: coordinates of the base point
+// x, y: coordinates of the base point, which must be constants
 // curve: parameters of the Edwards curve
 // scal: scalar as a SNARK constraint
-// Standard left to right double and add
+// Windowed, with windows of 2 bits: the multiples k*4^i*base (k=0..3) are precomputed, such that
+// no doubling is needed, and each window costs a lookup (1 constraint) and an addition
 func (p *Point) ScalarMulFixedBase(cs *frontend.ConstraintSystem, x, y interface{}, scalar frontend.Variable, curve EdCurve) *Point {
 
 	// first unpack the scalar
-	b := cs.ToBinary(scalar, 256)
+	b := cs.ToBinary(scalar, curve.Modulus.BitLen())
+
+	return p.ScalarMulFixedBaseBits(cs, x, y, b, curve)
+}
+
+// ScalarMulFixedBaseBits computes the scalar multiplication of a constant point, like ScalarMulFixedBase,
+// the scalar being given by its binary decomposition (little endian, as returned by ToBinary)
+// which allows scalars larger than the field modulus. The bits must be boolean constrained.
+func (p *Point) ScalarMulFixedBaseBits(cs *frontend.ConstraintSystem, x, y interface{}, b []frontend.Variable, curve EdCurve) *Point {
+
+	// base = 4^i*(x, y), for the window i
+	var base [2]big.Int
+	base[0] = backend.FromInterface(x)
+	base[1] = backend.FromInterface(y)
+
+	var res Point
+	for i := 0; i < len(b); i += 2 {
+
+		// table of the multiples 0, base, 2*base, 3*base
+		var table [4][2]big.Int
+		table[0][1].SetUint64(1)
+		table[1] = base
+		table[2] = curve.add(base, base)
+		table[3] = curve.add(table[2], base)
+
+		var tmp Point
+		if i+1 < len(b) {
+			tmp.lookup2(cs, b[i], b[i+1], table)
+		} else {
+			tmp.X = cs.Select(b[i], table[1][0], table[0][0]) // no constraint is recorded
+			tmp.Y = cs.Select(b[i], table[1][1], table[0][1]) // no constraint is recorded
+		}
+
+		if i == 0 {
+			res = tmp
+		} else {
+			res.AddGeneric(cs, &res, &tmp, curve)
+		}
+
+		base = curve.add(table[2], table[2])
+	}
+
+	p.X = res.X
+	p.Y = res.Y
+
+	return p
+}
+
+// DoubleBaseScalarMul computes s1*p1 + s2*p2 on a twisted Edwards curve, sharing the doublings (Shamir's trick)
+// p1, p2: points (as snark points)
+// s1, s2: scalars as SNARK constraints
+// curve: parameters of the Edwards curve
+// At each bit, the point to add is looked up among (0, p1, p2, p1+p2)
+func (p *Point) DoubleBaseScalarMul(cs *frontend.ConstraintSystem, p1 *Point, s1 frontend.Variable, p2 *Point, s2 frontend.Variable, curve EdCurve) *Point {
+
+	// first unpack the scalars
+	b1 := cs.ToBinary(s1, curve.Modulus.BitLen())
+	b2 := cs.ToBinary(s2, curve.Modulus.BitLen())
+
+	var p12 Point
+	p12.AddGeneric(cs, p1, p2, curve)
 
 	res := Point{
 		cs.Constant(0),
 		cs.Constant(1),
 	}
 
-	for i := len(b) - 1; i >= 0; i-- {
+	for i := len(b1) - 1; i >= 0; i-- {
 		res.Double(cs, &res, curve)
-		tmp := Point{}
-		tmp.AddFixedPoint(cs, &res, x, y, curve)
-		res.X = cs.Select(b[i], tmp.X, res.X)
-		res.Y = cs.Select(b[i], tmp.Y, res.Y)
+		tmp := Point{
+			cs.Lookup2(b1[i], b2[i], 0, p1.X, p2.X, p12.X),
+			cs.Lookup2(b1[i], b2[i], 1, p1.Y, p2.Y, p12.Y),
+		}
+		res.AddGeneric(cs, &res, &tmp, curve)
+	}
+
+	p.X = res.X
+	p.Y = res.Y
+
+	return p
+}
+
+// lookup2 sets p to table[b0 + 2*b1], table being constant points
+// 1 constraint (b0*b1), the coordinates are linear in b0, b1 and b0*b1
+func (p *Point) lookup2(cs *frontend.ConstraintSystem, b0, b1 frontend.Variable, table [4][2]big.Int) *Point {
+
+	b0b1 := cs.Mul(b0, b1)
+
+	var coords [2]frontend.Variable
+	for j := 0; j < 2; j++ {
+		// t0 + b0*(t1-t0) + b1*(t2-t0) + b0*b1*(t3-t2-t1+t0)
+		var c1, c2, c3 big.Int
+		c1.Sub(&table[1][j], &table[0][j])
+		c2.Sub(&table[2][j], &table[0][j])
+		c3.Sub(&table[3][j], &table[2][j]).Sub(&c3, &table[1][j]).Add(&c3, &table[0][j])
+		coords[j] = cs.Add(cs.Mul(b0, c1), cs.Mul(b1, c2), cs.Mul(b0b1, c3), table[0][j])
+	}
+
+	p.X = coords[0]
+	p.Y = coords[1]
+
+	return p
+}
+
+// MultiScalarMul computes the sum of the scalar multiplications [scalars[i]]points[i], sharing the
+// doublings between all the points (Straus' algorithm)
+// points: points on the twisted Edwards curve (constant points are cheaper to add)
+// scalars: binary decomposition of the scalars, little endian (as returned by ToBinary)
+// curve: parameters of the Edwards curve
+// The scalars may have different lengths, which allows scalars larger than the field modulus.
+func (p *Point) MultiScalarMul(cs *frontend.ConstraintSystem, points []Point, scalars [][]frontend.Variable, curve EdCurve) *Point {
+
+	nbBits := 0
+	for i := 0; i < len(scalars); i++ {
+		if len(scalars[i]) > nbBits {
+			nbBits = len(scalars[i])
+		}
+	}
+
+	res := Point{
+		cs.Constant(0),
+		cs.Constant(1),
+	}
+
+	for j := nbBits - 1; j >= 0; j-- {
+		if j != nbBits-1 {
+			res.Double(cs, &res, curve)
+		}
+		for i := 0; i < len(points); i++ {
+			if j >= len(scalars[i]) {
+				continue
+			}
+			tmp := Point{}
+			tmp.AddGeneric(cs, &res, &points[i], curve)
+			res.X = cs.Select(scalars[i][j], tmp.X, res.X)
+			res.Y = cs.Select(scalars[i][j], tmp.Y, res.Y)
+		}
 	}
 
 	p.X = res.X
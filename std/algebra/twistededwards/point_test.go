@@ -17,10 +17,12 @@ limitations under the License.
 package twistededwards
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark/backend/groth16"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/testutil"
 	"github.com/consensys/gurvy"
 )
 
@@ -214,3 +216,156 @@ func TestScalarMul(t *testing.T) {
 	assert.SolvingSucceeded(r1cs, &witness)
 
 }
+
+type fixedBaseMul struct {
+	S frontend.Variable
+	R Point
+}
+
+func (circuit *fixedBaseMul) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	params, err := NewEdCurve(curveID)
+	if err != nil {
+		return err
+	}
+	var res Point
+	res.ScalarMulFixedBase(cs, params.BaseX, params.BaseY, circuit.S, params)
+	cs.AssertIsEqual(res.X, circuit.R.X)
+	cs.AssertIsEqual(res.Y, circuit.R.Y)
+	return nil
+}
+
+type doubleBaseMul struct {
+	S1, S2    frontend.Variable
+	P1, P2, R Point
+}
+
+func (circuit *doubleBaseMul) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	params, err := NewEdCurve(curveID)
+	if err != nil {
+		return err
+	}
+	var res Point
+	res.DoubleBaseScalarMul(cs, &circuit.P1, circuit.S1, &circuit.P2, circuit.S2, params)
+	cs.AssertIsEqual(res.X, circuit.R.X)
+	cs.AssertIsEqual(res.Y, circuit.R.Y)
+	return nil
+}
+
+// twoScalarMul computes the same as doubleBaseMul, with two scalar multiplications
+type twoScalarMul doubleBaseMul
+
+func (circuit *twoScalarMul) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	params, err := NewEdCurve(curveID)
+	if err != nil {
+		return err
+	}
+	var res, tmp Point
+	res.ScalarMulNonFixedBase(cs, &circuit.P1, circuit.S1, params)
+	tmp.ScalarMulNonFixedBase(cs, &circuit.P2, circuit.S2, params)
+	res.AddGeneric(cs, &res, &tmp, params)
+	cs.AssertIsEqual(res.X, circuit.R.X)
+	cs.AssertIsEqual(res.Y, circuit.R.Y)
+	return nil
+}
+
+// nativeScalarMul returns, computed with the twisted Edwards curve of the crypto libs, the base point B,
+// P = s3*B, s1*B and s1*B + s2*P
+func nativeScalarMul(t *testing.T, curveID gurvy.ID, s1, s2, s3 *big.Int) (b, p, r1, r2 [2]big.Int) {
+	var identity [2]big.Int
+	identity[1].SetUint64(1)
+
+	b, err := testutil.Base(curveID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, err = testutil.ScalarMulAdd(curveID, s3, b, identity); err != nil {
+		t.Fatal(err)
+	}
+	if r1, err = testutil.ScalarMulAdd(curveID, s1, b, identity); err != nil {
+		t.Fatal(err)
+	}
+	if r2, err = testutil.ScalarMulAdd(curveID, s2, p, r1); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestScalarMulWindowed(t *testing.T) {
+
+	assert := groth16.NewAssert(t)
+
+	curves := []gurvy.ID{gurvy.BN256, gurvy.BLS381, gurvy.BLS377, gurvy.BW761}
+	for _, curve := range curves {
+		params, err := NewEdCurve(curve)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var s1, s2 big.Int
+		s1.Sub(&params.Order, big.NewInt(28242048))
+		s2.SetString("15132049151119024294202596478829150741889300374007672163496852915064", 10)
+		b, p, r1, r2 := nativeScalarMul(t, curve, &s1, &s2, big.NewInt(7))
+
+		// fixed base
+		{
+			var circuit, good, bad fixedBaseMul
+			r1cs, err := frontend.Compile(curve, &circuit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			good.S.Assign(s1)
+			good.R.X.Assign(r1[0])
+			good.R.Y.Assign(r1[1])
+			bad.S.Assign(s2)
+			bad.R.X.Assign(r1[0])
+			bad.R.Y.Assign(r1[1])
+			assert.SolvingSucceeded(r1cs, &good)
+			assert.SolvingFailed(r1cs, &bad)
+		}
+
+		// double base
+		for _, circuit := range []frontend.Circuit{&doubleBaseMul{}, &twoScalarMul{}} {
+			var good, bad doubleBaseMul
+			for _, w := range []*doubleBaseMul{&good, &bad} {
+				w.S1.Assign(s1)
+				w.P1.X.Assign(b[0])
+				w.P1.Y.Assign(b[1])
+				w.P2.X.Assign(p[0])
+				w.P2.Y.Assign(p[1])
+				w.R.X.Assign(r2[0])
+				w.R.Y.Assign(r2[1])
+			}
+			good.S2.Assign(s2)
+			bad.S2.Assign(s1)
+			r1cs, err := frontend.Compile(curve, circuit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.SolvingSucceeded(r1cs, &good)
+			assert.SolvingFailed(r1cs, &bad)
+		}
+	}
+}
+
+func TestScalarMulConstraints(t *testing.T) {
+
+	nbConstraints := func(circuit frontend.Circuit) uint64 {
+		r1cs, err := frontend.Compile(gurvy.BN256, circuit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r1cs.GetNbConstraints()
+	}
+
+	twoMuls := nbConstraints(&twoScalarMul{})
+	fixed := nbConstraints(&fixedBaseMul{})
+	doubleBase := nbConstraints(&doubleBaseMul{})
+
+	// windowed fixed base is more than 3 times cheaper than a (non fixed base) scalar multiplication
+	if 3*fixed > twoMuls/2 {
+		t.Fatalf("fixed base: %d constraints, non fixed base: about %d constraints", fixed, twoMuls/2)
+	}
+	// Shamir's trick saves the doublings of a scalar multiplication
+	if 3*doubleBase > 2*twoMuls {
+		t.Fatalf("double base: %d constraints, two scalar multiplications: %d constraints", doubleBase, twoMuls)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package keccak provides the Keccak-256 hash function used by Ethereum as a gnark circuit gadget
+//
+// This is the original Keccak padding (golang.org/x/crypto/sha3.NewLegacyKeccak256), not SHA3-256.
+package keccak
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// Size is the size of a Keccak-256 digest in bytes
+const Size = 32
+
+// rate of Keccak-256 in bytes (1600 - 2*256 bits)
+const rate = 136
+
+var roundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+	0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+	0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// rotation offsets of ρ, indexed by x + 5*y
+var rotations = [25]int{
+	0, 1, 62, 28, 27,
+	36, 44, 6, 55, 20,
+	3, 10, 43, 25, 39,
+	41, 45, 15, 21, 8,
+	18, 2, 61, 56, 14,
+}
+
+// Sum256 returns the Keccak-256 digest of data, as Size bytes
+//
+// each byte of data is constrained to be less than 256. The length of data is known when the
+// circuit is compiled, such that the padding doesn't cost any constraint.
+//
+// A block of 136 bytes costs about 155000 constraints.
+func Sum256(cs *frontend.ConstraintSystem, data []frontend.Variable) [Size]frontend.Variable {
+
+	// range check the input and append the padding 0x01 || 0x00... || 0x80
+	msg := make([]uints.U8, len(data), len(data)+rate)
+	for i := 0; i < len(data); i++ {
+		msg[i].SetVariable(cs, data[i])
+	}
+	padding := make([]uint8, rate-len(data)%rate)
+	padding[0] |= 0x01
+	padding[len(padding)-1] |= 0x80
+	for _, b := range padding {
+		var u uints.U8
+		msg = append(msg, *u.SetConstant(cs, b))
+	}
+
+	var state [25]uints.U64
+	for i := 0; i < len(msg); i += rate {
+		for j := 0; j < rate/8; j++ {
+			lane := fromBytes(msg[i+8*j : i+8*j+8])
+			if i == 0 {
+				state[j] = lane
+			} else {
+				state[j].Xor(cs, state[j], lane)
+			}
+		}
+		if i == 0 {
+			for j := rate / 8; j < 25; j++ {
+				state[j].SetConstant(cs, 0)
+			}
+		}
+		permutation(cs, &state)
+	}
+
+	var digest [Size]frontend.Variable
+	for i := 0; i < Size/8; i++ {
+		for j := 0; j < 8; j++ {
+			var b uints.U8
+			copy(b[:], state[i][8*j:8*j+8])
+			digest[8*i+j] = b.ToVariable(cs)
+		}
+	}
+	return digest
+}
+
+// permutation applies Keccak-f[1600] to the state, lane (x, y) is at index x + 5*y
+func permutation(cs *frontend.ConstraintSystem, a *[25]uints.U64) {
+	for r := 0; r < 24; r++ {
+		// θ
+		var c [5]uints.U64
+		for x := 0; x < 5; x++ {
+			c[x].Xor(cs, a[x], a[x+5])
+			c[x].Xor(cs, c[x], a[x+10])
+			c[x].Xor(cs, c[x], a[x+15])
+			c[x].Xor(cs, c[x], a[x+20])
+		}
+		for x := 0; x < 5; x++ {
+			var d uints.U64
+			d.RotateLeft(cs, c[(x+1)%5], 1)
+			d.Xor(cs, c[(x+4)%5], d)
+			for y := 0; y < 25; y += 5 {
+				a[x+y].Xor(cs, a[x+y], d)
+			}
+		}
+
+		// ρ and π: b[y, 2x+3y] = a[x, y] <<< rotations[x, y]
+		var b [25]uints.U64
+		for x := 0; x < 5; x++ {
+			for y := 0; y < 5; y++ {
+				b[y+5*((2*x+3*y)%5)].RotateLeft(cs, a[x+5*y], rotations[x+5*y])
+			}
+		}
+
+		// χ
+		for y := 0; y < 25; y += 5 {
+			for x := 0; x < 5; x++ {
+				var t uints.U64
+				t.Not(cs, b[(x+1)%5+y])
+				t.And(cs, t, b[(x+2)%5+y])
+				a[x+y].Xor(cs, b[x+y], t)
+			}
+		}
+
+		// ι, a xor with a constant only negates bits (no constraint is recorded)
+		for i := 0; i < 64; i++ {
+			if (roundConstants[r]>>uint(i))&1 == 1 {
+				a[0][i] = cs.Not(a[0][i])
+			}
+		}
+	}
+}
+
+// fromBytes returns the little endian lane made of the bits of p[0..8] (no constraint is recorded)
+func fromBytes(p []uints.U8) uints.U64 {
+	var res uints.U64
+	for i := 0; i < 8; i++ {
+		copy(res[8*i:8*i+8], p[i][:])
+	}
+	return res
+}
@@ -0,0 +1,114 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keccak
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gurvy"
+	"golang.org/x/crypto/sha3"
+)
+
+type keccakCircuit struct {
+	Data   [3]frontend.Variable
+	Digest [Size]frontend.Variable `gnark:",public"`
+}
+
+func (circuit *keccakCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	digest := Sum256(cs, circuit.Data[:])
+	for i := 0; i < Size; i++ {
+		cs.AssertIsEqual(digest[i], circuit.Digest[i])
+	}
+	return nil
+}
+
+// 136 bytes, the padding is a full block
+type keccakTwoBlocksCircuit struct {
+	Data   [rate]frontend.Variable
+	Digest [Size]frontend.Variable `gnark:",public"`
+}
+
+func (circuit *keccakTwoBlocksCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	digest := Sum256(cs, circuit.Data[:])
+	for i := 0; i < Size; i++ {
+		cs.AssertIsEqual(digest[i], circuit.Digest[i])
+	}
+	return nil
+}
+
+func keccak256(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func TestSum256(t *testing.T) {
+	assert := groth16.NewAssert(t)
+
+	var circuit keccakCircuit
+	r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("abc")
+	digest := keccak256(data)
+
+	var good, bad keccakCircuit
+	for i := 0; i < len(data); i++ {
+		good.Data[i].Assign(int(data[i]))
+		bad.Data[i].Assign(int(data[i]))
+	}
+	for i := 0; i < Size; i++ {
+		good.Digest[i].Assign(int(digest[i]))
+	}
+	digest[0] ^= 1
+	for i := 0; i < Size; i++ {
+		bad.Digest[i].Assign(int(digest[i]))
+	}
+
+	assert.SolvingSucceeded(r1cs, &good)
+	assert.SolvingFailed(r1cs, &bad)
+}
+
+func TestSum256TwoBlocks(t *testing.T) {
+	assert := groth16.NewAssert(t)
+
+	var circuit keccakTwoBlocksCircuit
+	r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data [rate]byte
+	for i := 0; i < len(data); i++ {
+		data[i] = byte(i * 7)
+	}
+	digest := keccak256(data[:])
+
+	var witness keccakTwoBlocksCircuit
+	for i := 0; i < len(data); i++ {
+		witness.Data[i].Assign(int(data[i]))
+	}
+	for i := 0; i < Size; i++ {
+		witness.Digest[i].Assign(int(digest[i]))
+	}
+
+	assert.SolvingSucceeded(r1cs, &witness)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/consensys/gnark/crypto/hash/mimc/bls377"
 	"github.com/consensys/gnark/crypto/hash/mimc/bls381"
 	"github.com/consensys/gnark/crypto/hash/mimc/bn256"
+	"github.com/consensys/gnark/crypto/hash/mimc/bw761"
 
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gurvy"
@@ -35,49 +36,67 @@ func init() {
 	encryptFuncs[gurvy.BN256] = encryptBN256
 	encryptFuncs[gurvy.BLS381] = encryptBLS381
 	encryptFuncs[gurvy.BLS377] = encryptBLS377
+	encryptFuncs[gurvy.BW761] = encryptBW761
 
 	newMimc = make(map[gurvy.ID]func(string) MiMC)
 	newMimc[gurvy.BN256] = newMimcBN256
 	newMimc[gurvy.BLS381] = newMimcBLS381
 	newMimc[gurvy.BLS377] = newMimcBLS377
+	newMimc[gurvy.BW761] = newMimcBW761
 }
 
 // -------------------------------------------------------------------------------------------------
 // constructors
 
 func newMimcBLS377(seed string) MiMC {
-	res := MiMC{}
-	params := bls377.NewParams(seed)
-	for _, v := range params {
-		var cpy big.Int
-		v.ToBigIntRegular(&cpy)
-		res.params = append(res.params, cpy)
+	return MiMC{params: paramsBLS377(bls377.NewParams(seed)), id: gurvy.BLS377}
+}
+
+// paramsBLS377 returns the constants of params, in regular form
+func paramsBLS377(params bls377.Params) []big.Int {
+	res := make([]big.Int, len(params))
+	for i := 0; i < len(params); i++ {
+		params[i].ToBigIntRegular(&res[i])
 	}
-	res.id = gurvy.BLS377
 	return res
 }
 
 func newMimcBLS381(seed string) MiMC {
-	res := MiMC{}
-	params := bls381.NewParams(seed)
-	for _, v := range params {
-		var cpy big.Int
-		v.ToBigIntRegular(&cpy)
-		res.params = append(res.params, cpy)
+	return MiMC{params: paramsBLS381(bls381.NewParams(seed)), id: gurvy.BLS381}
+}
+
+// paramsBLS381 returns the constants of params, in regular form
+func paramsBLS381(params bls381.Params) []big.Int {
+	res := make([]big.Int, len(params))
+	for i := 0; i < len(params); i++ {
+		params[i].ToBigIntRegular(&res[i])
 	}
-	res.id = gurvy.BLS381
 	return res
 }
 
 func newMimcBN256(seed string) MiMC {
-	res := MiMC{}
-	params := bn256.NewParams(seed)
-	for _, v := range params {
-		var cpy big.Int
-		v.ToBigIntRegular(&cpy)
-		res.params = append(res.params, cpy)
+	return MiMC{params: paramsBN256(bn256.NewParams(seed)), id: gurvy.BN256}
+}
+
+// paramsBN256 returns the constants of params, in regular form
+func paramsBN256(params bn256.Params) []big.Int {
+	res := make([]big.Int, len(params))
+	for i := 0; i < len(params); i++ {
+		params[i].ToBigIntRegular(&res[i])
+	}
+	return res
+}
+
+func newMimcBW761(seed string) MiMC {
+	return MiMC{params: paramsBW761(bw761.NewParams(seed)), id: gurvy.BW761}
+}
+
+// paramsBW761 returns the constants of params, in regular form
+func paramsBW761(params bw761.Params) []big.Int {
+	res := make([]big.Int, len(params))
+	for i := 0; i < len(params); i++ {
+		params[i].ToBigIntRegular(&res[i])
 	}
-	res.id = gurvy.BN256
 	return res
 }
 
@@ -134,3 +153,20 @@ func encryptBLS377(cs *frontend.ConstraintSystem, h MiMC, message frontend.Varia
 	return res
 
 }
+
+// execution of a mimc run expressed as r1cs
+func encryptBW761(cs *frontend.ConstraintSystem, h MiMC, message frontend.Variable, key frontend.Variable) frontend.Variable {
+
+	res := message
+
+	for i := 0; i < len(h.params); i++ {
+		tmp := cs.Add(res, key, h.params[i])
+		// res = (res+k+c)^5
+		res = cs.Mul(tmp, tmp) // square
+		res = cs.Mul(res, res) // square
+		res = cs.Mul(res, tmp) // mul
+	}
+	res = cs.Add(res, key)
+	return res
+
+}
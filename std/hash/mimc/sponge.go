@@ -0,0 +1,156 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mimc
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark/crypto/hash/mimc/bls377"
+	"github.com/consensys/gnark/crypto/hash/mimc/bls381"
+	"github.com/consensys/gnark/crypto/hash/mimc/bn256"
+	"github.com/consensys/gnark/crypto/hash/mimc/bw761"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gurvy"
+)
+
+// Sponge is a MiMC sponge (in r1cs form), absorbing and squeezing field elements
+//
+// the state is rate elements followed by the capacity element, the permutation is a generalized
+// Feistel network (MiMC-2n/n on (xL, xR) with a rate of 1 element). It matches the Sponge of
+// crypto/hash/mimc, including the padding of a partially filled rate.
+type Sponge struct {
+	params    []big.Int
+	id        gurvy.ID
+	rate      int
+	state     []frontend.Variable // rate elements, followed by the capacity element
+	pos       int                 // position in the rate of the next absorbed or squeezed element
+	squeezing bool
+}
+
+var newSpongeParams map[gurvy.ID]func(string, int) []big.Int
+
+func init() {
+	newSpongeParams = make(map[gurvy.ID]func(string, int) []big.Int)
+	newSpongeParams[gurvy.BN256] = func(seed string, rate int) []big.Int {
+		return paramsBN256(bn256.NewSpongeParams(seed, rate))
+	}
+	newSpongeParams[gurvy.BLS381] = func(seed string, rate int) []big.Int {
+		return paramsBLS381(bls381.NewSpongeParams(seed, rate))
+	}
+	newSpongeParams[gurvy.BLS377] = func(seed string, rate int) []big.Int {
+		return paramsBLS377(bls377.NewSpongeParams(seed, rate))
+	}
+	newSpongeParams[gurvy.BW761] = func(seed string, rate int) []big.Int {
+		return paramsBW761(bw761.NewSpongeParams(seed, rate))
+	}
+}
+
+// NewSponge returns a Sponge instance of the given rate (at least 1), than can be used in a gnark circuit
+func NewSponge(seed string, id gurvy.ID, rate int) (Sponge, error) {
+	if rate < 1 {
+		return Sponge{}, errors.New("the rate of the sponge must be at least 1")
+	}
+	if newParams, ok := newSpongeParams[id]; ok {
+		return Sponge{params: newParams(seed, rate), id: id, rate: rate}, nil
+	}
+	return Sponge{}, errors.New("unknown curve id")
+}
+
+// Absorb adds each element of data to the rate, with a permutation each time the rate is full
+func (s *Sponge) Absorb(cs *frontend.ConstraintSystem, data ...frontend.Variable) {
+	s.init(cs)
+	if s.squeezing {
+		s.squeezing = false
+		s.pos = 0
+	}
+	for i := 0; i < len(data); i++ {
+		s.state[s.pos] = cs.Add(s.state[s.pos], data[i])
+		s.pos++
+		if s.pos == s.rate {
+			s.permutation(cs)
+			s.pos = 0
+		}
+	}
+}
+
+// Squeeze returns n elements, read from the rate, with a permutation each time the rate is exhausted
+//
+// if the rate is partially filled, it is padded and the state is permuted first (see Sponge in crypto/hash/mimc)
+func (s *Sponge) Squeeze(cs *frontend.ConstraintSystem, n int) []frontend.Variable {
+	s.init(cs)
+	if !s.squeezing {
+		if s.pos != 0 {
+			s.state[s.rate] = cs.Add(s.state[s.rate], s.pos)
+			s.permutation(cs)
+		}
+		s.squeezing = true
+		s.pos = 0
+	}
+	res := make([]frontend.Variable, n)
+	for i := 0; i < n; i++ {
+		if s.pos == s.rate {
+			s.permutation(cs)
+			s.pos = 0
+		}
+		res[i] = s.state[s.pos]
+		s.pos++
+	}
+	return res
+}
+
+func (s *Sponge) init(cs *frontend.ConstraintSystem) {
+	if s.state == nil {
+		s.state = make([]frontend.Variable, s.rate+1)
+		for i := 0; i < len(s.state); i++ {
+			s.state[i] = cs.Constant(0)
+		}
+	}
+}
+
+// permutation (x_0, ..., x_rate) <- (x_1 + sbox(x_0 + c), x_2, ..., x_rate, x_0), for each constant c
+func (s *Sponge) permutation(cs *frontend.ConstraintSystem) {
+	for i := 0; i < len(s.params); i++ {
+		tmp := cs.Add(s.state[0], s.params[i])
+		tmp = cs.Add(sbox(cs, s.id, tmp), s.state[1])
+		x0 := s.state[0]
+		s.state[0] = tmp
+		copy(s.state[1:], s.state[2:])
+		s.state[s.rate] = x0
+	}
+}
+
+// sbox returns the round function of mimc for the curve id, applied to x
+func sbox(cs *frontend.ConstraintSystem, id gurvy.ID, x frontend.Variable) frontend.Variable {
+	switch id {
+	case gurvy.BN256:
+		// x^7
+		res := cs.Mul(x, x)
+		res = cs.Mul(res, x)
+		res = cs.Mul(res, res)
+		return cs.Mul(res, x)
+	case gurvy.BLS377:
+		// x^-1
+		return cs.Inverse(x)
+	default:
+		// x^5
+		res := cs.Mul(x, x)
+		res = cs.Mul(res, res)
+		return cs.Mul(res, x)
+	}
+}
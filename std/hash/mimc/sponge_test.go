@@ -0,0 +1,222 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mimc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gurvy"
+
+	mimcbls377 "github.com/consensys/gnark/crypto/hash/mimc/bls377"
+	mimcbls381 "github.com/consensys/gnark/crypto/hash/mimc/bls381"
+	mimcbn256 "github.com/consensys/gnark/crypto/hash/mimc/bn256"
+	mimcbw761 "github.com/consensys/gnark/crypto/hash/mimc/bw761"
+
+	fr_bls377 "github.com/consensys/gurvy/bls377/fr"
+	fr_bls381 "github.com/consensys/gurvy/bls381/fr"
+	fr_bn256 "github.com/consensys/gurvy/bn256/fr"
+	fr_bw761 "github.com/consensys/gurvy/bw761/fr"
+)
+
+// absorbs 3 elements, squeezes 2 elements, absorbs 1 element and squeezes 1 element
+type spongeCircuit struct {
+	Data     [4]frontend.Variable
+	Expected [3]frontend.Variable `gnark:",public"`
+	rate     int
+}
+
+func (circuit *spongeCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	sponge, err := NewSponge("seed", curveID, circuit.rate)
+	if err != nil {
+		return err
+	}
+	sponge.Absorb(cs, circuit.Data[:3]...)
+	res := sponge.Squeeze(cs, 2)
+	sponge.Absorb(cs, circuit.Data[3])
+	res = append(res, sponge.Squeeze(cs, 1)...)
+
+	for i := 0; i < len(res); i++ {
+		cs.AssertIsEqual(res[i], circuit.Expected[i])
+	}
+	return nil
+}
+
+// nativeSponge runs the native sponge of the given rate like spongeCircuit
+func nativeSponge(curveID gurvy.ID, rate int, data []uint64) []big.Int {
+	res := make([]big.Int, 3)
+	switch curveID {
+	case gurvy.BN256:
+		e := make([]fr_bn256.Element, len(data))
+		for i := 0; i < len(data); i++ {
+			e[i].SetUint64(data[i])
+		}
+		s := mimcbn256.NewSponge("seed", rate)
+		s.Absorb(e[:3]...)
+		out := s.Squeeze(2)
+		s.Absorb(e[3])
+		out = append(out, s.Squeeze(1)...)
+		for i := 0; i < len(out); i++ {
+			out[i].ToBigIntRegular(&res[i])
+		}
+	case gurvy.BLS381:
+		e := make([]fr_bls381.Element, len(data))
+		for i := 0; i < len(data); i++ {
+			e[i].SetUint64(data[i])
+		}
+		s := mimcbls381.NewSponge("seed", rate)
+		s.Absorb(e[:3]...)
+		out := s.Squeeze(2)
+		s.Absorb(e[3])
+		out = append(out, s.Squeeze(1)...)
+		for i := 0; i < len(out); i++ {
+			out[i].ToBigIntRegular(&res[i])
+		}
+	case gurvy.BLS377:
+		e := make([]fr_bls377.Element, len(data))
+		for i := 0; i < len(data); i++ {
+			e[i].SetUint64(data[i])
+		}
+		s := mimcbls377.NewSponge("seed", rate)
+		s.Absorb(e[:3]...)
+		out := s.Squeeze(2)
+		s.Absorb(e[3])
+		out = append(out, s.Squeeze(1)...)
+		for i := 0; i < len(out); i++ {
+			out[i].ToBigIntRegular(&res[i])
+		}
+	case gurvy.BW761:
+		e := make([]fr_bw761.Element, len(data))
+		for i := 0; i < len(data); i++ {
+			e[i].SetUint64(data[i])
+		}
+		s := mimcbw761.NewSponge("seed", rate)
+		s.Absorb(e[:3]...)
+		out := s.Squeeze(2)
+		s.Absorb(e[3])
+		out = append(out, s.Squeeze(1)...)
+		for i := 0; i < len(out); i++ {
+			out[i].ToBigIntRegular(&res[i])
+		}
+	}
+	return res
+}
+
+func TestSponge(t *testing.T) {
+	assert := groth16.NewAssert(t)
+
+	data := []uint64{1, 2, 3, 4}
+
+	curves := []gurvy.ID{gurvy.BN256, gurvy.BLS377, gurvy.BLS381, gurvy.BW761}
+	for _, curve := range curves {
+		// the 3 first elements fill the rate, 1 rate and a half, or 3/4 of the rate
+		for _, rate := range []int{1, 2, 3, 4} {
+			circuit := spongeCircuit{rate: rate}
+			r1cs, err := frontend.Compile(curve, &circuit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var good, bad spongeCircuit
+			expected := nativeSponge(curve, rate, data)
+			if expected[0].Cmp(&expected[1]) == 0 {
+				t.Fatal("squeezed outputs should differ")
+			}
+			for i := 0; i < len(data); i++ {
+				good.Data[i].Assign(data[i])
+				bad.Data[i].Assign(data[i])
+			}
+			for i := 0; i < len(expected); i++ {
+				good.Expected[i].Assign(expected[i])
+			}
+			bad.Expected[0].Assign(expected[1])
+			bad.Expected[1].Assign(expected[0])
+			bad.Expected[2].Assign(expected[2])
+
+			assert.SolvingSucceeded(r1cs, &good)
+			assert.SolvingFailed(r1cs, &bad)
+		}
+	}
+}
+
+// absorbs X followed by nbZeros zeros, and squeezes 1 element
+type spongePaddingCircuit struct {
+	X        frontend.Variable
+	Expected frontend.Variable `gnark:",public"`
+	nbZeros  int
+}
+
+func (circuit *spongePaddingCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	sponge, err := NewSponge("seed", curveID, 2)
+	if err != nil {
+		return err
+	}
+	sponge.Absorb(cs, circuit.X)
+	for i := 0; i < circuit.nbZeros; i++ {
+		sponge.Absorb(cs, cs.Constant(0))
+	}
+	cs.AssertIsEqual(sponge.Squeeze(cs, 1)[0], circuit.Expected)
+	return nil
+}
+
+// the inputs which are equal once the rate is padded with zeros have distinct outputs
+func TestSpongePadding(t *testing.T) {
+	assert := groth16.NewAssert(t)
+
+	squeeze := func(data ...uint64) fr_bn256.Element {
+		s := mimcbn256.NewSponge("seed", 2)
+		for i := 0; i < len(data); i++ {
+			var e fr_bn256.Element
+			e.SetUint64(data[i])
+			s.Absorb(e)
+		}
+		return s.Squeeze(1)[0]
+	}
+
+	data := []uint64{42, 0, 0}
+	for nbZeros := 0; nbZeros < len(data)-1; nbZeros++ {
+		circuit := spongePaddingCircuit{nbZeros: nbZeros}
+		r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var good, bad spongePaddingCircuit
+		good.X.Assign(data[0])
+		good.Expected.Assign(squeeze(data[:nbZeros+1]...))
+		bad.X.Assign(data[0])
+		bad.Expected.Assign(squeeze(data[:nbZeros+2]...))
+
+		assert.SolvingSucceeded(r1cs, &good)
+		assert.SolvingFailed(r1cs, &bad)
+	}
+}
+
+func TestSpongeRate(t *testing.T) {
+	if _, err := NewSponge("seed", gurvy.BN256, 0); err == nil {
+		t.Fatal("a sponge of rate 0 should be rejected")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("a native sponge of rate 0 should panic")
+		}
+	}()
+	mimcbn256.NewSponge("seed", 0)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package poseidon provides the Poseidon hash function as a gnark circuit gadget,
+// matching the native implementation in crypto/hash/poseidon
+package poseidon
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark/crypto/hash/poseidon"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gurvy"
+	bls377fr "github.com/consensys/gurvy/bls377/fr"
+	bls381fr "github.com/consensys/gurvy/bls381/fr"
+	bn256fr "github.com/consensys/gurvy/bn256/fr"
+	bw761fr "github.com/consensys/gurvy/bw761/fr"
+)
+
+// Poseidon contains the params of the Poseidon permutation for a curve
+type Poseidon struct {
+	params *poseidon.Params
+}
+
+var modulus = map[gurvy.ID]func() *big.Int{
+	gurvy.BN256:  bn256fr.Modulus,
+	gurvy.BLS381: bls381fr.Modulus,
+	gurvy.BLS377: bls377fr.Modulus,
+	gurvy.BW761:  bw761fr.Modulus,
+}
+
+// NewPoseidon returns a Poseidon instance of width t, that can be used in a gnark circuit
+func NewPoseidon(id gurvy.ID, t int) (Poseidon, error) {
+	if m, ok := modulus[id]; ok {
+		return Poseidon{params: poseidon.NewParams(m(), t)}, nil
+	}
+	return Poseidon{}, errors.New("unknown curve id")
+}
+
+// Hash absorbs data in a sponge of rate t-1 and capacity 1, and returns the first element of the state
+// (see Params.Hash in the curve packages of crypto/hash/poseidon)
+//
+// the capacity element starts at poseidon.LengthTag(len(data)), and the last chunk of data is padded
+// with zeros
+func (h Poseidon) Hash(cs *frontend.ConstraintSystem, data ...frontend.Variable) frontend.Variable {
+	rate := h.params.T - 1
+	state := make([]frontend.Variable, h.params.T)
+	state[0] = cs.Constant(poseidon.LengthTag(len(data)))
+	for i := 1; i < len(state); i++ {
+		state[i] = cs.Constant(0)
+	}
+	if len(data) == 0 {
+		// a single permutation of the tag
+		data = []frontend.Variable{cs.Constant(0)}
+	}
+	for i := 0; i < len(data); i += rate {
+		for j := 0; j < rate && i+j < len(data); j++ {
+			state[1+j] = cs.Add(state[1+j], data[i+j])
+		}
+		h.Permutation(cs, state)
+	}
+	return state[0]
+}
+
+// Permutation applies the Poseidon permutation to state, of length t
+//
+// each S-box costs a few constraints (3 for x^5), the round constants and the MDS matrix are free
+func (h Poseidon) Permutation(cs *frontend.ConstraintSystem, state []frontend.Variable) {
+	p := h.params
+	half := p.NbFullRounds / 2
+
+	for r := 0; r < len(p.RoundConstants); r++ {
+		for i := 0; i < p.T; i++ {
+			state[i] = cs.Add(state[i], p.RoundConstants[r][i])
+		}
+
+		if r < half || r >= half+p.NbPartialRounds {
+			for i := 0; i < p.T; i++ {
+				state[i] = h.sbox(cs, state[i])
+			}
+		} else {
+			state[0] = h.sbox(cs, state[0])
+		}
+
+		mix := make([]frontend.Variable, p.T)
+		for i := 0; i < p.T; i++ {
+			mix[i] = cs.Mul(state[0], p.MDS[i][0])
+			for j := 1; j < p.T; j++ {
+				mix[i] = cs.Add(mix[i], cs.Mul(state[j], p.MDS[i][j]))
+			}
+		}
+		copy(state, mix)
+	}
+}
+
+// sbox returns x^alpha, by square and multiply
+func (h Poseidon) sbox(cs *frontend.ConstraintSystem, x frontend.Variable) frontend.Variable {
+	alpha := new(big.Int).SetUint64(h.params.Alpha)
+	res := x
+	for i := alpha.BitLen() - 2; i >= 0; i-- {
+		res = cs.Mul(res, res)
+		if alpha.Bit(i) == 1 {
+			res = cs.Mul(res, x)
+		}
+	}
+	return res
+}
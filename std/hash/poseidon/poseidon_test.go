@@ -0,0 +1,184 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poseidon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gurvy"
+
+	poseidonbls377 "github.com/consensys/gnark/crypto/hash/poseidon/bls377"
+	poseidonbls381 "github.com/consensys/gnark/crypto/hash/poseidon/bls381"
+	poseidonbn256 "github.com/consensys/gnark/crypto/hash/poseidon/bn256"
+	poseidonbw761 "github.com/consensys/gnark/crypto/hash/poseidon/bw761"
+
+	fr_bls377 "github.com/consensys/gurvy/bls377/fr"
+	fr_bls381 "github.com/consensys/gurvy/bls381/fr"
+	fr_bn256 "github.com/consensys/gurvy/bn256/fr"
+	fr_bw761 "github.com/consensys/gurvy/bw761/fr"
+)
+
+const width = 3
+
+type poseidonCircuit struct {
+	ExpectedResult frontend.Variable `gnark:"data,public"`
+	Data           [5]frontend.Variable
+}
+
+func (circuit *poseidonCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	poseidon, err := NewPoseidon(curveID, width)
+	if err != nil {
+		return err
+	}
+	result := poseidon.Hash(cs, circuit.Data[:]...)
+	cs.AssertIsEqual(result, circuit.ExpectedResult)
+	return nil
+}
+
+// nativeHash returns the native Poseidon hash of data
+func nativeHash(curveID gurvy.ID, data []uint64) big.Int {
+	var res big.Int
+	switch curveID {
+	case gurvy.BN256:
+		elements := make([]fr_bn256.Element, len(data))
+		for i := 0; i < len(data); i++ {
+			elements[i].SetUint64(data[i])
+		}
+		h := poseidonbn256.NewParams(width).Hash(elements...)
+		h.ToBigIntRegular(&res)
+	case gurvy.BLS381:
+		elements := make([]fr_bls381.Element, len(data))
+		for i := 0; i < len(data); i++ {
+			elements[i].SetUint64(data[i])
+		}
+		h := poseidonbls381.NewParams(width).Hash(elements...)
+		h.ToBigIntRegular(&res)
+	case gurvy.BLS377:
+		elements := make([]fr_bls377.Element, len(data))
+		for i := 0; i < len(data); i++ {
+			elements[i].SetUint64(data[i])
+		}
+		h := poseidonbls377.NewParams(width).Hash(elements...)
+		h.ToBigIntRegular(&res)
+	case gurvy.BW761:
+		elements := make([]fr_bw761.Element, len(data))
+		for i := 0; i < len(data); i++ {
+			elements[i].SetUint64(data[i])
+		}
+		h := poseidonbw761.NewParams(width).Hash(elements...)
+		h.ToBigIntRegular(&res)
+	}
+	return res
+}
+
+func TestPoseidon(t *testing.T) {
+	assert := groth16.NewAssert(t)
+
+	data := []uint64{1, 2, 3, 4, 5}
+
+	curves := []gurvy.ID{gurvy.BN256, gurvy.BLS377, gurvy.BLS381, gurvy.BW761}
+	for _, curve := range curves {
+		var circuit, good, bad poseidonCircuit
+		r1cs, err := frontend.Compile(curve, &circuit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := nativeHash(curve, data)
+		for i := 0; i < len(data); i++ {
+			good.Data[i].Assign(data[i])
+			bad.Data[i].Assign(data[i])
+		}
+		good.ExpectedResult.Assign(expected)
+		bad.ExpectedResult.Assign(nativeHash(curve, data[:4]))
+
+		assert.SolvingSucceeded(r1cs, &good)
+		assert.SolvingFailed(r1cs, &bad)
+	}
+}
+
+// paddingCircuit hashes X followed by nbZeros zeros
+type paddingCircuit struct {
+	ExpectedResult frontend.Variable `gnark:"data,public"`
+	X              frontend.Variable
+	nbZeros        int
+}
+
+func (circuit *paddingCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	poseidon, err := NewPoseidon(curveID, width)
+	if err != nil {
+		return err
+	}
+	data := []frontend.Variable{circuit.X}
+	for i := 0; i < circuit.nbZeros; i++ {
+		data = append(data, cs.Constant(0))
+	}
+	cs.AssertIsEqual(poseidon.Hash(cs, data...), circuit.ExpectedResult)
+	return nil
+}
+
+// the inputs which are equal once padded with zeros to a multiple of the rate have distinct hashes
+func TestPadding(t *testing.T) {
+	assert := groth16.NewAssert(t)
+
+	data := []uint64{42, 0, 0, 0}
+	for nbZeros := 0; nbZeros < len(data)-1; nbZeros++ {
+		circuit := paddingCircuit{nbZeros: nbZeros}
+		r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var good, bad paddingCircuit
+		good.X.Assign(data[0])
+		good.ExpectedResult.Assign(nativeHash(gurvy.BN256, data[:nbZeros+1]))
+		bad.X.Assign(data[0])
+		bad.ExpectedResult.Assign(nativeHash(gurvy.BN256, data[:nbZeros+2]))
+
+		assert.SolvingSucceeded(r1cs, &good)
+		assert.SolvingFailed(r1cs, &bad)
+	}
+}
+
+type permutationCircuit struct {
+	Data [width - 1]frontend.Variable
+}
+
+func (circuit *permutationCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	poseidon, err := NewPoseidon(curveID, width)
+	if err != nil {
+		return err
+	}
+	poseidon.Hash(cs, circuit.Data[:]...)
+	return nil
+}
+
+func TestPermutationConstraints(t *testing.T) {
+	var circuit permutationCircuit
+	r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 8 full rounds and 57 partial rounds, 3 constraints per S-box
+	if r1cs.GetNbConstraints() != (8*width+57)*3 {
+		t.Fatalf("unexpected number of constraints %d", r1cs.GetNbConstraints())
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sha2 provides the SHA-256 hash function (FIPS 180-4) as a gnark circuit gadget
+package sha2
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// Size is the size of a SHA-256 digest in bytes
+const Size = 32
+
+const blockSize = 64
+
+var _K = [64]uint32{
+	0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5, 0x3956c25b, 0x59f111f1, 0x923f82a4, 0xab1c5ed5,
+	0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3, 0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174,
+	0xe49b69c1, 0xefbe4786, 0x0fc19dc6, 0x240ca1cc, 0x2de92c6f, 0x4a7484aa, 0x5cb0a9dc, 0x76f988da,
+	0x983e5152, 0xa831c66d, 0xb00327c8, 0xbf597fc7, 0xc6e00bf3, 0xd5a79147, 0x06ca6351, 0x14292967,
+	0x27b70a85, 0x2e1b2138, 0x4d2c6dfc, 0x53380d13, 0x650a7354, 0x766a0abb, 0x81c2c92e, 0x92722c85,
+	0xa2bfe8a1, 0xa81a664b, 0xc24b8b70, 0xc76c51a3, 0xd192e819, 0xd6990624, 0xf40e3585, 0x106aa070,
+	0x19a4c116, 0x1e376c08, 0x2748774c, 0x34b0bcb5, 0x391c0cb3, 0x4ed8aa4a, 0x5b9cca4f, 0x682e6ff3,
+	0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208, 0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2,
+}
+
+var _IV = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+// Sum256 returns the SHA-256 digest of data, as Size bytes
+//
+// each byte of data is constrained to be less than 256. The length of data is known when the
+// circuit is compiled, such that the padding doesn't cost any constraint.
+//
+// A block of 64 bytes costs about 40000 constraints.
+func Sum256(cs *frontend.ConstraintSystem, data []frontend.Variable) [Size]frontend.Variable {
+
+	// range check the input and append the padding
+	msg := make([]uints.U8, len(data), len(data)+2*blockSize)
+	for i := 0; i < len(data); i++ {
+		msg[i].SetVariable(cs, data[i])
+	}
+	for _, b := range padding(len(data)) {
+		var u uints.U8
+		msg = append(msg, *u.SetConstant(cs, b))
+	}
+
+	var h [8]uints.U32
+	for i := 0; i < 8; i++ {
+		h[i].SetConstant(cs, _IV[i])
+	}
+	for i := 0; i < len(msg); i += blockSize {
+		block(cs, &h, msg[i:i+blockSize])
+	}
+
+	var digest [Size]frontend.Variable
+	for i := 0; i < 8; i++ {
+		bytes := toBytes(h[i])
+		for j := 0; j < 4; j++ {
+			digest[4*i+j] = bytes[j].ToVariable(cs)
+		}
+	}
+	return digest
+}
+
+// padding returns the bytes appended to a message of length n: 0x80, zeros and the length in bits
+// on 8 bytes, such that the padded message is a multiple of blockSize
+func padding(n int) []uint8 {
+	nbZeros := (blockSize + 55 - n%blockSize) % blockSize
+	res := make([]uint8, 1+nbZeros+8)
+	res[0] = 0x80
+	nbBits := uint64(n) << 3
+	for i := 0; i < 8; i++ {
+		res[len(res)-1-i] = uint8(nbBits >> uint(8*i))
+	}
+	return res
+}
+
+// block updates the state h with a block of 64 bytes
+func block(cs *frontend.ConstraintSystem, h *[8]uints.U32, p []uints.U8) {
+
+	// message schedule
+	var w [64]uints.U32
+	for i := 0; i < 16; i++ {
+		w[i] = fromBytes(p[4*i : 4*i+4])
+	}
+	for i := 16; i < 64; i++ {
+		var s0, s1, t uints.U32
+
+		// σ0 = (w[i-15] >>> 7) ^ (w[i-15] >>> 18) ^ (w[i-15] >> 3)
+		s0.RotateRight(cs, w[i-15], 7)
+		t.RotateRight(cs, w[i-15], 18)
+		s0.Xor(cs, s0, t)
+		t.ShiftRight(cs, w[i-15], 3)
+		s0.Xor(cs, s0, t)
+
+		// σ1 = (w[i-2] >>> 17) ^ (w[i-2] >>> 19) ^ (w[i-2] >> 10)
+		s1.RotateRight(cs, w[i-2], 17)
+		t.RotateRight(cs, w[i-2], 19)
+		s1.Xor(cs, s1, t)
+		t.ShiftRight(cs, w[i-2], 10)
+		s1.Xor(cs, s1, t)
+
+		w[i].Add(cs, w[i-16], s0, w[i-7], s1)
+	}
+
+	a, b, c, d, e, f, g, hh := h[0], h[1], h[2], h[3], h[4], h[5], h[6], h[7]
+
+	for i := 0; i < 64; i++ {
+		var s0, s1, ch, maj, k, t, t1, t2 uints.U32
+
+		// Σ1 = (e >>> 6) ^ (e >>> 11) ^ (e >>> 25)
+		s1.RotateRight(cs, e, 6)
+		t.RotateRight(cs, e, 11)
+		s1.Xor(cs, s1, t)
+		t.RotateRight(cs, e, 25)
+		s1.Xor(cs, s1, t)
+
+		// ch = (e & f) ^ (^e & g) = g ^ (e & (f ^ g))
+		ch.Xor(cs, f, g)
+		ch.And(cs, e, ch)
+		ch.Xor(cs, g, ch)
+
+		k.SetConstant(cs, _K[i])
+		t1.Add(cs, hh, s1, ch, k, w[i])
+
+		// Σ0 = (a >>> 2) ^ (a >>> 13) ^ (a >>> 22)
+		s0.RotateRight(cs, a, 2)
+		t.RotateRight(cs, a, 13)
+		s0.Xor(cs, s0, t)
+		t.RotateRight(cs, a, 22)
+		s0.Xor(cs, s0, t)
+
+		// maj = (a & b) ^ (a & c) ^ (b & c) = (a & b) ^ (c & (a ^ b))
+		t.Xor(cs, a, b)
+		t.And(cs, c, t)
+		maj.And(cs, a, b)
+		maj.Xor(cs, maj, t)
+
+		t2.Add(cs, s0, maj)
+
+		hh = g
+		g = f
+		f = e
+		e.Add(cs, d, t1)
+		d = c
+		c = b
+		b = a
+		a.Add(cs, t1, t2)
+	}
+
+	h[0].Add(cs, h[0], a)
+	h[1].Add(cs, h[1], b)
+	h[2].Add(cs, h[2], c)
+	h[3].Add(cs, h[3], d)
+	h[4].Add(cs, h[4], e)
+	h[5].Add(cs, h[5], f)
+	h[6].Add(cs, h[6], g)
+	h[7].Add(cs, h[7], hh)
+}
+
+// fromBytes returns the big endian word made of the bits of p[0..4] (no constraint is recorded)
+func fromBytes(p []uints.U8) uints.U32 {
+	var res uints.U32
+	for i := 0; i < 4; i++ {
+		copy(res[8*(3-i):8*(4-i)], p[i][:])
+	}
+	return res
+}
+
+// toBytes returns the 4 bytes of w, in big endian (no constraint is recorded)
+func toBytes(w uints.U32) [4]uints.U8 {
+	var res [4]uints.U8
+	for i := 0; i < 4; i++ {
+		copy(res[i][:], w[8*(3-i):8*(4-i)])
+	}
+	return res
+}
@@ -0,0 +1,123 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sha2
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gurvy"
+)
+
+type sha256Circuit struct {
+	Data   [3]frontend.Variable
+	Digest [Size]frontend.Variable `gnark:",public"`
+}
+
+func (circuit *sha256Circuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	digest := Sum256(cs, circuit.Data[:])
+	for i := 0; i < Size; i++ {
+		cs.AssertIsEqual(digest[i], circuit.Digest[i])
+	}
+	return nil
+}
+
+// 2 blocks are needed to hash 56 bytes, as the padding is 9 bytes at least
+type sha256TwoBlocksCircuit struct {
+	Data   [56]frontend.Variable
+	Digest [Size]frontend.Variable `gnark:",public"`
+}
+
+func (circuit *sha256TwoBlocksCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	digest := Sum256(cs, circuit.Data[:])
+	for i := 0; i < Size; i++ {
+		cs.AssertIsEqual(digest[i], circuit.Digest[i])
+	}
+	return nil
+}
+
+func TestSum256(t *testing.T) {
+	assert := groth16.NewAssert(t)
+
+	data := []byte("abc")
+	digest := sha256.Sum256(data)
+
+	var good, bad sha256Circuit
+	for i := 0; i < len(data); i++ {
+		good.Data[i].Assign(int(data[i]))
+		bad.Data[i].Assign(int(data[i]))
+	}
+	for i := 0; i < Size; i++ {
+		good.Digest[i].Assign(int(digest[i]))
+	}
+	digest[Size-1] ^= 1
+	for i := 0; i < Size; i++ {
+		bad.Digest[i].Assign(int(digest[i]))
+	}
+
+	curves := []gurvy.ID{gurvy.BN256, gurvy.BLS377, gurvy.BLS381, gurvy.BW761}
+	for _, curve := range curves {
+		var circuit sha256Circuit
+		r1cs, err := frontend.Compile(curve, &circuit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.SolvingSucceeded(r1cs, &good)
+		assert.SolvingFailed(r1cs, &bad)
+	}
+}
+
+func TestSum256TwoBlocks(t *testing.T) {
+	assert := groth16.NewAssert(t)
+
+	var data [56]byte
+	for i := 0; i < len(data); i++ {
+		data[i] = byte(i * 7)
+	}
+	digest := sha256.Sum256(data[:])
+
+	var witness sha256TwoBlocksCircuit
+	for i := 0; i < len(data); i++ {
+		witness.Data[i].Assign(int(data[i]))
+	}
+	for i := 0; i < Size; i++ {
+		witness.Digest[i].Assign(int(digest[i]))
+	}
+
+	var circuit sha256TwoBlocksCircuit
+	r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.SolvingSucceeded(r1cs, &witness)
+}
+
+func TestPadding(t *testing.T) {
+	for n := 0; n < 3*blockSize; n++ {
+		p := padding(n)
+		if (n+len(p))%blockSize != 0 {
+			t.Fatalf("padded length of a %d bytes message is not a multiple of the block size", n)
+		}
+		if len(p) < 9 || len(p) > blockSize+8 {
+			t.Fatalf("invalid padding length %d for a %d bytes message", len(p), n)
+		}
+	}
+}
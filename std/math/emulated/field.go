@@ -0,0 +1,349 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package emulated provides the arithmetic modulo an arbitrary prime p in gnark circuits
+// (non-native field arithmetic), for example to verify BN256 or secp256k1 arithmetic from
+// inside any curve.
+//
+// An element is a slice of limbs a_i, of value sum a_i*2^(64*i). The limbs of a reduced element
+// are range checked to 64 bits, and its value is less than 2^(64*NbLimbs()) (it is not necessarily
+// less than p, see AssertIsCanonical). Add, Sub and Mul don't reduce their result: the limbs grow,
+// and their overflow (number of bits above 64) is tracked, such that the operands are reduced only
+// when needed. The reduction of a computes r = a mod p and q = a / p with hints, and checks the
+// integer equality a = q*p + r limb per limb, with carries.
+//
+// Constraint counts (n is the number of limbs of the operands):
+//
+//	Add, Sub: 0
+//	Mul: n^2
+//	Reduce: about 65 per limb of r and q, and the range checks of the 2n-2 carries of a product
+//	Inverse, AssertIsEqual: about the cost of a Mul and a Reduce
+//	Div: about the cost of an Inverse, a Mul and a Reduce
+package emulated
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+const (
+	nbBits = 64 // number of bits of a limb
+
+	// maxBits bounds the size of the limbs of the intermediate values, such that the integer
+	// equalities checked in the circuit don't wrap around its scalar field (at least 252 bits)
+	maxBits = 240
+)
+
+// Field emulates the arithmetic modulo a prime p with the integers of the circuit
+type Field struct {
+	modulus big.Int
+	limbs   []big.Int // limbs of the modulus
+}
+
+// Element of a Field, in the circuit
+//
+// An Element built from its Limbs is assumed to be reduced, its limbs must be range checked
+// to 64 bits (see NewElement).
+type Element struct {
+	Limbs    []frontend.Variable
+	overflow int // the limbs are less than 2^(nbBits+overflow)
+}
+
+// NewField returns the field of integers modulo p, p must be prime
+func NewField(p *big.Int) *Field {
+	var f Field
+	f.modulus.Set(p)
+	f.limbs = decompose(&f.modulus, (p.BitLen()+nbBits-1)/nbBits)
+	return &f
+}
+
+// Modulus returns the modulus of the field
+func (f *Field) Modulus() *big.Int {
+	return new(big.Int).Set(&f.modulus)
+}
+
+// NbLimbs returns the number of limbs of a reduced element
+func (f *Field) NbLimbs() int {
+	return len(f.limbs)
+}
+
+// Assign assigns the limbs of v (0 <= v < 2^(64*NbLimbs())) to limbs, to be used as the input of NewElement
+func (f *Field) Assign(limbs []frontend.Variable, v *big.Int) {
+	for i := 0; i < f.NbLimbs(); i++ {
+		limbs[i].Assign(limbOf(v, i))
+	}
+}
+
+// Constant returns the element of value v (0 <= v < 2^(64*NbLimbs()))
+func (f *Field) Constant(cs *frontend.ConstraintSystem, v *big.Int) Element {
+	limbs := decompose(v, f.NbLimbs())
+	res := Element{Limbs: make([]frontend.Variable, f.NbLimbs())}
+	for i := 0; i < f.NbLimbs(); i++ {
+		res.Limbs[i] = cs.Constant(limbs[i])
+	}
+	return res
+}
+
+// NewElement returns the element of the given limbs (little endian), which are range checked
+func (f *Field) NewElement(cs *frontend.ConstraintSystem, limbs ...frontend.Variable) Element {
+	if len(limbs) != f.NbLimbs() {
+		panic("wrong number of limbs")
+	}
+	res := Element{Limbs: make([]frontend.Variable, f.NbLimbs())}
+	for i := 0; i < f.NbLimbs(); i++ {
+		cs.ToBinary(limbs[i], nbBits)
+		res.Limbs[i] = limbs[i]
+	}
+	return res
+}
+
+// Add returns a+b (not reduced)
+func (f *Field) Add(cs *frontend.ConstraintSystem, a, b Element) Element {
+	for max(a.overflow, b.overflow)+1+nbBits > maxBits {
+		a, b = f.reduceLargest(cs, a, b)
+	}
+	res := Element{
+		Limbs:    make([]frontend.Variable, max(len(a.Limbs), len(b.Limbs))),
+		overflow: max(a.overflow, b.overflow) + 1,
+	}
+	for i := 0; i < len(res.Limbs); i++ {
+		res.Limbs[i] = cs.Add(limb(cs, a, i), limb(cs, b, i))
+	}
+	return res
+}
+
+// Sub returns a-b (not reduced)
+//
+// the limbs of the result are a_i-b_i+u_i, where u is a multiple of p whose limbs are larger than
+// the limbs of b, such that they are positive
+func (f *Field) Sub(cs *frontend.ConstraintSystem, a, b Element) Element {
+	for max(a.overflow, b.overflow+1)+1+nbBits > maxBits {
+		a, b = f.reduceLargest(cs, a, b)
+	}
+	u := f.padding(b.overflow, max(len(a.Limbs), len(b.Limbs)))
+	res := Element{
+		Limbs:    make([]frontend.Variable, len(u)),
+		overflow: max(a.overflow, b.overflow+1) + 1,
+	}
+	for i := 0; i < len(res.Limbs); i++ {
+		res.Limbs[i] = cs.Sub(cs.Add(limb(cs, a, i), u[i]), limb(cs, b, i))
+	}
+	return res
+}
+
+// Mul returns a*b (not reduced), the limbs of the result are the coefficients of the product
+// of the polynomials of limbs (len(a.Limbs)*len(b.Limbs) constraints)
+func (f *Field) Mul(cs *frontend.ConstraintSystem, a, b Element) Element {
+	for mulBits(a, b) > maxBits {
+		a, b = f.reduceLargest(cs, a, b)
+	}
+	res := Element{
+		Limbs:    make([]frontend.Variable, len(a.Limbs)+len(b.Limbs)-1),
+		overflow: mulBits(a, b) - nbBits,
+	}
+	for i := 0; i < len(res.Limbs); i++ {
+		res.Limbs[i] = cs.Constant(0)
+	}
+	for i := 0; i < len(a.Limbs); i++ {
+		for j := 0; j < len(b.Limbs); j++ {
+			res.Limbs[i+j] = cs.Add(res.Limbs[i+j], cs.Mul(a.Limbs[i], b.Limbs[j]))
+		}
+	}
+	return res
+}
+
+// Div returns a/b (reduced), the circuit has no solution if b = 0 modulo p
+func (f *Field) Div(cs *frontend.ConstraintSystem, a, b Element) Element {
+	return f.Reduce(cs, f.Mul(cs, a, f.Inverse(cs, b)))
+}
+
+// Inverse returns 1/a (reduced), the circuit has no solution if a = 0 modulo p
+//
+// the result inv is computed by a hint, and constrained by a*inv-1 = q*p
+func (f *Field) Inverse(cs *frontend.ConstraintSystem, a Element) Element {
+	inputs := variables(a.Limbs)
+
+	res := Element{Limbs: make([]frontend.Variable, f.NbLimbs())}
+	for i := 0; i < f.NbLimbs(); i++ {
+		res.Limbs[i] = f.hint(cs, invHint, i, inputs...)
+		cs.ToBinary(res.Limbs[i], nbBits)
+	}
+
+	f.assertIsZero(cs, f.Sub(cs, f.Mul(cs, a, res), f.Constant(cs, big.NewInt(1))))
+	return res
+}
+
+// Reduce returns an element equal to a modulo p, whose limbs are range checked to 64 bits
+func (f *Field) Reduce(cs *frontend.ConstraintSystem, a Element) Element {
+	if a.overflow == 0 && len(a.Limbs) == f.NbLimbs() {
+		return a
+	}
+
+	inputs := variables(a.Limbs)
+	r := make([]frontend.Variable, f.NbLimbs())
+	for i := 0; i < f.NbLimbs(); i++ {
+		r[i] = f.hint(cs, remHint, i, inputs...)
+		cs.ToBinary(r[i], nbBits)
+	}
+
+	f.checkZero(cs, a, f.quotient(cs, a), r)
+	return Element{Limbs: r}
+}
+
+// AssertIsEqual asserts that a = b modulo p
+func (f *Field) AssertIsEqual(cs *frontend.ConstraintSystem, a, b Element) {
+	f.assertIsZero(cs, f.Sub(cs, a, b))
+}
+
+// AssertIsCanonical asserts that the reduced element a is less than p
+//
+// it checks a+c = p-1, where c is computed by a hint and range checked
+func (f *Field) AssertIsCanonical(cs *frontend.ConstraintSystem, a Element) {
+	inputs := variables(a.Limbs)
+	c := Element{Limbs: make([]frontend.Variable, f.NbLimbs())}
+	for i := 0; i < f.NbLimbs(); i++ {
+		c.Limbs[i] = f.hint(cs, complementHint, i, inputs...)
+		cs.ToBinary(c.Limbs[i], nbBits)
+	}
+
+	var pMinusOne big.Int
+	pMinusOne.Sub(&f.modulus, big.NewInt(1))
+	f.checkZero(cs, f.Add(cs, a, c), nil, f.Constant(cs, &pMinusOne).Limbs)
+}
+
+// assertIsZero asserts that a = 0 modulo p
+func (f *Field) assertIsZero(cs *frontend.ConstraintSystem, a Element) {
+	f.checkZero(cs, a, f.quotient(cs, a), nil)
+}
+
+// quotient returns the limbs of q = a / p (integer division), computed by a hint and range checked
+func (f *Field) quotient(cs *frontend.ConstraintSystem, a Element) []frontend.Variable {
+	// a < 2^(nbBits*len(a.Limbs)+a.overflow+1) and p >= 2^(p.BitLen()-1)
+	nbQuotientBits := nbBits*len(a.Limbs) + a.overflow + 2 - f.modulus.BitLen()
+	if nbQuotientBits <= 0 {
+		return nil
+	}
+
+	inputs := variables(a.Limbs)
+	q := make([]frontend.Variable, (nbQuotientBits+nbBits-1)/nbBits)
+	for i := 0; i < len(q); i++ {
+		q[i] = f.hint(cs, remHint, f.NbLimbs()+i, inputs...)
+		cs.ToBinary(q[i], min(nbBits, nbQuotientBits-i*nbBits))
+	}
+	return q
+}
+
+// checkZero asserts that a - q*p - r = 0 (as integers, r may be nil)
+//
+// the coefficients d_k of the polynomial a - q*p - r are computed limb per limb, and the carries
+// c_k = (d_k + c_{k-1}) / 2^nbBits are constrained to be small integers (1 constraint and a range
+// check per limb), the last coefficient d_n + c_{n-1} must then be 0.
+func (f *Field) checkZero(cs *frontend.ConstraintSystem, a Element, q, r []frontend.Variable) {
+	d := make([]frontend.Variable, max(len(a.Limbs), len(q)+f.NbLimbs()-1, f.NbLimbs()))
+	for i := 0; i < len(d); i++ {
+		d[i] = cs.Constant(0)
+	}
+	for i := 0; i < len(a.Limbs); i++ {
+		d[i] = cs.Add(d[i], a.Limbs[i])
+	}
+	for i := 0; i < len(q); i++ {
+		for j := 0; j < f.NbLimbs(); j++ {
+			d[i+j] = cs.Sub(d[i+j], cs.Mul(q[i], f.limbs[j]))
+		}
+	}
+	for i := 0; i < len(r); i++ {
+		d[i] = cs.Sub(d[i], r[i])
+	}
+
+	// |d_k| < 2^nbDiffBits, and |c_k| < 2^(nbDiffBits-nbBits+1)
+	nbDiffBits := max(nbBits+a.overflow, 2*nbBits+bits.Len(uint(f.NbLimbs()))) + 1
+	var shift, offset big.Int
+	shift.Lsh(big.NewInt(1), nbBits)
+	offset.Lsh(big.NewInt(1), uint(nbDiffBits-nbBits+1))
+
+	carry := cs.Constant(0)
+	for i := 0; i < len(d)-1; i++ {
+		carry = cs.Div(cs.Add(d[i], carry), shift)
+		cs.ToBinary(cs.Add(carry, offset), nbDiffBits-nbBits+2)
+	}
+	cs.AssertIsEqual(cs.Add(d[len(d)-1], carry), 0)
+}
+
+// reduceLargest reduces the operand with the largest overflow
+func (f *Field) reduceLargest(cs *frontend.ConstraintSystem, a, b Element) (Element, Element) {
+	if a.overflow >= b.overflow {
+		return f.Reduce(cs, a), b
+	}
+	return a, f.Reduce(cs, b)
+}
+
+// padding returns the limbs of a multiple of p, on n >= NbLimbs() limbs, which are at least
+// 2^(nbBits+overflow) and less than 2^(nbBits+overflow+1)
+func (f *Field) padding(overflow, n int) []big.Int {
+	n = max(n, f.NbLimbs())
+
+	// t = sum 2^(nbBits+overflow)*2^(nbBits*i), u = t + (-t mod p)
+	var t, m big.Int
+	for i := 0; i < n; i++ {
+		t.Lsh(&t, nbBits).Add(&t, new(big.Int).Lsh(big.NewInt(1), uint(nbBits+overflow)))
+	}
+	m.Neg(&t).Mod(&m, &f.modulus)
+
+	u := decompose(&m, n)
+	for i := 0; i < n; i++ {
+		u[i].Add(&u[i], new(big.Int).Lsh(big.NewInt(1), uint(nbBits+overflow)))
+	}
+	return u
+}
+
+// mulBits returns the number of bits of the limbs of a*b
+func mulBits(a, b Element) int {
+	return 2*nbBits + a.overflow + b.overflow + bits.Len(uint(min(len(a.Limbs), len(b.Limbs))-1))
+}
+
+// limb returns the limb i of a, or 0
+func limb(cs *frontend.ConstraintSystem, a Element, i int) frontend.Variable {
+	if i < len(a.Limbs) {
+		return a.Limbs[i]
+	}
+	return cs.Constant(0)
+}
+
+func variables(limbs []frontend.Variable) []interface{} {
+	res := make([]interface{}, len(limbs))
+	for i := 0; i < len(limbs); i++ {
+		res[i] = limbs[i]
+	}
+	return res
+}
+
+func max(a int, b ...int) int {
+	for _, v := range b {
+		if v > a {
+			a = v
+		}
+	}
+	return a
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
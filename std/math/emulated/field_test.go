@@ -0,0 +1,196 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emulated
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/hint"
+	"github.com/consensys/gnark/frontend"
+	bn256backend "github.com/consensys/gnark/internal/backend/bn256"
+	"github.com/consensys/gurvy"
+	fp_bls381 "github.com/consensys/gurvy/bls381/fp"
+	fp_bn256 "github.com/consensys/gurvy/bn256/fp"
+)
+
+type fieldCircuit struct {
+	A, B, Sum, Diff, Prod, Quo, Inv []frontend.Variable
+	field                           *Field
+}
+
+func newFieldCircuit(f *Field) fieldCircuit {
+	return fieldCircuit{
+		A:     make([]frontend.Variable, f.NbLimbs()),
+		B:     make([]frontend.Variable, f.NbLimbs()),
+		Sum:   make([]frontend.Variable, f.NbLimbs()),
+		Diff:  make([]frontend.Variable, f.NbLimbs()),
+		Prod:  make([]frontend.Variable, f.NbLimbs()),
+		Quo:   make([]frontend.Variable, f.NbLimbs()),
+		Inv:   make([]frontend.Variable, f.NbLimbs()),
+		field: f,
+	}
+}
+
+func (circuit *fieldCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	f := circuit.field
+	a := f.NewElement(cs, circuit.A...)
+	b := f.NewElement(cs, circuit.B...)
+
+	f.AssertIsEqual(cs, f.Add(cs, a, b), f.NewElement(cs, circuit.Sum...))
+	f.AssertIsEqual(cs, f.Sub(cs, a, b), f.NewElement(cs, circuit.Diff...))
+	f.AssertIsEqual(cs, f.Div(cs, a, b), f.NewElement(cs, circuit.Quo...))
+	f.AssertIsEqual(cs, f.Inverse(cs, b), f.NewElement(cs, circuit.Inv...))
+
+	prod := f.NewElement(cs, circuit.Prod...)
+	f.AssertIsEqual(cs, f.Mul(cs, a, b), prod)
+	f.AssertIsCanonical(cs, prod)
+
+	// (a*b)^3 - a is reduced on the way, and its reduction is equal
+	ab := f.Mul(cs, a, b)
+	res := f.Sub(cs, f.Mul(cs, f.Mul(cs, ab, ab), ab), a)
+	f.AssertIsEqual(cs, f.Reduce(cs, res), res)
+
+	return nil
+}
+
+func (circuit *fieldCircuit) assign(a, b, sum, diff, prod, quo, inv *big.Int) {
+	f := circuit.field
+	f.Assign(circuit.A, a)
+	f.Assign(circuit.B, b)
+	f.Assign(circuit.Sum, sum)
+	f.Assign(circuit.Diff, diff)
+	f.Assign(circuit.Prod, prod)
+	f.Assign(circuit.Quo, quo)
+	f.Assign(circuit.Inv, inv)
+}
+
+func TestField(t *testing.T) {
+	var secp256k1 big.Int
+	secp256k1.SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	moduli := []*big.Int{&secp256k1, fp_bn256.Modulus(), fp_bls381.Modulus()}
+
+	assert := groth16.NewAssert(t)
+	rnd := rand.New(rand.NewSource(0))
+
+	curves := []gurvy.ID{gurvy.BN256, gurvy.BLS381, gurvy.BLS377, gurvy.BW761}
+	for _, curve := range curves {
+		for _, p := range moduli {
+			f := NewField(p)
+			circuit := newFieldCircuit(f)
+			r1cs, err := frontend.Compile(curve, &circuit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var a, b, sum, diff, prod, quo, inv big.Int
+			a.Rand(rnd, p)
+			b.Sub(p, big.NewInt(1)) // largest element
+			sum.Add(&a, &b).Mod(&sum, p)
+			diff.Sub(&a, &b).Mod(&diff, p)
+			prod.Mul(&a, &b).Mod(&prod, p)
+			inv.ModInverse(&b, p)
+			quo.Mul(&a, &inv).Mod(&quo, p)
+
+			good := newFieldCircuit(f)
+			good.assign(&a, &b, &sum, &diff, &prod, &quo, &inv)
+			assert.SolvingSucceeded(r1cs, &good)
+
+			// wrong product
+			var wrong big.Int
+			wrong.Add(&prod, big.NewInt(1))
+			bad := newFieldCircuit(f)
+			bad.assign(&a, &b, &sum, &diff, &wrong, &quo, &inv)
+			assert.SolvingFailed(r1cs, &bad)
+
+			// product equal modulo p, but not canonical
+			wrong.Add(&prod, p)
+			if wrong.BitLen() <= 64*f.NbLimbs() {
+				bad := newFieldCircuit(f)
+				bad.assign(&a, &b, &sum, &diff, &wrong, &quo, &inv)
+				assert.SolvingFailed(r1cs, &bad)
+			}
+		}
+	}
+}
+
+type inverseCircuit struct {
+	A, B  []frontend.Variable
+	field *Field
+	div   bool
+}
+
+func (circuit *inverseCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	f := circuit.field
+	a := f.NewElement(cs, circuit.A...)
+	b := f.NewElement(cs, circuit.B...)
+	if circuit.div {
+		f.Div(cs, a, b)
+	} else {
+		f.Inverse(cs, b)
+	}
+	return nil
+}
+
+// anyHint is a dishonest invHint, which returns 1 instead of failing
+func anyHint(_ gurvy.ID, inputs []*big.Int, result *big.Int) error {
+	if inputs[0].Sign() == 0 {
+		result.SetUint64(1)
+	} else {
+		result.SetUint64(0)
+	}
+	return nil
+}
+
+// TestInverseOfZero checks that Inverse(0) and Div(0, 0) are not satisfiable, whatever the hints return
+func TestInverseOfZero(t *testing.T) {
+	f := NewField(fp_bn256.Modulus())
+	assert := groth16.NewAssert(t)
+
+	for _, div := range []bool{false, true} {
+		newCircuit := func() inverseCircuit {
+			return inverseCircuit{
+				A:     make([]frontend.Variable, f.NbLimbs()),
+				B:     make([]frontend.Variable, f.NbLimbs()),
+				field: f,
+				div:   div,
+			}
+		}
+		circuit := newCircuit()
+		r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the prover doesn't have to run invHint
+		hints := r1cs.(*bn256backend.R1CS).Hints
+		for i := 0; i < len(hints); i++ {
+			if hints[i].ID == hint.UUID(invHint) {
+				hints[i].ID = hint.Register(anyHint)
+			}
+		}
+
+		for _, b := range []*big.Int{big.NewInt(0), f.Modulus()} {
+			bad := newCircuit()
+			f.Assign(bad.A, big.NewInt(0))
+			f.Assign(bad.B, b)
+			assert.SolvingFailed(r1cs, &bad)
+		}
+	}
+}
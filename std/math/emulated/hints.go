@@ -0,0 +1,122 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emulated
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark/backend/hint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gurvy"
+)
+
+var errNotInvertible = errors.New("element is not invertible")
+
+// the hints are registered, so that a deserialized R1CS can be solved
+func init() {
+	hint.Register(remHint)
+	hint.Register(invHint)
+	hint.Register(complementHint)
+}
+
+// hint returns the limb index of the output of h, on inputs (index, n, p_0, ..., p_{n-1}, in...)
+func (f *Field) hint(cs *frontend.ConstraintSystem, h hint.Function, index int, in ...interface{}) frontend.Variable {
+	inputs := []interface{}{index, f.NbLimbs()}
+	for i := 0; i < f.NbLimbs(); i++ {
+		inputs = append(inputs, f.limbs[i])
+	}
+	return cs.NewHint(h, append(inputs, in...)...)
+}
+
+// remHint computes the limb inputs[0] of the remainder r and the quotient q of the euclidean
+// division of a by p (the limbs 0 to n-1 are the limbs of r, the next ones are the limbs of q)
+//
+// inputs = (index, n, p_0, ..., p_{n-1}, a_0, a_1, ...)
+func remHint(_ gurvy.ID, inputs []*big.Int, result *big.Int) error {
+	index, n := int(inputs[0].Uint64()), int(inputs[1].Uint64())
+	p := recompose(inputs[2 : 2+n])
+	a := recompose(inputs[2+n:])
+
+	var q, r big.Int
+	q.DivMod(&a, &p, &r)
+	if index < n {
+		result.Set(limbOf(&r, index))
+	} else {
+		result.Set(limbOf(&q, index-n))
+	}
+	return nil
+}
+
+// invHint computes the limb inputs[0] of 1/a modulo p
+//
+// inputs = (index, n, p_0, ..., p_{n-1}, a_0, a_1, ...)
+func invHint(_ gurvy.ID, inputs []*big.Int, result *big.Int) error {
+	index, n := int(inputs[0].Uint64()), int(inputs[1].Uint64())
+	p := recompose(inputs[2 : 2+n])
+	a := recompose(inputs[2+n:])
+
+	var inv big.Int
+	if inv.ModInverse(&a, &p) == nil {
+		return errNotInvertible
+	}
+	result.Set(limbOf(&inv, index))
+	return nil
+}
+
+// complementHint computes the limb inputs[0] of p-1-a
+//
+// inputs = (index, n, p_0, ..., p_{n-1}, a_0, a_1, ...)
+func complementHint(_ gurvy.ID, inputs []*big.Int, result *big.Int) error {
+	index, n := int(inputs[0].Uint64()), int(inputs[1].Uint64())
+	p := recompose(inputs[2 : 2+n])
+	a := recompose(inputs[2+n:])
+
+	var c big.Int
+	c.Sub(&p, big.NewInt(1)).Sub(&c, &a)
+	if c.Sign() < 0 {
+		c.SetUint64(0) // a isn't canonical, the circuit will not be satisfied
+	}
+	result.Set(limbOf(&c, index))
+	return nil
+}
+
+// decompose returns the n limbs of v
+func decompose(v *big.Int, n int) []big.Int {
+	res := make([]big.Int, n)
+	for i := 0; i < n; i++ {
+		res[i].Set(limbOf(v, i))
+	}
+	return res
+}
+
+// recompose returns sum limbs_i*2^(nbBits*i), the limbs may be larger than 2^nbBits
+func recompose(limbs []*big.Int) big.Int {
+	var res big.Int
+	for i := len(limbs) - 1; i >= 0; i-- {
+		res.Lsh(&res, nbBits).Add(&res, limbs[i])
+	}
+	return res
+}
+
+// limbOf returns the limb i of v
+func limbOf(v *big.Int, i int) *big.Int {
+	var res big.Int
+	res.Rsh(v, uint(nbBits*i))
+	res.And(&res, new(big.Int).SetUint64(^uint64(0)))
+	return &res
+}
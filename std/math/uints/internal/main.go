@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/consensys/bavard"
+)
+
+//go:generate go run main.go uint_template.go
+func main() {
+
+	// -----------------------------------------------------
+	// U8, U32, U64
+	for _, width := range []int{8, 32, 64} {
+		d := templateData{
+			Name:  fmt.Sprintf("U%d", width),
+			Type:  fmt.Sprintf("uint%d", width),
+			Width: width,
+		}
+		if err := bavard.Generate(fmt.Sprintf("../u%d.go", width), []string{uintTemplate}, d,
+			bavard.Package("uints"),
+			bavard.Apache2(copyrightHolder, 2020),
+			bavard.GeneratedBy("gnark"),
+			bavard.Format(false),
+			bavard.Import(false),
+		); err != nil {
+			panic(err)
+		}
+	}
+
+	// run go fmt on the generated files
+	cmd := exec.Command("gofmt", "-s", "-w", "../")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		panic(err)
+	}
+}
+
+// templateData meta data for template generation
+type templateData struct {
+	Name  string // name of the type
+	Type  string // Go unsigned integer of the same width
+	Width int
+}
+
+const copyrightHolder = "ConsenSys Software Inc."
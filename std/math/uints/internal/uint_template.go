@@ -0,0 +1,88 @@
+package main
+
+const uintTemplate = `
+
+import "github.com/consensys/gnark/frontend"
+
+// {{.Name}} is a {{.Width}} bits unsigned integer, stored as its bits in little endian
+type {{.Name}} [{{.Width}}]frontend.Variable
+
+// Assign assigns v to u, to build a witness
+func (u *{{.Name}}) Assign(v {{.Type}}) {
+	assign(u[:], uint64(v))
+}
+
+// SetConstant sets u to the constant v (no constraint is recorded)
+func (u *{{.Name}}) SetConstant(cs *frontend.ConstraintSystem, v {{.Type}}) *{{.Name}} {
+	constant(cs, u[:], uint64(v))
+	return u
+}
+
+// SetVariable sets u to the bits of v, and ensures that v < 2**{{.Width}}
+func (u *{{.Name}}) SetVariable(cs *frontend.ConstraintSystem, v frontend.Variable) *{{.Name}} {
+	fromVariable(cs, u[:], v)
+	return u
+}
+
+// ToVariable packs u in a frontend.Variable (no constraint is recorded if the bits are boolean constrained)
+func (u {{.Name}}) ToVariable(cs *frontend.ConstraintSystem) frontend.Variable {
+	return cs.FromBinary(u[:]...)
+}
+
+// Add sets u to the sum of the operands modulo 2**{{.Width}}
+func (u *{{.Name}}) Add(cs *frontend.ConstraintSystem, a, b {{.Name}}, c ...{{.Name}}) *{{.Name}} {
+	operands := [][]frontend.Variable{a[:], b[:]}
+	for i := 0; i < len(c); i++ {
+		operands = append(operands, c[i][:])
+	}
+	add(cs, u[:], operands...)
+	return u
+}
+
+// Xor sets u to a ^ b
+func (u *{{.Name}}) Xor(cs *frontend.ConstraintSystem, a, b {{.Name}}) *{{.Name}} {
+	xor(cs, u[:], a[:], b[:])
+	return u
+}
+
+// And sets u to a & b
+func (u *{{.Name}}) And(cs *frontend.ConstraintSystem, a, b {{.Name}}) *{{.Name}} {
+	and(cs, u[:], a[:], b[:])
+	return u
+}
+
+// Or sets u to a | b
+func (u *{{.Name}}) Or(cs *frontend.ConstraintSystem, a, b {{.Name}}) *{{.Name}} {
+	or(cs, u[:], a[:], b[:])
+	return u
+}
+
+// Not sets u to ^a
+func (u *{{.Name}}) Not(cs *frontend.ConstraintSystem, a {{.Name}}) *{{.Name}} {
+	not(cs, u[:], a[:])
+	return u
+}
+
+// RotateLeft sets u to a rotated by k bits to the left (or to the right if k < 0)
+func (u *{{.Name}}) RotateLeft(cs *frontend.ConstraintSystem, a {{.Name}}, k int) *{{.Name}} {
+	rotateLeft(u[:], a[:], k)
+	return u
+}
+
+// RotateRight sets u to a rotated by k bits to the right
+func (u *{{.Name}}) RotateRight(cs *frontend.ConstraintSystem, a {{.Name}}, k int) *{{.Name}} {
+	rotateLeft(u[:], a[:], -k)
+	return u
+}
+
+// ShiftRight sets u to a >> k
+func (u *{{.Name}}) ShiftRight(cs *frontend.ConstraintSystem, a {{.Name}}, k int) *{{.Name}} {
+	shiftRight(cs, u[:], a[:], k)
+	return u
+}
+
+// MustBeEqual constrains u to be equal to other
+func (u {{.Name}}) MustBeEqual(cs *frontend.ConstraintSystem, other {{.Name}}) {
+	mustBeEqual(cs, u[:], other[:])
+}
+`
@@ -0,0 +1,127 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package uints provides fixed width unsigned integers (U8, U32, U64) for gnark circuits.
+//
+// An integer is stored as its bits, in little endian (first bit = lsb), such that the bitwise
+// operations and the rotations are cheap. The bits of a circuit input are boolean constrained
+// the first time they are used. U8, U32 and U64 are generated from the same template (see
+// internal/uint_template.go), their methods call the functions of this file on the slice of bits.
+//
+// Constraint counts (n is the width of the integer, assuming the bits are already boolean constrained):
+//
+//	Xor, And, Or: n
+//	Not, RotateLeft, RotateRight, ShiftRight: 0
+//	Add of k integers: n + bits.Len(k-1) + 1
+//	SetVariable: n + 1
+//	MustBeEqual: 1
+package uints
+
+import (
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// constant sets res to the bits of v
+//
+// the bits are flagged boolean, such that they are not boolean constrained again
+func constant(cs *frontend.ConstraintSystem, res []frontend.Variable, v uint64) {
+	one := cs.Constant(1)
+	zero := cs.Not(one) // no constraint is recorded
+	for i := 0; i < len(res); i++ {
+		if (v>>uint(i))&1 == 1 {
+			res[i] = one
+		} else {
+			res[i] = zero
+		}
+	}
+}
+
+// assign assigns the bits of v to res, to build a witness
+func assign(res []frontend.Variable, v uint64) {
+	for i := 0; i < len(res); i++ {
+		res[i].Assign((v >> uint(i)) & 1)
+	}
+}
+
+// fromVariable sets res to the bits of v, and ensures that v < 2**len(res)
+func fromVariable(cs *frontend.ConstraintSystem, res []frontend.Variable, v frontend.Variable) {
+	copy(res, cs.ToBinary(v, len(res)))
+}
+
+// add sets res to the sum of the operands, modulo 2**len(res)
+func add(cs *frontend.ConstraintSystem, res []frontend.Variable, operands ...[]frontend.Variable) {
+	sum := cs.Constant(0)
+	for _, op := range operands {
+		sum = cs.Add(sum, cs.FromBinary(op...)) // no constraint is recorded
+	}
+
+	// the carry is on bits.Len(len(operands)-1) bits
+	nbBits := len(res) + bits.Len(uint(len(operands)-1))
+	copy(res, cs.ToBinary(sum, nbBits))
+}
+
+func xor(cs *frontend.ConstraintSystem, res, a, b []frontend.Variable) {
+	for i := 0; i < len(res); i++ {
+		res[i] = cs.Xor(a[i], b[i])
+	}
+}
+
+func and(cs *frontend.ConstraintSystem, res, a, b []frontend.Variable) {
+	for i := 0; i < len(res); i++ {
+		res[i] = cs.And(a[i], b[i])
+	}
+}
+
+func or(cs *frontend.ConstraintSystem, res, a, b []frontend.Variable) {
+	for i := 0; i < len(res); i++ {
+		res[i] = cs.Or(a[i], b[i])
+	}
+}
+
+func not(cs *frontend.ConstraintSystem, res, a []frontend.Variable) {
+	for i := 0; i < len(res); i++ {
+		res[i] = cs.Not(a[i])
+	}
+}
+
+// rotateLeft sets res to a rotated by k bits to the left, res and a must not overlap
+func rotateLeft(res, a []frontend.Variable, k int) {
+	n := len(res)
+	k %= n
+	if k < 0 {
+		k += n
+	}
+	for i := 0; i < n; i++ {
+		res[(i+k)%n] = a[i]
+	}
+}
+
+// shiftRight sets res to a >> k, res and a must not overlap
+func shiftRight(cs *frontend.ConstraintSystem, res, a []frontend.Variable, k int) {
+	n := len(res)
+	if k > n {
+		k = n
+	}
+	copy(res, a[k:])
+	constant(cs, res[n-k:], 0)
+}
+
+// mustBeEqual compares the packed values, as the bits are boolean constrained (1 constraint)
+func mustBeEqual(cs *frontend.ConstraintSystem, a, b []frontend.Variable) {
+	cs.AssertIsEqual(cs.FromBinary(a...), cs.FromBinary(b...))
+}
@@ -0,0 +1,264 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uints
+
+import (
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gurvy"
+)
+
+type u32TestCircuit struct {
+	A, B, C U32
+	define  func(cs *frontend.ConstraintSystem, A, B U32) U32
+}
+
+func (circuit *u32TestCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	// boolean constrain the bits of the inputs, such that they are not counted in the operation
+	circuit.A.ToVariable(cs)
+	circuit.B.ToVariable(cs)
+	circuit.C.ToVariable(cs)
+
+	res := circuit.define(cs, circuit.A, circuit.B)
+	res.MustBeEqual(cs, circuit.C)
+	return nil
+}
+
+func TestU32(t *testing.T) {
+	testData := []struct {
+		name          string
+		define        func(cs *frontend.ConstraintSystem, A, B U32) U32
+		expected      func(a, b uint32) uint32
+		nbConstraints int // constraints of the operation
+	}{
+		{
+			name: "add",
+			define: func(cs *frontend.ConstraintSystem, A, B U32) U32 {
+				var res U32
+				res.Add(cs, A, B)
+				return res
+			},
+			expected:      func(a, b uint32) uint32 { return a + b },
+			nbConstraints: 34,
+		},
+		{
+			name: "add 4 operands",
+			define: func(cs *frontend.ConstraintSystem, A, B U32) U32 {
+				var res U32
+				res.Add(cs, A, B, A, B)
+				return res
+			},
+			expected:      func(a, b uint32) uint32 { return 2*a + 2*b },
+			nbConstraints: 35,
+		},
+		{
+			name: "add constant",
+			define: func(cs *frontend.ConstraintSystem, A, B U32) U32 {
+				var k, res U32
+				k.SetConstant(cs, 0x428a2f98)
+				res.Add(cs, A, k)
+				return res
+			},
+			expected:      func(a, b uint32) uint32 { return a + 0x428a2f98 },
+			nbConstraints: 35, // the constant bits are boolean constrained once, with the constant 1
+		},
+		{
+			name: "xor",
+			define: func(cs *frontend.ConstraintSystem, A, B U32) U32 {
+				var res U32
+				res.Xor(cs, A, B)
+				return res
+			},
+			expected:      func(a, b uint32) uint32 { return a ^ b },
+			nbConstraints: 32,
+		},
+		{
+			name: "and",
+			define: func(cs *frontend.ConstraintSystem, A, B U32) U32 {
+				var res U32
+				res.And(cs, A, B)
+				return res
+			},
+			expected:      func(a, b uint32) uint32 { return a & b },
+			nbConstraints: 32,
+		},
+		{
+			name: "or",
+			define: func(cs *frontend.ConstraintSystem, A, B U32) U32 {
+				var res U32
+				res.Or(cs, A, B)
+				return res
+			},
+			expected:      func(a, b uint32) uint32 { return a | b },
+			nbConstraints: 32,
+		},
+		{
+			name: "not",
+			define: func(cs *frontend.ConstraintSystem, A, B U32) U32 {
+				var res U32
+				res.Not(cs, A)
+				return res
+			},
+			expected:      func(a, b uint32) uint32 { return ^a },
+			nbConstraints: 0,
+		},
+		{
+			name: "rotate left",
+			define: func(cs *frontend.ConstraintSystem, A, B U32) U32 {
+				var res U32
+				res.RotateLeft(cs, A, 7)
+				return res
+			},
+			expected:      func(a, b uint32) uint32 { return bits.RotateLeft32(a, 7) },
+			nbConstraints: 0,
+		},
+		{
+			name: "rotate right",
+			define: func(cs *frontend.ConstraintSystem, A, B U32) U32 {
+				var res U32
+				res.RotateRight(cs, A, 13)
+				return res
+			},
+			expected:      func(a, b uint32) uint32 { return bits.RotateLeft32(a, -13) },
+			nbConstraints: 0,
+		},
+		{
+			name: "shift right",
+			define: func(cs *frontend.ConstraintSystem, A, B U32) U32 {
+				var res U32
+				res.ShiftRight(cs, A, 10)
+				return res
+			},
+			expected:      func(a, b uint32) uint32 { return a >> 10 },
+			nbConstraints: 1, // the constant bits are boolean constrained once, with the constant 1
+		},
+		{
+			name: "set variable",
+			define: func(cs *frontend.ConstraintSystem, A, B U32) U32 {
+				var res U32
+				res.SetVariable(cs, A.ToVariable(cs))
+				return res
+			},
+			expected:      func(a, b uint32) uint32 { return a },
+			nbConstraints: 33,
+		},
+	}
+
+	for _, tData := range testData {
+		t.Run(tData.name, func(t *testing.T) {
+			circuit := u32TestCircuit{define: tData.define}
+			r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// 3*32 boolean constraints for the inputs, and 1 for MustBeEqual
+			if got := int(r1cs.GetNbConstraints()) - 3*32 - 1; got != tData.nbConstraints {
+				t.Fatalf("expected %d constraints, got %d", tData.nbConstraints, got)
+			}
+
+			assert := groth16.NewAssert(t)
+			for i := 0; i < 4; i++ {
+				a, b := rand.Uint32(), rand.Uint32()
+
+				var good, bad u32TestCircuit
+				good.A.Assign(a)
+				good.B.Assign(b)
+				good.C.Assign(tData.expected(a, b))
+				assert.SolvingSucceeded(r1cs, &good)
+
+				bad.A.Assign(a)
+				bad.B.Assign(b)
+				bad.C.Assign(tData.expected(a, b) + 1)
+				assert.SolvingFailed(r1cs, &bad)
+			}
+		})
+	}
+}
+
+type u64AddCircuit struct {
+	A, B, C U64
+	Sum     U64 `gnark:",public"`
+}
+
+func (circuit *u64AddCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	var res U64
+	res.Add(cs, circuit.A, circuit.B, circuit.C)
+	res.MustBeEqual(cs, circuit.Sum)
+	return nil
+}
+
+func TestU64Add(t *testing.T) {
+	var circuit u64AddCircuit
+	r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, b, c := rand.Uint64(), rand.Uint64(), rand.Uint64()
+
+	var good, bad u64AddCircuit
+	good.A.Assign(a)
+	good.B.Assign(b)
+	good.C.Assign(c)
+	good.Sum.Assign(a + b + c)
+
+	bad.A.Assign(a)
+	bad.B.Assign(b)
+	bad.C.Assign(c)
+	bad.Sum.Assign(a + b)
+
+	assert := groth16.NewAssert(t)
+	assert.SolvingSucceeded(r1cs, &good)
+	assert.SolvingFailed(r1cs, &bad)
+}
+
+type u8Circuit struct {
+	X frontend.Variable
+	Y U8 `gnark:",public"`
+}
+
+func (circuit *u8Circuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	var x, res U8
+	x.SetVariable(cs, circuit.X)
+	res.Not(cs, x)
+	res.MustBeEqual(cs, circuit.Y)
+	return nil
+}
+
+func TestU8(t *testing.T) {
+	var circuit u8Circuit
+	r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var good, bad u8Circuit
+	good.X.Assign(0x35)
+	good.Y.Assign(0xca)
+
+	bad.X.Assign(0x35)
+	bad.Y.Assign(0x35)
+
+	assert := groth16.NewAssert(t)
+	assert.SolvingSucceeded(r1cs, &good)
+	assert.SolvingFailed(r1cs, &bad)
+}
@@ -0,0 +1,211 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ecdsa
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// secp256k1 short Weierstrass curve y^2 = x^3 + 7 over Fp, whose points form a group of prime order n
+type curve struct {
+	fp, fn *emulated.Field // base field and scalar field
+	g      [2]big.Int      // generator of the group
+	offset [2]big.Int      // initial value of the scalar multiplications, of unknown discrete logarithm
+	cancel [2]big.Int      // -(2^256-1)*offset, added at the end of the scalar multiplications
+}
+
+var secp256k1 curve
+var initOnce sync.Once
+
+// getSecp256k1 returns the parameters of secp256k1
+// cf https://www.secg.org/sec2-v2.pdf
+func getSecp256k1() *curve {
+	initOnce.Do(initSecp256k1)
+	return &secp256k1
+}
+
+func initSecp256k1() {
+	c := &secp256k1
+	var p, n big.Int
+	p.SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	n.SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	c.fp = emulated.NewField(&p)
+	c.fn = emulated.NewField(&n)
+	c.g[0].SetString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", 16)
+	c.g[1].SetString("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8", 16)
+
+	// offset is the first point whose x is at least sha256("gnark/std/signature/ecdsa")
+	h := sha256.Sum256([]byte("gnark/std/signature/ecdsa"))
+	x := new(big.Int).SetBytes(h[:])
+	for {
+		if y := new(big.Int).ModSqrt(c.rhs(x), c.fp.Modulus()); y != nil {
+			c.offset[0].Set(x)
+			c.offset[1].Set(y)
+			break
+		}
+		x.Add(x, big.NewInt(1))
+	}
+
+	// the scalar multiplications add the offset once per bit of the scalars
+	var k big.Int
+	k.Lsh(big.NewInt(1), nbScalarBits).Sub(&k, big.NewInt(1))
+	c.cancel = c.neg(c.scalarMul(c.offset, &k))
+}
+
+// rhs returns x^3 + 7 mod p
+func (c *curve) rhs(x *big.Int) *big.Int {
+	var res big.Int
+	res.Mul(x, x).Mul(&res, x).Add(&res, big.NewInt(7)).Mod(&res, c.fp.Modulus())
+	return &res
+}
+
+// add returns p1 + p2 (native), p1 and p2 are not the point at infinity, and p1 != -p2
+func (c *curve) add(p1, p2 [2]big.Int) [2]big.Int {
+	p := c.fp.Modulus()
+	var lambda, tmp big.Int
+	if p1[0].Cmp(&p2[0]) == 0 {
+		// lambda = 3*x1^2 / (2*y1)
+		lambda.Mul(&p1[0], &p1[0]).Mul(&lambda, big.NewInt(3))
+		tmp.Lsh(&p1[1], 1).ModInverse(&tmp, p)
+	} else {
+		// lambda = (y2-y1) / (x2-x1)
+		lambda.Sub(&p2[1], &p1[1])
+		tmp.Sub(&p2[0], &p1[0]).Mod(&tmp, p).ModInverse(&tmp, p)
+	}
+	lambda.Mul(&lambda, &tmp).Mod(&lambda, p)
+
+	var res [2]big.Int
+	res[0].Mul(&lambda, &lambda).Sub(&res[0], &p1[0]).Sub(&res[0], &p2[0]).Mod(&res[0], p)
+	res[1].Sub(&p1[0], &res[0]).Mul(&res[1], &lambda).Sub(&res[1], &p1[1]).Mod(&res[1], p)
+	return res
+}
+
+// neg returns -p1 (native)
+func (c *curve) neg(p1 [2]big.Int) [2]big.Int {
+	var res [2]big.Int
+	res[0].Set(&p1[0])
+	res[1].Sub(c.fp.Modulus(), &p1[1])
+	return res
+}
+
+// scalarMul returns s*p1 (native), s mod n must not be 0
+func (c *curve) scalarMul(p1 [2]big.Int, s *big.Int) [2]big.Int {
+	var k big.Int
+	k.Mod(s, c.fn.Modulus())
+
+	res := p1
+	for i := k.BitLen() - 2; i >= 0; i-- {
+		res = c.add(res, res)
+		if k.Bit(i) == 1 {
+			res = c.add(res, p1)
+		}
+	}
+	return res
+}
+
+// point of secp256k1 in affine coordinates, in the circuit
+type point struct {
+	x, y emulated.Element
+}
+
+func (c *curve) constantPoint(cs *frontend.ConstraintSystem, p1 [2]big.Int) point {
+	return point{c.fp.Constant(cs, &p1[0]), c.fp.Constant(cs, &p1[1])}
+}
+
+// assertIsOnCurve asserts that y^2 = x^3 + 7
+func (c *curve) assertIsOnCurve(cs *frontend.ConstraintSystem, p1 point) {
+	fp := c.fp
+	x3 := fp.Mul(cs, fp.Mul(cs, p1.x, p1.x), p1.x)
+	fp.AssertIsEqual(cs, fp.Mul(cs, p1.y, p1.y), fp.Add(cs, x3, fp.Constant(cs, big.NewInt(7))))
+}
+
+// addPoints returns p1 + p2 (reduced), p1 != ±p2 (incomplete formula, the division has no
+// solution if x1 = x2)
+func (c *curve) addPoints(cs *frontend.ConstraintSystem, p1, p2 point) point {
+	fp := c.fp
+
+	// lambda = (y2-y1) / (x2-x1)
+	lambda := fp.Div(cs, fp.Sub(cs, p2.y, p1.y), fp.Sub(cs, p2.x, p1.x))
+	return c.line(cs, p1, p2.x, lambda)
+}
+
+// double returns 2*p1 (reduced), y1 != 0 (the division has no solution otherwise)
+func (c *curve) double(cs *frontend.ConstraintSystem, p1 point) point {
+	fp := c.fp
+
+	// lambda = 3*x1^2 / (2*y1)
+	xx := fp.Mul(cs, p1.x, p1.x)
+	lambda := fp.Div(cs, fp.Add(cs, fp.Add(cs, xx, xx), xx), fp.Add(cs, p1.y, p1.y))
+	return c.line(cs, p1, p1.x, lambda)
+}
+
+// line returns the third point of the curve on the line through p1 of slope lambda, whose
+// x coordinates are x1 and x2, negated: x3 = lambda^2 - x1 - x2, y3 = lambda*(x1-x3) - y1
+func (c *curve) line(cs *frontend.ConstraintSystem, p1 point, x2, lambda emulated.Element) point {
+	fp := c.fp
+	var res point
+	res.x = fp.Reduce(cs, fp.Sub(cs, fp.Sub(cs, fp.Mul(cs, lambda, lambda), p1.x), x2))
+	res.y = fp.Reduce(cs, fp.Sub(cs, fp.Mul(cs, lambda, fp.Sub(cs, p1.x, res.x)), p1.y))
+	return res
+}
+
+// lookup2 returns t[b0+2*b1], the points of t are reduced
+func (c *curve) lookup2(cs *frontend.ConstraintSystem, b0, b1 frontend.Variable, t [4]point) point {
+	res := point{
+		x: emulated.Element{Limbs: make([]frontend.Variable, len(t[0].x.Limbs))},
+		y: emulated.Element{Limbs: make([]frontend.Variable, len(t[0].y.Limbs))},
+	}
+	for i := 0; i < len(res.x.Limbs); i++ {
+		res.x.Limbs[i] = cs.Lookup2(b0, b1, t[0].x.Limbs[i], t[1].x.Limbs[i], t[2].x.Limbs[i], t[3].x.Limbs[i])
+		res.y.Limbs[i] = cs.Lookup2(b0, b1, t[0].y.Limbs[i], t[1].y.Limbs[i], t[2].y.Limbs[i], t[3].y.Limbs[i])
+	}
+	return res
+}
+
+// doubleBaseScalarMul returns s1*G + s2*p2, the scalars are reduced elements of Fn (Shamir's trick)
+//
+// The additions use incomplete formulas: the accumulator starts at offset, which is added at each
+// step, such that it never meets the points of the table (unless the discrete logarithm of offset
+// is known). The sum of the offsets is cancelled at the end.
+func (c *curve) doubleBaseScalarMul(cs *frontend.ConstraintSystem, s1 emulated.Element, p2 point, s2 emulated.Element) point {
+	var s1Bits, s2Bits []frontend.Variable
+	for i := 0; i < len(s1.Limbs); i++ {
+		s1Bits = append(s1Bits, cs.ToBinary(s1.Limbs[i], 64)...)
+		s2Bits = append(s2Bits, cs.ToBinary(s2.Limbs[i], 64)...)
+	}
+
+	// offset + {0, G, p2, G+p2}
+	var t [4]point
+	t[0] = c.constantPoint(cs, c.offset)
+	t[1] = c.constantPoint(cs, c.add(c.offset, c.g))
+	t[2] = c.addPoints(cs, t[0], p2)
+	t[3] = c.addPoints(cs, t[1], p2)
+
+	n := len(s1Bits)
+	res := c.lookup2(cs, s1Bits[n-1], s2Bits[n-1], t)
+	for i := n - 2; i >= 0; i-- {
+		res = c.double(cs, res)
+		res = c.addPoints(cs, res, c.lookup2(cs, s1Bits[i], s2Bits[i], t))
+	}
+
+	return c.addPoints(cs, res, c.constantPoint(cs, c.cancel))
+}
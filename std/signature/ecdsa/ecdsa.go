@@ -0,0 +1,81 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ecdsa verifies ECDSA signatures over secp256k1 in a circuit
+//
+// The base field and the scalar field of secp256k1 are not the scalar field of the circuit: their
+// elements are represented in 64 bits limbs, and their arithmetic is emulated with the integers of
+// the circuit (see std/math/emulated). The verification of a signature costs about 1.7M constraints.
+package ecdsa
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// nbScalarBits is the size of the scalars of the scalar multiplications
+const nbScalarBits = 256
+
+// Element is an integer less than 2^256, in little endian 64 bits limbs (to be used in gnark circuit)
+type Element [4]frontend.Variable
+
+// Assign assigns the limbs of v (less than 2^256) to e
+func (e *Element) Assign(v *big.Int) {
+	getSecp256k1().fp.Assign(e[:], v)
+}
+
+// PublicKey stores a secp256k1 public key (to be used in gnark circuit)
+type PublicKey struct {
+	X, Y Element
+}
+
+// Signature stores an ecdsa signature (to be used in gnark circuit)
+type Signature struct {
+	R, S Element
+}
+
+// Verify verifies an ecdsa signature of the hash of a message, over secp256k1
+// cf https://en.wikipedia.org/wiki/Elliptic_Curve_Digital_Signature_Algorithm
+//
+// msgHash is the hash of the message, truncated to 256 bits. The limbs of the inputs are range
+// checked, r and s must be canonical (less than the order n), and the public key is checked to be
+// on the curve.
+func Verify(cs *frontend.ConstraintSystem, sig Signature, msgHash Element, pubKey PublicKey) {
+	c := getSecp256k1()
+	fp, fn := c.fp, c.fn
+
+	r := fn.NewElement(cs, sig.R[:]...)
+	s := fn.NewElement(cs, sig.S[:]...)
+	z := fn.NewElement(cs, msgHash[:]...)
+	q := point{fp.NewElement(cs, pubKey.X[:]...), fp.NewElement(cs, pubKey.Y[:]...)}
+	c.assertIsOnCurve(cs, q)
+
+	// r and s are in [1, n-1]: canonical, and invertible modulo n
+	fn.AssertIsCanonical(cs, r)
+	fn.AssertIsCanonical(cs, s)
+	fn.Inverse(cs, r)
+	sInv := fn.Inverse(cs, s)
+
+	// u1 = z/s, u2 = r/s
+	u1 := fn.Reduce(cs, fn.Mul(cs, z, sInv))
+	u2 := fn.Reduce(cs, fn.Mul(cs, r, sInv))
+
+	// (x, y) = u1*G + u2*Q, x mod n = r
+	res := c.doubleBaseScalarMul(cs, u1, q, u2)
+	fp.AssertIsCanonical(cs, res.x)
+	fn.AssertIsEqual(cs, res.x, r)
+}
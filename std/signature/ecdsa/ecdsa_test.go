@@ -0,0 +1,228 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ecdsa
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gurvy"
+)
+
+func TestCurve(t *testing.T) {
+	c := getSecp256k1()
+
+	for _, p := range [][2]big.Int{c.g, c.offset, c.cancel} {
+		var y2 big.Int
+		y2.Mul(&p[1], &p[1]).Mod(&y2, c.fp.Modulus())
+		if y2.Cmp(c.rhs(&p[0])) != 0 {
+			t.Fatal("point should be on the curve")
+		}
+	}
+
+	// (n-1)*G = -G
+	var k big.Int
+	k.Sub(c.fn.Modulus(), big.NewInt(1))
+	g, negG := c.scalarMul(c.g, &k), c.neg(c.g)
+	if g[0].Cmp(&negG[0]) != 0 || g[1].Cmp(&negG[1]) != 0 {
+		t.Fatal("G should be of order n")
+	}
+}
+
+type curveCircuit struct {
+	P, Q     PublicKey
+	Sum, Dbl PublicKey
+}
+
+func (circuit *curveCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	c := getSecp256k1()
+	fp := c.fp
+	newPoint := func(p PublicKey) point {
+		return point{fp.NewElement(cs, p.X[:]...), fp.NewElement(cs, p.Y[:]...)}
+	}
+	p, q := newPoint(circuit.P), newPoint(circuit.Q)
+	sum, dbl := newPoint(circuit.Sum), newPoint(circuit.Dbl)
+
+	c.assertIsOnCurve(cs, p)
+	res := c.addPoints(cs, p, q)
+	fp.AssertIsEqual(cs, res.x, sum.x)
+	fp.AssertIsEqual(cs, res.y, sum.y)
+	res = c.double(cs, p)
+	fp.AssertIsEqual(cs, res.x, dbl.x)
+	fp.AssertIsEqual(cs, res.y, dbl.y)
+
+	return nil
+}
+
+// assign sets the witness of the circuit
+func (circuit *curveCircuit) assign(p, q, sum, dbl [2]big.Int) {
+	for _, v := range []struct {
+		pub *PublicKey
+		p   [2]big.Int
+	}{{&circuit.P, p}, {&circuit.Q, q}, {&circuit.Sum, sum}, {&circuit.Dbl, dbl}} {
+		v.pub.X.Assign(&v.p[0])
+		v.pub.Y.Assign(&v.p[1])
+	}
+}
+
+// TestCurveGadget checks the point arithmetic used by Verify on a few points, it runs in short mode
+func TestCurveGadget(t *testing.T) {
+	c := getSecp256k1()
+	p := c.scalarMul(c.g, big.NewInt(3))
+	q := c.scalarMul(c.g, big.NewInt(5))
+
+	var circuit curveCircuit
+	r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// pOff = (x, y+1) isn't on the curve, the sum and the double are computed with the same formulas
+	var pOff [2]big.Int
+	pOff[0].Set(&p[0])
+	pOff[1].Add(&p[1], big.NewInt(1))
+
+	var good, wrongSum, offCurve curveCircuit
+	good.assign(p, q, c.scalarMul(c.g, big.NewInt(8)), c.scalarMul(c.g, big.NewInt(6)))
+	wrongSum.assign(p, q, c.scalarMul(c.g, big.NewInt(9)), c.scalarMul(c.g, big.NewInt(6)))
+	offCurve.assign(pOff, q, c.add(pOff, q), c.add(pOff, pOff))
+
+	assert := groth16.NewAssert(t)
+	assert.SolvingSucceeded(r1cs, &good)
+	assert.SolvingFailed(r1cs, &wrongSum)
+	assert.SolvingFailed(r1cs, &offCurve)
+}
+
+type ecdsaCircuit struct {
+	PublicKey PublicKey `gnark:",public"`
+	Signature Signature `gnark:",public"`
+	MsgHash   Element   `gnark:",public"`
+}
+
+func (circuit *ecdsaCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	Verify(cs, circuit.Signature, circuit.MsgHash, circuit.PublicKey)
+	return nil
+}
+
+// sign returns the public key of the private key d, and the signature of z with nonce k
+func sign(d, z, k *big.Int) (pub [2]big.Int, r, s big.Int) {
+	c := getSecp256k1()
+	n := c.fn.Modulus()
+
+	pub = c.scalarMul(c.g, d)
+	R := c.scalarMul(c.g, k)
+
+	// r = R.x mod n, s = (z + r*d) / k mod n
+	r.Mod(&R[0], n)
+	s.Mul(&r, d).Add(&s, z).Mod(&s, n)
+	s.Mul(&s, new(big.Int).ModInverse(k, n)).Mod(&s, n)
+	return
+}
+
+// signSmall returns a public key, and a signature (r, s) of z valid for this key, where r is the smallest x
+// coordinate of a point R of the curve, such that r+n < 2^256
+//
+// the public key is Q = (s*R - z*G) / r, such that z/s*G + r/s*Q = R (the private key is not known)
+func signSmall(z, s *big.Int) (pub [2]big.Int, r big.Int) {
+	c := getSecp256k1()
+	n := c.fn.Modulus()
+
+	var R [2]big.Int
+	for x := int64(1); ; x++ {
+		R[0].SetInt64(x)
+		if R[1].ModSqrt(c.rhs(&R[0]), c.fp.Modulus()) != nil {
+			break
+		}
+	}
+	r.Set(&R[0])
+
+	var rInv, u1, u2 big.Int
+	rInv.ModInverse(&r, n)
+	u1.Mul(s, &rInv).Mod(&u1, n)
+	u2.Mul(z, &rInv).Neg(&u2).Mod(&u2, n)
+	pub = c.add(c.scalarMul(R, &u1), c.scalarMul(c.g, &u2))
+	return
+}
+
+// assign sets the witness of the circuit
+func (circuit *ecdsaCircuit) assign(pubX, pubY, r, s, z *big.Int) {
+	circuit.PublicKey.X.Assign(pubX)
+	circuit.PublicKey.Y.Assign(pubY)
+	circuit.Signature.R.Assign(r)
+	circuit.Signature.S.Assign(s)
+	circuit.MsgHash.Assign(z)
+}
+
+// TestVerify compiles and solves the whole verification circuit (about 1.7M constraints, 2 minutes),
+// it is skipped in short mode (as in CI) and runs with go test ./std/signature/ecdsa
+func TestVerify(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping ecdsa verification in short mode")
+	}
+
+	c := getSecp256k1()
+	n := c.fn.Modulus()
+	rnd := rand.New(rand.NewSource(0))
+	var d, z, k big.Int
+	d.Rand(rnd, n)
+	z.Rand(rnd, n)
+	k.Rand(rnd, n)
+	pub, r, s := sign(&d, &z, &k)
+
+	var circuit ecdsaCircuit
+	r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log("nb constraints", r1cs.GetNbConstraints())
+
+	assert := groth16.NewAssert(t)
+
+	var good, wrongHash, zeroR, zeroS, offCurve ecdsaCircuit
+	var zPlusOne, pubYPlusOne big.Int
+	zPlusOne.Add(&z, big.NewInt(1))
+	pubYPlusOne.Add(&pub[1], big.NewInt(1))
+	good.assign(&pub[0], &pub[1], &r, &s, &z)
+	wrongHash.assign(&pub[0], &pub[1], &r, &s, &zPlusOne)
+	zeroR.assign(&pub[0], &pub[1], big.NewInt(0), &s, &z)
+	zeroS.assign(&pub[0], &pub[1], &r, big.NewInt(0), &z)
+	offCurve.assign(&pub[0], &pubYPlusOne, &r, &s, &z)
+
+	assert.SolvingSucceeded(r1cs, &good)
+	assert.SolvingFailed(r1cs, &wrongHash)
+	assert.SolvingFailed(r1cs, &zeroR)
+	assert.SolvingFailed(r1cs, &zeroS)
+	assert.SolvingFailed(r1cs, &offCurve)
+
+	// r and s are small, r+n and s+n are less than 2^256 and equal to r and s modulo n
+	var goodSmall, rPlusN, sPlusN ecdsaCircuit
+	sSmall := big.NewInt(3)
+	pubSmall, rSmall := signSmall(&z, sSmall)
+	var rSmallPlusN, sSmallPlusN big.Int
+	rSmallPlusN.Add(&rSmall, n)
+	sSmallPlusN.Add(sSmall, n)
+	goodSmall.assign(&pubSmall[0], &pubSmall[1], &rSmall, sSmall, &z)
+	rPlusN.assign(&pubSmall[0], &pubSmall[1], &rSmallPlusN, sSmall, &z)
+	sPlusN.assign(&pubSmall[0], &pubSmall[1], &rSmall, &sSmallPlusN, &z)
+
+	assert.SolvingSucceeded(r1cs, &goodSmall)
+	assert.SolvingFailed(r1cs, &rPlusN)
+	assert.SolvingFailed(r1cs, &sPlusN)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eddsa
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/twistededwards"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// nbRandomBits is the size of the random coefficients of the linear combination
+const nbRandomBits = 128
+
+var errBatchSize = errors.New("the number of signatures, messages and public keys must be the same, and positive")
+
+// BatchVerify verifies a batch of eddsa signatures, whose public keys are on the same curve
+//
+// instead of checking cofactor*S_i*B = cofactor*(R_i + H(R_i,A_i,M_i)*A_i) for each signature, it checks
+// a random linear combination of the equations
+//
+//	cofactor*(sum z_i*R_i + sum (z_i*H(R_i,A_i,M_i))*A_i - (sum z_i*S_i)*B) = 0
+//
+// where the z_i are nbRandomBits bits coefficients derived by hashing the batch (Fiat-Shamir), such
+// that a batch containing an invalid signature passes with probability about 2^-nbRandomBits.
+//
+// The scalars are computed as integers (not reduced modulo the order of the curve). The R_i and A_i terms
+// are a single multi-scalar multiplication, so that the doublings are shared by all the signatures, and
+// the generator, a constant, contributes once through the windowed fixed-base multiplication. The cofactor
+// multiplication is done once for the whole batch.
+func BatchVerify(cs *frontend.ConstraintSystem, sigs []Signature, msgs []frontend.Variable, pubKeys []PublicKey) error {
+	if len(sigs) == 0 || len(sigs) != len(msgs) || len(sigs) != len(pubKeys) {
+		return errBatchSize
+	}
+	curve := pubKeys[0].Curve
+
+	// the points are given by the prover, the addition formulas hold only on the curve
+	for i := 0; i < len(sigs); i++ {
+		sigs[i].R.A.MustBeOnCurve(cs, curve)
+		pubKeys[i].A.MustBeOnCurve(cs, curve)
+	}
+
+	hash, err := mimc.NewMiMC("seed", curve.ID)
+	if err != nil {
+		return err
+	}
+
+	// H(R_i, A_i, M_i), and the seed of the random coefficients
+	hram := make([]frontend.Variable, len(sigs))
+	for i := 0; i < len(sigs); i++ {
+		hram[i] = hash.Hash(cs, sigs[i].R.A.X, sigs[i].R.A.Y, pubKeys[i].A.X, pubKeys[i].A.Y, msgs[i])
+	}
+	transcript := append([]frontend.Variable{}, hram...)
+	for i := 0; i < len(sigs); i++ {
+		transcript = append(transcript, sigs[i].S)
+	}
+	seed := hash.Hash(cs, transcript...)
+
+	nbBits := curve.Modulus.BitLen()
+	points := make([]twistededwards.Point, 0, 2*len(sigs))
+	scalars := make([][]frontend.Variable, 0, 2*len(sigs))
+	z := make([]frontend.Variable, len(sigs))
+	s := make([][]frontend.Variable, len(sigs))
+	for i := 0; i < len(sigs); i++ {
+		// z_i, the nbRandomBits least significant bits of H(seed, i)
+		zBits := cs.ToBinary(hash.Hash(cs, seed, cs.Constant(i)), nbBits)[:nbRandomBits]
+		z[i] = cs.FromBinary(zBits...)
+
+		// z_i*R_i and (z_i*H(R_i,A_i,M_i))*A_i
+		points = append(points, sigs[i].R.A, pubKeys[i].A)
+		scalars = append(scalars, zBits, linearCombination(cs, z[i:i+1], [][]frontend.Variable{cs.ToBinary(hram[i], nbBits)}))
+
+		s[i] = cs.ToBinary(sigs[i].S, nbBits)
+	}
+
+	var res twistededwards.Point
+	res.MultiScalarMul(cs, points, scalars, curve)
+
+	// -(sum z_i*S_i)*B, B is a constant point
+	var negBaseX big.Int
+	negBaseX.Sub(&curve.Modulus, &curve.BaseX)
+	var sB twistededwards.Point
+	sB.ScalarMulFixedBaseBits(cs, negBaseX, curve.BaseY, linearCombination(cs, z, s), curve)
+	res.AddGeneric(cs, &res, &sB, curve)
+
+	// cofactor*res = (0, 1)
+	cofactor := curve.Cofactor
+	acc := res
+	for i := cofactor.BitLen() - 2; i >= 0; i-- {
+		acc.Double(cs, &acc, curve)
+		if cofactor.Bit(i) == 1 {
+			acc.AddGeneric(cs, &acc, &res, curve)
+		}
+	}
+	cs.AssertIsEqual(acc.X, 0)
+	cs.AssertIsEqual(acc.Y, 1)
+
+	return nil
+}
+
+// linearCombination returns the binary decomposition of the integer sum z_i*x_i, where the z_i are less than
+// 2^nbRandomBits and the x_i are given in binary, on the bit size of the field
+//
+// the x_i are split in limbs, such that the partial sums (and the carry of the previous limb) don't overflow
+// the field.
+func linearCombination(cs *frontend.ConstraintSystem, z []frontend.Variable, x [][]frontend.Variable) []frontend.Variable {
+	nbBits := len(x[0])
+	nbCarryBits := bits.Len(uint(len(z)))
+
+	// a partial sum (with the carry) is less than 2^(nbRandomBits+limb+nbCarryBits+1) < 2^(nbBits-1)
+	limb := nbBits - nbRandomBits - nbCarryBits - 2
+
+	res := make([]frontend.Variable, 0, nbBits+nbRandomBits+nbCarryBits)
+	carry := cs.Constant(0)
+	for start := 0; start < nbBits; start += limb {
+		end := start + limb
+		if end > nbBits {
+			end = nbBits
+		}
+		sum := carry
+		for i := 0; i < len(z); i++ {
+			sum = cs.Add(sum, cs.Mul(z[i], cs.FromBinary(x[i][start:end]...)))
+		}
+		sumBits := cs.ToBinary(sum, nbRandomBits+end-start+nbCarryBits+1)
+		if end == nbBits {
+			return append(res, sumBits...)
+		}
+		res = append(res, sumBits[:limb]...)
+		carry = cs.FromBinary(sumBits[limb:]...)
+	}
+	return res
+}
@@ -17,15 +17,14 @@ limitations under the License.
 package eddsa
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark/backend/groth16"
-	mimc_bn256 "github.com/consensys/gnark/crypto/hash/mimc/bn256"
-	eddsa_bn256 "github.com/consensys/gnark/crypto/signature/eddsa/bn256"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/testutil"
 	"github.com/consensys/gnark/std/algebra/twistededwards"
 	"github.com/consensys/gurvy"
-	fr_bn256 "github.com/consensys/gurvy/bn256/fr"
 )
 
 type eddsaCircuit struct {
@@ -35,7 +34,7 @@ type eddsaCircuit struct {
 }
 
 func (circuit *eddsaCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
-	params, err := twistededwards.NewEdCurve(gurvy.BN256)
+	params, err := twistededwards.NewEdCurve(curveID)
 	if err != nil {
 		return err
 	}
@@ -47,71 +46,174 @@ func (circuit *eddsaCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSys
 	return nil
 }
 
-func TestEddsa(t *testing.T) {
+const (
+	msg      = "44717650746155748460101257525078853138837311576962212923649547644148297035978"
+	wrongMsg = "44717650746155748460101257525078853138837311576962212923649547644148297035979"
+)
 
-	assert := groth16.NewAssert(t)
+// assign sets the witness of the circuit from a signature computed with the crypto lib
+func (circuit *eddsaCircuit) assign(message string, pubX, pubY, rX, rY, s interface{}) {
+	circuit.Message.Assign(message)
+	circuit.PublicKey.A.X.Assign(pubX)
+	circuit.PublicKey.A.Y.Assign(pubY)
+	circuit.Signature.R.A.X.Assign(rX)
+	circuit.Signature.R.A.Y.Assign(rY)
+	circuit.Signature.S.Assign(s)
+}
 
+// signedWitnesses returns a witness with a valid signature of msg, and the same signature
+// with wrongMsg
+func signedWitnesses(t *testing.T, curveID gurvy.ID) (good, bad eddsaCircuit) {
 	var seed [32]byte
-	s := []byte("eddsa")
-	for i, v := range s {
-		seed[i] = v
-	}
-
-	hFunc := mimc_bn256.NewMiMC("seed")
+	copy(seed[:], "eddsa")
 
 	// generate eddsa witnesses from the crypto lib
-	pubKey, privKey := eddsa_bn256.New(seed, hFunc)
-
-	var frMsg fr_bn256.Element
-	frMsg.SetString("44717650746155748460101257525078853138837311576962212923649547644148297035978")
-	msgBin := frMsg.Bytes()
-	signature, err := eddsa_bn256.Sign(msgBin[:], pubKey, privKey)
-	if err != nil {
-		t.Fatal(err)
-	}
-	res, err := eddsa_bn256.Verify(signature, msgBin[:], pubKey)
+	var m big.Int
+	m.SetString(msg, 10)
+	sig, err := testutil.SignEdDSA(curveID, seed, &m)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if !res {
-		t.Fatal("Verifying the signature should return true")
-	}
+	good.assign(msg, sig.A[0], sig.A[1], sig.R[0], sig.R[1], sig.S)
+	bad.assign(wrongMsg, sig.A[0], sig.A[1], sig.R[0], sig.R[1], sig.S)
+	return
+}
 
-	var circuit eddsaCircuit
-	r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
-	if err != nil {
-		t.Fatal(err)
-	}
+func TestEddsa(t *testing.T) {
+
+	assert := groth16.NewAssert(t)
 
-	// verification with the correct Message
-	{
-		var witness eddsaCircuit
-		witness.Message.Assign(frMsg)
+	curves := []gurvy.ID{gurvy.BN256, gurvy.BLS381, gurvy.BLS377, gurvy.BW761}
+	for _, curve := range curves {
+		var circuit eddsaCircuit
+		r1cs, err := frontend.Compile(curve, &circuit)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-		witness.PublicKey.A.X.Assign(pubKey.A.X)
-		witness.PublicKey.A.Y.Assign(pubKey.A.Y)
+		good, bad := signedWitnesses(t, curve)
 
-		witness.Signature.R.A.X.Assign(signature.R.X)
-		witness.Signature.R.A.Y.Assign(signature.R.Y)
+		// verification with the correct Message
+		assert.SolvingSucceeded(r1cs, &good)
 
-		witness.Signature.S.Assign(signature.S)
+		// verification with incorrect Message
+		assert.SolvingFailed(r1cs, &bad)
+	}
+}
+
+const batchSize = 3
+
+type batchCircuit struct {
+	PublicKeys [batchSize]PublicKey         `gnark:",public"`
+	Signatures [batchSize]Signature         `gnark:",public"`
+	Messages   [batchSize]frontend.Variable `gnark:",public"`
+}
 
-		assert.SolvingSucceeded(r1cs, &witness)
+func (circuit *batchCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	params, err := twistededwards.NewEdCurve(curveID)
+	if err != nil {
+		return err
 	}
+	for i := 0; i < batchSize; i++ {
+		circuit.PublicKeys[i].Curve = params
+	}
+	return BatchVerify(cs, circuit.Signatures[:], circuit.Messages[:], circuit.PublicKeys[:])
+}
 
-	// verification with incorrect Message
-	{
-		var witness eddsaCircuit
-		witness.Message.Assign("44717650746155748460101257525078853138837311576962212923649547644148297035979")
+// batchSigner returns the message i+1, signed with the i-th key of a batch
+func batchSigner(t *testing.T, curveID gurvy.ID, i int) (big.Int, testutil.Signature) {
+	var seed [32]byte
+	seed[0] = byte(i)
 
-		witness.PublicKey.A.X.Assign(pubKey.A.X)
-		witness.PublicKey.A.Y.Assign(pubKey.A.Y)
+	msg := big.NewInt(int64(i + 1))
+	sig, err := testutil.SignEdDSA(curveID, seed, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *msg, sig
+}
 
-		witness.Signature.R.A.X.Assign(signature.R.X)
-		witness.Signature.R.A.Y.Assign(signature.R.Y)
+// rootOfUnity returns y such that y^order = 1 and y^2 != 1 modulo p (order is a power of 2, 4 <= order | p-1)
+func rootOfUnity(p *big.Int, order uint64) *big.Int {
+	var e, y, half big.Int
+	e.Sub(p, big.NewInt(1)).Div(&e, new(big.Int).SetUint64(order))
+	for g := int64(2); ; g++ {
+		y.Exp(big.NewInt(g), &e, p)
+		if half.Exp(&y, new(big.Int).SetUint64(order/2), p).Cmp(big.NewInt(1)) != 0 {
+			return &y
+		}
+	}
+}
 
-		witness.Signature.S.Assign(signature.S)
+func TestBatchVerify(t *testing.T) {
+
+	assert := groth16.NewAssert(t)
 
-		assert.SolvingFailed(r1cs, &witness)
+	curves := []gurvy.ID{gurvy.BN256, gurvy.BLS381, gurvy.BLS377, gurvy.BW761}
+	for _, curve := range curves {
+		var circuit, good, wrongMsg, swappedS, offCurve batchCircuit
+		r1cs, err := frontend.Compile(curve, &circuit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var msg, pubX, pubY, rX, rY, s [batchSize]interface{}
+		for i := 0; i < batchSize; i++ {
+			m, sig := batchSigner(t, curve, i)
+			msg[i], pubX[i], pubY[i], rX[i], rY[i], s[i] = m, sig.A[0], sig.A[1], sig.R[0], sig.R[1], sig.S
+		}
+		assign := func(w *batchCircuit, msg, pubX, pubY, rX, rY, s [batchSize]interface{}) {
+			for i := 0; i < batchSize; i++ {
+				w.PublicKeys[i].A.X.Assign(pubX[i])
+				w.PublicKeys[i].A.Y.Assign(pubY[i])
+				w.Signatures[i].R.A.X.Assign(rX[i])
+				w.Signatures[i].R.A.Y.Assign(rY[i])
+				w.Signatures[i].S.Assign(s[i])
+				w.Messages[i].Assign(msg[i])
+			}
+		}
+		assign(&good, msg, pubX, pubY, rX, rY, s)
+
+		// the message of the second signature is wrong
+		badMsg := msg
+		badMsg[1] = 42
+		assign(&wrongMsg, badMsg, pubX, pubY, rX, rY, s)
+
+		// the S parts of the first two signatures are swapped
+		badS := s
+		badS[0], badS[1] = s[1], s[0]
+		assign(&swappedS, msg, pubX, pubY, rX, rY, badS)
+
+		// the points are the identity (0, 1), except R_1 = (0, y) which isn't on the curve, and S = 0
+		//
+		// the addition formulas send (0, y) + (x', y') to (y*x', y*y'), so the multi-scalar multiplication
+		// is (0, y^z_1) and (sum z_i*S_i)*B is (0, 1): with y^cofactor = 1, only the on-curve check fails
+		params, err := twistededwards.NewEdCurve(curve)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var zero, one [batchSize]interface{}
+		for i := 0; i < batchSize; i++ {
+			zero[i], one[i] = 0, 1
+		}
+		offCurveRY := one
+		offCurveRY[1] = rootOfUnity(&params.Modulus, params.Cofactor.Uint64())
+		assign(&offCurve, msg, zero, one, zero, offCurveRY, zero)
+
+		assert.SolvingSucceeded(r1cs, &good)
+		assert.SolvingFailed(r1cs, &wrongMsg)
+		assert.SolvingFailed(r1cs, &swappedS)
+		assert.SolvingFailed(r1cs, &offCurve)
+
+		// the batch is at least 1.5 times cheaper than the individual verifications
+		var single eddsaCircuit
+		r1csSingle, err := frontend.Compile(curve, &single)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if 3*r1cs.GetNbConstraints() > 2*batchSize*r1csSingle.GetNbConstraints() {
+			t.Fatalf("%s, batch of %d signatures: %d constraints, single signature: %d constraints",
+				curve.String(), batchSize, r1cs.GetNbConstraints(), r1csSingle.GetNbConstraints())
+		}
 	}
 }
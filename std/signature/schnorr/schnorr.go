@@ -0,0 +1,70 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schnorr
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/twistededwards"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// PublicKey stores a schnorr public key (to be used in gnark circuit)
+type PublicKey struct {
+	A     twistededwards.Point
+	Curve twistededwards.EdCurve
+}
+
+// Signature stores a signature (to be used in gnark circuit)
+type Signature struct {
+	E, S frontend.Variable
+}
+
+// Verify verifies a schnorr signature, as computed by crypto/signature/schnorr
+// cf https://en.wikipedia.org/wiki/Schnorr_signature
+//
+// it checks that E = H(S*B + E*A, A, M), where B is the base point of the curve. Unlike eddsa,
+// the hash binds the whole point R = S*B + E*A, so no cofactor multiplication is needed. As in
+// the native Verify, the public key is checked to be on the curve.
+func Verify(cs *frontend.ConstraintSystem, sig Signature, msg frontend.Variable, pubKey PublicKey) error {
+
+	// the Edwards formulas are only sound on the curve: (0, 0) for instance absorbs any point
+	pubKey.A.MustBeOnCurve(cs, pubKey.Curve)
+
+	// R = S*B + E*A
+	R := twistededwards.Point{}
+	eA := twistededwards.Point{}
+	R.ScalarMulFixedBase(cs, pubKey.Curve.BaseX, pubKey.Curve.BaseY, sig.S, pubKey.Curve)
+	eA.ScalarMulNonFixedBase(cs, &pubKey.A, sig.E, pubKey.Curve)
+	R.AddGeneric(cs, &R, &eA, pubKey.Curve)
+
+	// compute H(R, A, M), all parameters in data are in Montgomery form
+	data := []frontend.Variable{
+		R.X,
+		R.Y,
+		pubKey.A.X,
+		pubKey.A.Y,
+		msg,
+	}
+
+	hash, err := mimc.NewMiMC("seed", pubKey.Curve.ID)
+	if err != nil {
+		return err
+	}
+	cs.AssertIsEqual(hash.Hash(cs, data...), sig.E)
+
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schnorr
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark/backend/groth16"
+	mimc_bn256 "github.com/consensys/gnark/crypto/hash/mimc/bn256"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/testutil"
+	"github.com/consensys/gnark/std/algebra/twistededwards"
+	"github.com/consensys/gurvy"
+	fr_bn256 "github.com/consensys/gurvy/bn256/fr"
+)
+
+type schnorrCircuit struct {
+	PublicKey PublicKey         `gnark:",public"`
+	Signature Signature         `gnark:",public"`
+	Message   frontend.Variable `gnark:",public"`
+}
+
+func (circuit *schnorrCircuit) Define(curveID gurvy.ID, cs *frontend.ConstraintSystem) error {
+	params, err := twistededwards.NewEdCurve(curveID)
+	if err != nil {
+		return err
+	}
+	circuit.PublicKey.Curve = params
+
+	// verify the signature in the cs
+	Verify(cs, circuit.Signature, circuit.Message, circuit.PublicKey)
+
+	return nil
+}
+
+const (
+	msg      = "44717650746155748460101257525078853138837311576962212923649547644148297035978"
+	wrongMsg = "44717650746155748460101257525078853138837311576962212923649547644148297035979"
+)
+
+// assign sets the witness of the circuit from a signature computed with the crypto lib
+func (circuit *schnorrCircuit) assign(message string, pubX, pubY, e, s interface{}) {
+	circuit.Message.Assign(message)
+	circuit.PublicKey.A.X.Assign(pubX)
+	circuit.PublicKey.A.Y.Assign(pubY)
+	circuit.Signature.E.Assign(e)
+	circuit.Signature.S.Assign(s)
+}
+
+// signedWitnesses returns a witness with a valid signature of msg, and the same signature
+// with wrongMsg
+func signedWitnesses(t *testing.T, curveID gurvy.ID) (good, bad schnorrCircuit) {
+	var seed [32]byte
+	copy(seed[:], "schnorr")
+
+	// generate schnorr witnesses from the crypto lib
+	var m big.Int
+	m.SetString(msg, 10)
+	sig, err := testutil.SignSchnorr(curveID, seed, &m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	good.assign(msg, sig.A[0], sig.A[1], sig.E, sig.S)
+	bad.assign(wrongMsg, sig.A[0], sig.A[1], sig.E, sig.S)
+	return
+}
+
+// offCurveWitness returns a witness with the public key A = (0, 0), which is not on the curve
+//
+// the Edwards formulas send (0, 0) + P to (0, 0), so S*B + E*A = (0, 0) for any S, and
+// E = H((0, 0), A, M) would verify without the on-curve check
+func offCurveWitness(t *testing.T) (res schnorrCircuit) {
+	var frMsg fr_bn256.Element
+	frMsg.SetString(msg)
+	msgBin := frMsg.Bytes()
+
+	h := mimc_bn256.NewMiMC("seed")
+	if _, err := h.Write(append(make([]byte, 4*fr_bn256.Bytes), msgBin[:]...)); err != nil {
+		t.Fatal(err)
+	}
+	var e big.Int
+	e.SetBytes(h.Sum(nil))
+
+	res.assign(msg, 0, 0, e, 1)
+	return
+}
+
+func TestSchnorrOffCurve(t *testing.T) {
+	var circuit schnorrCircuit
+	r1cs, err := frontend.Compile(gurvy.BN256, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	witness := offCurveWitness(t)
+	groth16.NewAssert(t).SolvingFailed(r1cs, &witness)
+}
+
+func TestSchnorr(t *testing.T) {
+
+	assert := groth16.NewAssert(t)
+
+	curves := []gurvy.ID{gurvy.BN256, gurvy.BLS381, gurvy.BLS377, gurvy.BW761}
+	for _, curve := range curves {
+		var circuit schnorrCircuit
+		r1cs, err := frontend.Compile(curve, &circuit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		good, bad := signedWitnesses(t, curve)
+
+		// verification with the correct Message
+		assert.SolvingSucceeded(r1cs, &good)
+
+		// verification with incorrect Message
+		assert.SolvingFailed(r1cs, &bad)
+	}
+}